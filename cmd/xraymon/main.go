@@ -4,6 +4,8 @@
 package main
 
 import (
+	"time"
+
 	"github.com/eterline/xraymon/internal/app/xraymon"
 	"github.com/eterline/xraymon/internal/config"
 	"github.com/eterline/xraymon/internal/infra/log"
@@ -36,7 +38,35 @@ var (
 		Core: config.Core{
 			CoreAccess: "core_access.log",
 			CoreLog:    "core_logging.log",
+			CoreStderr: "core_stderr.log",
 			ConfigFile: "settings.json",
+			CoreAPI:    config.APIUnix,
+			CoresDir:   "cores",
+			StopGrace:  5 * time.Second,
+
+			ConfigRevisions: 50,
+
+			AttachProcess: "xray",
+
+			CoreLogLevel: "warning",
+		},
+		Restart: config.Restart{
+			RestartDelay:      10 * time.Second,
+			RestartMultiplier: 2,
+			RestartMaxDelay:   5 * time.Minute,
+			RestartJitter:     0.2,
+			RestartMaxCrashes: 10,
+			RestartReset:      5 * time.Minute,
+			RollbackGrace:     30 * time.Second,
+			CrashHistory:      32,
+			DrainTimeout:      time.Minute,
+			DrainThreshold:    1024,
+			DrainInterval:     time.Second,
+		},
+		Health: config.Health{
+			HealthInterval: 15 * time.Second,
+			HealthTimeout:  5 * time.Second,
+			HealthFailures: 3,
 		},
 	}
 )
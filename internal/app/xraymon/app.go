@@ -9,11 +9,13 @@ import (
 
 	"github.com/eterline/xraymon/internal/config"
 	"github.com/eterline/xraymon/internal/infra/log"
+	"github.com/eterline/xraymon/internal/infra/sandbox"
+	"github.com/eterline/xraymon/internal/infra/sdnotify"
 	xraycommon "github.com/eterline/xraymon/internal/infra/xray/common"
 	"github.com/eterline/xraymon/internal/interface/grpc/commands"
 	"github.com/eterline/xraymon/internal/interface/grpc/server"
-	"github.com/eterline/xraymon/internal/usecase/manager"
-	"github.com/eterline/xraymon/internal/usecase/statspool"
+	"github.com/eterline/xraymon/internal/usecase/eventbus"
+	"github.com/eterline/xraymon/internal/usecase/scheduler"
 	"github.com/eterline/xraymon/pkg/toolkit"
 	"google.golang.org/grpc"
 )
@@ -40,52 +42,95 @@ func Execute(root *toolkit.AppStarter, flags InitFlags, conf config.Configuratio
 
 	// ========================================================
 
-	log.Info("init base xray settings file", "file", conf.ConfigFile)
-	cfgExporter, err := xraycommon.NewConfigFileProvider(conf.ConfigFile)
+	// read before any core is spawned, the environment is cleared for children
+	notifier, err := sdnotify.FromEnv()
 	if err != nil {
-		log.Error("failed init config provider", "file", conf.ConfigFile, "error", err)
+		log.Error("failed init systemd notify", "error", err)
 		root.MustStopApp(1)
 	}
-	defer cfgExporter.Close()
 
-	log.Info("init access logger", "file", conf.CoreAccess)
-	accessLog, err := xraycommon.NewAccessLogger(conf.CoreAccess)
+	instConfs, err := conf.Instances()
 	if err != nil {
-		log.Error("failed init access logger", "file", conf.CoreAccess, "error", err)
+		log.Error("failed load core instances", "error", err)
 		root.MustStopApp(1)
 	}
-	defer accessLog.Close()
 
-	log.Info("init core logger", "file", conf.CoreLog)
-	coreLog, err := xraycommon.NewCoreLogger(conf.CoreLog)
+	// version checks execute uploaded binaries, they run with the core credentials and rlimits
+	binConf := sandboxConfig(conf.Sandbox)
+	binConf.Cgroup = ""
+
+	binSandbox, err := sandbox.New(binConf, "")
 	if err != nil {
-		log.Error("failed init core logger", "file", conf.CoreLog, "error", err)
+		log.Error("failed init core binaries sandbox", "error", err)
 		root.MustStopApp(1)
 	}
-	defer coreLog.Close()
+	defer binSandbox.Close()
 
-	// ========================================================
+	log.Info("init core binaries registry", "dir", conf.CoresDir, "restrictions", binSandbox.String())
+	cores, err := xraycommon.NewCoreRegistry(conf.CoresDir, xraycommon.WithVersionSandbox(binSandbox))
+	if err != nil {
+		log.Error("failed init core binaries registry", "error", err)
+		root.MustStopApp(1)
+	}
 
-	dsp := xraycommon.NewXrayDispatcher(accessLog, coreLog)
-	coreMg := manager.NewCoreManager(ctx, dsp, cfgExporter, coreLog, "warning")
+	schedConf, err := conf.Schedule()
+	if err != nil {
+		log.Error("failed load schedule", "error", err)
+		root.MustStopApp(1)
+	}
 
-	root.WrapWorker(func() {
-		log.Info("starting core")
-		err := coreMg.Start()
+	jobs, err := scheduleJobs(schedConf)
+	if err != nil {
+		log.Error("invalid schedule", "error", err)
+		root.MustStopApp(1)
+	}
+
+	events := eventbus.New(log)
+	instances := commands.NewInstances()
+	targets := make(map[string]scheduler.Target, len(instConfs))
+	insts := make([]*coreInstance, 0, len(instConfs))
+
+	for _, ic := range instConfs {
+		ci, err := newCoreInstance(ctx, ic, conf, events, cores.Instance(ic.Name))
 		if err != nil {
-			slog.Error("start core failed", "error", err)
+			log.Error("failed init core instance", "instance", ic.Name, "error", err)
+			root.MustStopApp(1)
 		}
-	})
+		defer ci.Close()
 
-	statProv, err := xraycommon.NewStatsProvider()
-	if err != nil {
-		log.Error("failed init stats provider", "error", err)
-		root.MustStopApp(1)
+		if err := instances.Add(ci.name, &ci.handles); err != nil {
+			log.Error("failed register core instance", "instance", ci.name, "error", err)
+			root.MustStopApp(1)
+		}
+
+		root.WrapWorker(func() {
+			log.Info("starting core", "instance", ci.name)
+			err := ci.manager.Start()
+			if err != nil {
+				slog.Error("start core failed", "instance", ci.name, "error", err)
+			}
+
+			// keeps the app alive until the core is stopped and reaped
+			<-ci.manager.Done()
+		})
+
+		ci.stats.Start(ci.ctx)
+		defer ci.stats.Stop()
+
+		targets[ci.name] = scheduleTarget{ci}
+		insts = append(insts, ci)
 	}
 
-	statsPool := statspool.NewStatsPool(statProv, 5*time.Second, log)
-	statsPool.Start(ctx)
-	defer statsPool.Stop()
+	sched := scheduler.New(targets, log, events)
+	for _, job := range jobs {
+		if err := sched.Add(job); err != nil {
+			log.Error("failed add scheduled job", "error", err)
+			root.MustStopApp(1)
+		}
+	}
+
+	sched.Start(ctx)
+	defer sched.Stop()
 
 	// ========================================================
 
@@ -108,12 +153,21 @@ func Execute(root *toolkit.AppStarter, flags InitFlags, conf config.Configuratio
 
 	// ==========
 
-	coreManage := commands.NewCoreManageHandlers(cfgExporter, cfgExporter, coreMg, log)
+	coreManage := commands.NewCoreManageHandlers(instances, log)
 	commands.RegisterCoreManagmentServiceServer(grpcSrv, coreManage)
 
-	jrnl := commands.NewJournalHandlers(accessLog, coreLog, statsPool, log)
+	jrnl := commands.NewJournalHandlers(instances, log)
 	commands.RegisterJournalProviderServer(grpcSrv, jrnl)
 
+	bins := commands.NewBinaryHandlers(cores, instances, log)
+	commands.RegisterCoreBinaryProviderServer(grpcSrv, bins)
+
+	schd := commands.NewScheduleHandlers(sched, log)
+	commands.RegisterScheduleProviderServer(grpcSrv, schd)
+
+	evts := commands.NewEventHandlers(events, log)
+	commands.RegisterEventProviderServer(grpcSrv, evts)
+
 	// ==========
 
 	srv, err := server.NewGrpcServerWrapper(grpcSrv, conf.Listen)
@@ -131,6 +185,11 @@ func Execute(root *toolkit.AppStarter, flags InitFlags, conf config.Configuratio
 	})
 	defer srv.Close()
 
+	// the listener is bound by now, READY waits for the cores
+	root.WrapWorker(func() {
+		notifySystemd(ctx, notifier, insts, log)
+	})
+
 	// ========================================================
 
 	root.WaitWorkers(10 * time.Second)
@@ -0,0 +1,262 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package xraymon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/eterline/xraymon/internal/config"
+	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/infra/log"
+	"github.com/eterline/xraymon/internal/infra/procfs"
+	"github.com/eterline/xraymon/internal/infra/sandbox"
+	xraycommon "github.com/eterline/xraymon/internal/infra/xray/common"
+	"github.com/eterline/xraymon/internal/interface/grpc/commands"
+	"github.com/eterline/xraymon/internal/usecase/eventbus"
+	"github.com/eterline/xraymon/internal/usecase/manager"
+	"github.com/eterline/xraymon/internal/usecase/statspool"
+)
+
+// coreInstance - supervised core with its own config provider, journals, stats pool and API endpoint.
+type coreInstance struct {
+	name    string
+	ctx     context.Context
+	manager *manager.CoreManager
+	stats   *statspool.StatsPool
+	handles commands.Instance
+
+	closers []io.Closer
+}
+
+// Close - releases instance resources in reverse order of creation.
+func (ci *coreInstance) Close() {
+	for i := len(ci.closers) - 1; i >= 0; i-- {
+		ci.closers[i].Close()
+	}
+}
+
+func restartPolicy(conf config.Restart) manager.RestartPolicy {
+	return manager.RestartPolicy{
+		InitialDelay: conf.RestartDelay,
+		Multiplier:   conf.RestartMultiplier,
+		MaxDelay:     conf.RestartMaxDelay,
+		Jitter:       conf.RestartJitter,
+		MaxCrashes:   conf.RestartMaxCrashes,
+		ResetAfter:   conf.RestartReset,
+	}
+}
+
+func drainPolicy(conf config.Restart) manager.DrainPolicy {
+	return manager.DrainPolicy{
+		Timeout:   conf.DrainTimeout,
+		Threshold: conf.DrainThreshold,
+		Interval:  conf.DrainInterval,
+	}
+}
+
+func sandboxConfig(conf config.Sandbox) sandbox.Config {
+	return sandbox.Config{
+		User:         conf.CoreUser,
+		Group:        conf.CoreGroup,
+		NoFile:       conf.CoreNoFile,
+		AddressSpace: conf.CoreAS,
+		Cgroup:       conf.CoreCgroup,
+		MemoryMax:    conf.CoreMemoryMax,
+		CPUMax:       conf.CoreCPUMax,
+	}
+}
+
+func healthPolicy(conf config.Health) manager.HealthPolicy {
+	return manager.HealthPolicy{
+		Interval: conf.HealthInterval,
+		Timeout:  conf.HealthTimeout,
+		Failures: conf.HealthFailures,
+	}
+}
+
+func newCoreInstance(ctx context.Context, inst config.Instance, conf config.Configuration, bus domain.EventPublisher, cores domain.CoreBinarySelector) (ci *coreInstance, err error) {
+	logger := log.MustLoggerFromContext(ctx).With("instance", inst.Name)
+	ctx = log.WrapLoggerToContext(ctx, logger)
+	events := eventbus.Named(bus, inst.Name)
+
+	ci = &coreInstance{
+		name: inst.Name,
+		ctx:  ctx,
+	}
+
+	defer func() {
+		if err != nil {
+			ci.Close()
+		}
+	}()
+
+	logger.Info("init access logger", "file", inst.CoreAccess)
+	accessLog, err := xraycommon.NewAccessLogger(inst.CoreAccess, events)
+	if err != nil {
+		return nil, fmt.Errorf("init access logger %q: %w", inst.CoreAccess, err)
+	}
+	ci.closers = append(ci.closers, accessLog)
+
+	logger.Info("init core logger", "file", inst.CoreLog)
+	coreLog, err := xraycommon.NewCoreLogger(inst.CoreLog, events)
+	if err != nil {
+		return nil, fmt.Errorf("init core logger %q: %w", inst.CoreLog, err)
+	}
+	ci.closers = append(ci.closers, coreLog)
+
+	logger.Info("init core stderr logger", "file", inst.StderrLog())
+	stderrLog, err := xraycommon.NewStderrLogger(inst.StderrLog(), events)
+	if err != nil {
+		return nil, fmt.Errorf("init core stderr logger %q: %w", inst.StderrLog(), err)
+	}
+	ci.closers = append(ci.closers, stderrLog)
+
+	// ========================================================
+
+	apiEndpoint, err := xraycommon.NewAPIEndpoint(inst.CoreAPI, conf.InstanceRuntimeDir(inst.Name))
+	if err != nil {
+		return nil, fmt.Errorf("init core api endpoint: %w", err)
+	}
+	ci.closers = append(ci.closers, apiEndpoint)
+
+	statProv := xraycommon.NewStatsProvider(apiEndpoint)
+	ci.stats = statspool.NewStatsPool(statProv, 5*time.Second, logger, events)
+
+	if inst.Attach != nil {
+		logger.Info("attached core", "api", apiEndpoint.Addr(), "pid_file", inst.Attach.PIDFile, "process", inst.Attach.Process)
+
+		ci.manager = attachedManager(ctx, inst, conf, events, accessLog, coreLog, statProv)
+		ci.handles.History = externalConfig{}
+		ci.handles.ConfLoad = externalConfig{}
+	} else {
+		logger.Info("init base xray settings file", "file", inst.ConfigFile)
+		cfgExporter, err := xraycommon.NewConfigFileProvider(
+			inst.ConfigFile, events,
+			xraycommon.WithRevisionRetention(conf.ConfigRevisions),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("init config provider %q: %w", inst.ConfigFile, err)
+		}
+		ci.closers = append(ci.closers, cfgExporter)
+
+		// a core left by a crashed xraymon still holds the API endpoint and the cgroup
+		pidFile := conf.InstancePIDFile(inst.Name)
+		if pid, err := xraycommon.TerminateStale(pidFile, conf.StopGrace); err != nil {
+			return nil, fmt.Errorf("stop stale core: %w", err)
+		} else if pid != 0 {
+			logger.Warn("stale core of a previous run stopped", "pid", pid)
+		}
+
+		sb, err := sandbox.New(sandboxConfig(conf.Sandbox), inst.Name)
+		if err != nil {
+			return nil, fmt.Errorf("init core sandbox: %w", err)
+		}
+		ci.closers = append(ci.closers, sb)
+		logger.Info("core sandbox", "restrictions", sb.String())
+
+		if uid, gid, ok := sb.Owner(); ok {
+			if err := apiEndpoint.SetOwner(uid, gid); err != nil {
+				return nil, fmt.Errorf("init core api endpoint: %w", err)
+			}
+		}
+		logger.Info("core api endpoint", "spec", inst.CoreAPI, "addr", apiEndpoint.Addr())
+
+		dsp := xraycommon.NewXrayDispatcher(
+			apiEndpoint, accessLog, coreLog, stderrLog,
+			xraycommon.WithSandbox(sb),
+			xraycommon.WithStopGrace(conf.StopGrace),
+			xraycommon.WithPIDFile(pidFile),
+		)
+		handlerProv := xraycommon.NewHandlerProvider(apiEndpoint)
+
+		logStore := xraycommon.NewLogSettingsFile(inst.LogSettingsFile(), domain.LogSettings{Level: conf.CoreLogLevel})
+		logSettings, err := logStore.LoadLogSettings()
+		if err == nil {
+			err = logSettings.Validate()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("init core log settings: %w", err)
+		}
+
+		ci.manager = manager.NewCoreManager(
+			ctx, dsp, cfgExporter, coreLog, logSettings,
+			manager.WithRestartPolicy(restartPolicy(conf.Restart)),
+			manager.WithEvents(events),
+			manager.WithRollback(cfgExporter, conf.RollbackGrace),
+			manager.WithCrashHistory(conf.CrashHistory),
+			manager.WithConfigHistory(cfgExporter),
+			manager.WithHotApply(handlerProv),
+			manager.WithDrain(ci.stats, drainPolicy(conf.Restart)),
+			manager.WithProcessSampler(procfs.NewSampler()),
+			manager.WithBinaries(cores),
+			manager.WithHealthProbe(statProv, healthPolicy(conf.Health)),
+			manager.WithLogStore(logStore),
+		)
+		ci.handles.History = cfgExporter
+		ci.handles.ConfLoad = cfgExporter
+	}
+
+	ci.handles.ConfTest = ci.manager
+	ci.handles.CoreState = ci.manager
+	ci.handles.CoreJournal = coreLog
+	ci.handles.ConnJournal = accessLog
+	ci.handles.ErrJournal = stderrLog
+	ci.handles.Stats = ci.stats
+	ci.handles.Binaries = ci.manager
+	ci.handles.Crashes = ci.manager
+	ci.handles.Logging = ci.manager
+
+	return ci, nil
+}
+
+// coreJournal - core log journal, also the source of the last core log line.
+type coreJournal interface {
+	io.Writer
+	manager.LastLogger
+}
+
+// attachedManager - supervises an externally managed core through its API, process and log files.
+func attachedManager(
+	ctx context.Context, inst config.Instance, conf config.Configuration, events domain.EventPublisher,
+	accessLog io.Writer, coreLog coreJournal, prober domain.CoreProber,
+) *manager.CoreManager {
+	runner := xraycommon.NewAttachedCore(xraycommon.AttachTarget{
+		PIDFile:    inst.Attach.PIDFile,
+		Process:    inst.Attach.Process,
+		AccessFile: inst.Attach.AccessFile,
+		ErrorFile:  inst.Attach.ErrorFile,
+	}, accessLog, coreLog)
+
+	return manager.NewCoreManager(
+		ctx, runner, externalConfig{}, coreLog, domain.LogSettings{Level: conf.CoreLogLevel},
+		manager.WithAttached(),
+		manager.WithRestartPolicy(restartPolicy(conf.Restart)),
+		manager.WithEvents(events),
+		manager.WithCrashHistory(conf.CrashHistory),
+		manager.WithProcessSampler(procfs.NewSampler()),
+		manager.WithHealthProbe(prober, healthPolicy(conf.Health)),
+	)
+}
+
+// externalConfig - config of an attached core, owned by whoever runs the core.
+type externalConfig struct{}
+
+func (externalConfig) LoadConfig() (domain.CoreConfiguration, error) {
+	return nil, domain.ErrAttached
+}
+
+func (externalConfig) SaveRevision(domain.CoreConfiguration, domain.ConfigChange) (domain.ConfigRevision, error) {
+	return domain.ConfigRevision{}, domain.ErrAttached
+}
+
+func (externalConfig) Revisions() ([]domain.ConfigRevision, error) {
+	return nil, domain.ErrAttached
+}
+
+func (externalConfig) Revision(uint64) (domain.ConfigRevision, error) {
+	return domain.ConfigRevision{}, domain.ErrAttached
+}
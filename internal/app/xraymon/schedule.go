@@ -0,0 +1,94 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package xraymon
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eterline/xraymon/internal/config"
+	"github.com/eterline/xraymon/internal/usecase/scheduler"
+)
+
+// scheduleTarget - core instance operations for the scheduler.
+type scheduleTarget struct {
+	*coreInstance
+}
+
+func (st scheduleTarget) Restart() error {
+	return st.manager.Restart()
+}
+
+func (st scheduleTarget) ApplyConfig() error {
+	return st.manager.ApplyConfig()
+}
+
+func (st scheduleTarget) RotateJournals() error {
+	return errors.Join(
+		st.handles.ConnJournal.Rotate(),
+		st.handles.CoreJournal.Rotate(),
+		st.handles.ErrJournal.Rotate(),
+	)
+}
+
+// scheduleJobs - parses scheduled jobs and their maintenance windows.
+func scheduleJobs(conf config.Schedule) ([]scheduler.Job, error) {
+	windows := make(map[string]*scheduler.Window, len(conf.Windows))
+
+	for _, w := range conf.Windows {
+		if _, ok := windows[w.Name]; ok || w.Name == "" {
+			return nil, fmt.Errorf("invalid or duplicate window name %q", w.Name)
+		}
+
+		start, err := scheduler.ParseCron(w.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("window %q: %w", w.Name, err)
+		}
+
+		dur, err := time.ParseDuration(w.Duration)
+		if err != nil || dur <= 0 {
+			return nil, fmt.Errorf("window %q: invalid duration %q", w.Name, w.Duration)
+		}
+
+		windows[w.Name] = &scheduler.Window{Name: w.Name, Start: start, Duration: dur}
+	}
+
+	jobs := make([]scheduler.Job, 0, len(conf.Jobs))
+
+	for _, j := range conf.Jobs {
+		action, err := scheduler.ParseAction(j.Action)
+		if err != nil {
+			return nil, fmt.Errorf("job %q: %w", j.Name, err)
+		}
+
+		job := scheduler.Job{
+			Name:     j.Name,
+			Instance: j.Instance,
+			Action:   action,
+		}
+
+		if job.Instance == "" {
+			job.Instance = config.DefaultInstance
+		}
+
+		if j.Cron != "" {
+			if job.Cron, err = scheduler.ParseCron(j.Cron); err != nil {
+				return nil, fmt.Errorf("job %q: %w", j.Name, err)
+			}
+		}
+
+		if j.Window != "" {
+			w, ok := windows[j.Window]
+			if !ok {
+				return nil, fmt.Errorf("job %q: unknown window %q", j.Name, j.Window)
+			}
+			job.Window = w
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package xraymon
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/infra/sdnotify"
+)
+
+const (
+	sdStatusInterval = 5 * time.Second        // STATUS refresh period when the watchdog is disabled
+	sdStartupPoll    = 500 * time.Millisecond // core state poll period until READY is sent
+)
+
+// notifySystemd - reports service state to systemd until ctx is done. READY is sent once every core
+// has started, watchdog pings are sent while every core manager answers and no core has failed.
+func notifySystemd(ctx context.Context, n *sdnotify.Notifier, insts []*coreInstance, log *slog.Logger) {
+	if n == nil {
+		return
+	}
+
+	interval := sdStatusInterval
+	wd, watchdog := n.WatchdogInterval()
+	if watchdog {
+		interval = wd / 2
+	}
+
+	var (
+		ready      bool
+		lastStatus string
+		started    = make(map[string]bool, len(insts))
+	)
+
+	ticker := time.NewTicker(sdStartupPoll)
+	defer ticker.Stop()
+
+	for {
+		// a wedged manager blocks here and stops the pings
+		statuses := make([]domain.CoreStatus, len(insts))
+		for i, ci := range insts {
+			statuses[i] = ci.manager.Status()
+			if statuses[i].State == domain.CoreRunning {
+				started[ci.name] = true
+			}
+		}
+
+		status := sdStatus(insts, statuses)
+
+		switch {
+		case !ready && len(started) == len(insts):
+			if err := n.Ready(status); err != nil {
+				log.Error("systemd notify failed", "error", err)
+			}
+			log.Info("systemd notified: ready")
+			ready = true
+			lastStatus = status
+			ticker.Reset(interval)
+		case status != lastStatus:
+			if err := n.Status(status); err != nil {
+				log.Error("systemd notify failed", "error", err)
+			}
+			lastStatus = status
+		}
+
+		if watchdog && !sdFailed(statuses) {
+			if err := n.Watchdog(); err != nil {
+				log.Error("systemd watchdog ping failed", "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			n.Stopping()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sdFailed - reports whether a core has reached the crash limit and waits for a manual restart.
+func sdFailed(statuses []domain.CoreStatus) bool {
+	for _, s := range statuses {
+		if s.State == domain.CoreFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// sdStatus - formats core states as "name: state" pairs.
+func sdStatus(insts []*coreInstance, statuses []domain.CoreStatus) string {
+	parts := make([]string, len(insts))
+
+	for i, s := range statuses {
+		state := string(s.State)
+		if s.State == domain.CoreRunning && !s.Healthy {
+			state += " (unhealthy)"
+		}
+		parts[i] = fmt.Sprintf("%s: %s", insts[i].name, state)
+	}
+
+	return strings.Join(parts, "; ")
+}
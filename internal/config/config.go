@@ -1,8 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/alexflint/go-arg"
 )
@@ -14,9 +16,55 @@ type (
 	}
 
 	Core struct {
-		CoreAccess string `arg:"--core-access" help:"Core access file path"`
-		CoreLog    string `arg:"--core-log" help:"Core logging file path"`
-		ConfigFile string `arg:"--core-config" help:"Core logging file path"`
+		CoreAccess    string `arg:"--core-access" help:"Core access file path"`
+		CoreLog       string `arg:"--core-log" help:"Core logging file path"`
+		CoreStderr    string `arg:"--core-stderr" help:"Core stderr journal file path"`
+		ConfigFile    string `arg:"--core-config" help:"Core logging file path"`
+		CoreAPI       string `arg:"--core-api" help:"Core API endpoint: host:port, auto - free loopback port, unix - socket in the runtime dir"`
+		RuntimeDir    string `arg:"--runtime-dir" help:"Private directory for core API sockets"`
+		CoreLogLevel  string `arg:"--core-log-level" help:"Core log level until changed over API: debug|info|warning|error|none"`
+		CoresDir      string `arg:"--cores-dir" help:"Directory with core binaries"`
+		ScheduleFile  string `arg:"--schedule" help:"JSON file with scheduled core restarts, rotations and applies"`
+		InstancesFile string `arg:"--instances" help:"JSON file with named core instances, replaces single core flags"`
+
+		Attach        bool   `arg:"--attach" help:"Monitor an externally managed core through --core-api instead of spawning one"`
+		AttachPIDFile string `arg:"--attach-pidfile" help:"Pid file of the attached core, the process is looked up by name when empty"`
+		AttachProcess string `arg:"--attach-process" help:"Process name of the attached core"`
+		AttachAccess  string `arg:"--attach-access" help:"Access log file of the attached core to follow"`
+		AttachError   string `arg:"--attach-error" help:"Error log file of the attached core to follow"`
+
+		StopGrace       time.Duration `arg:"--core-stop-grace" help:"Time the core gets to exit after SIGTERM before its process group is killed"`
+		ConfigRevisions int           `arg:"--config-revisions" help:"Saved config revisions kept per instance, 0 - unlimited"`
+	}
+
+	Restart struct {
+		RestartDelay      time.Duration `arg:"--restart-delay" help:"Initial core crash restart delay"`
+		RestartMultiplier float64       `arg:"--restart-multiplier" help:"Crash restart delay multiplier"`
+		RestartMaxDelay   time.Duration `arg:"--restart-max-delay" help:"Maximum crash restart delay"`
+		RestartJitter     float64       `arg:"--restart-jitter" help:"Crash restart delay jitter fraction: 0..1"`
+		RestartMaxCrashes int           `arg:"--restart-max-crashes" help:"Consecutive crashes before core is marked failed, 0 - unlimited"`
+		RestartReset      time.Duration `arg:"--restart-reset" help:"Stable core run time that resets the crash counter"`
+		RollbackGrace     time.Duration `arg:"--rollback-grace" help:"Core crash window after config upload that restores the last-known-good config, 0 - disabled"`
+		CrashHistory      int           `arg:"--crash-history" help:"Number of kept core crash records per instance"`
+		DrainTimeout      time.Duration `arg:"--drain-timeout" help:"Longest connection drain before a draining restart, 0 - draining disabled"`
+		DrainThreshold    uint64        `arg:"--drain-threshold" help:"Inbound traffic in bytes per second that ends the drain"`
+		DrainInterval     time.Duration `arg:"--drain-interval" help:"Traffic check period while draining"`
+	}
+
+	Health struct {
+		HealthInterval time.Duration `arg:"--health-interval" help:"Core API liveness probe interval, 0 - disabled"`
+		HealthTimeout  time.Duration `arg:"--health-timeout" help:"Core API liveness probe timeout"`
+		HealthFailures int           `arg:"--health-failures" help:"Consecutive failed probes before the core is restarted"`
+	}
+
+	Sandbox struct {
+		CoreUser      string  `arg:"--core-user" help:"User the core runs as, nobody when xraymon runs as root"`
+		CoreGroup     string  `arg:"--core-group" help:"Group the core runs as, primary group of the core user by default"`
+		CoreNoFile    uint64  `arg:"--core-nofile" help:"Core RLIMIT_NOFILE, 0 - inherited"`
+		CoreAS        uint64  `arg:"--core-as" help:"Core RLIMIT_AS in bytes, 0 - inherited"`
+		CoreCgroup    string  `arg:"--core-cgroup" help:"cgroup v2 directory for per instance core cgroups, empty - disabled"`
+		CoreMemoryMax uint64  `arg:"--core-memory-max" help:"Core cgroup memory.max in bytes, 0 - unlimited"`
+		CoreCPUMax    float64 `arg:"--core-cpu-max" help:"Core cgroup CPU limit in cores, 0 - unlimited"`
 	}
 
 	Server struct {
@@ -29,6 +77,9 @@ type (
 		Log
 		Server
 		Core
+		Restart
+		Health
+		Sandbox
 	}
 )
 
@@ -63,3 +114,22 @@ func selfExec() string {
 
 	return filepath.Base(exePath)
 }
+
+// InstanceRuntimeDir - returns runtime directory of the instance. Defaults to /run/xraymon for root
+// and a per user directory in the temp dir otherwise.
+func (c Core) InstanceRuntimeDir(instance string) string {
+	dir := c.RuntimeDir
+	if dir == "" {
+		if os.Geteuid() == 0 {
+			dir = "/run/xraymon"
+		} else {
+			dir = filepath.Join(os.TempDir(), fmt.Sprintf("xraymon-%d", os.Geteuid()))
+		}
+	}
+	return filepath.Join(dir, instance)
+}
+
+// InstancePIDFile - returns pid file of the instance core, used to find a core left by a previous run.
+func (c Core) InstancePIDFile(instance string) string {
+	return filepath.Join(c.InstanceRuntimeDir(instance), "core.pid")
+}
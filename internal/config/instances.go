@@ -0,0 +1,168 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultInstance - name of the instance built from single core flags.
+const DefaultInstance = "default"
+
+// API endpoint specs allocated by xraymon instead of a fixed address.
+const (
+	APIAuto = "auto"
+	APIUnix = "unix"
+)
+
+// Instance - named core instance with its own config, journals and API endpoint.
+type Instance struct {
+	Name       string `json:"name"`
+	ConfigFile string `json:"config"`
+	CoreAccess string `json:"access_log"`
+	CoreLog    string `json:"core_log"`
+	CoreStderr string `json:"stderr_log"` // optional, StderrLog derives it from CoreLog
+	CoreAPI    string `json:"api_listen"` // host:port, APIAuto or APIUnix; host:port or unix:///path when attached
+
+	Attach *Attach `json:"attach,omitempty"` // set - the core is managed externally
+}
+
+// Attach - externally managed core monitored through its API, process table and log files.
+type Attach struct {
+	PIDFile    string `json:"pid_file"`    // preferred over Process when set
+	Process    string `json:"process"`     // process name, "xray" when empty
+	AccessFile string `json:"access_file"` // access log of the core, empty - not followed
+	ErrorFile  string `json:"error_file"`  // error log of the core, empty - not followed
+}
+
+// defaultAttachProcess - process name of the attached core when neither pid file nor name is set.
+const defaultAttachProcess = "xray"
+
+// LogSettingsFile - file with core log settings changed over API, kept next to the config.
+func (i Instance) LogSettingsFile() string {
+	return i.ConfigFile + ".logging.json"
+}
+
+// StderrLog - core stderr journal file, defaults to the core log path with ".stderr" suffix.
+func (i Instance) StderrLog() string {
+	if i.CoreStderr != "" {
+		return i.CoreStderr
+	}
+	return i.CoreLog + ".stderr"
+}
+
+func (i Instance) validate() error {
+	switch {
+	case i.Name == "":
+		return errors.New("instance name is empty")
+	case i.ConfigFile == "":
+		return fmt.Errorf("instance %q: config file is empty", i.Name)
+	case i.CoreAccess == "":
+		return fmt.Errorf("instance %q: access log file is empty", i.Name)
+	case i.CoreLog == "":
+		return fmt.Errorf("instance %q: core log file is empty", i.Name)
+	case i.CoreAPI == "":
+		return fmt.Errorf("instance %q: api listen address is empty", i.Name)
+	}
+
+	if i.Attach == nil {
+		if strings.HasPrefix(i.CoreAPI, "unix://") {
+			return fmt.Errorf("instance %q: unix:// api address is supported for attached cores only", i.Name)
+		}
+		return nil
+	}
+
+	if i.CoreAPI == APIAuto || i.CoreAPI == APIUnix {
+		return fmt.Errorf("instance %q: attached core needs the api address it listens on, not %q", i.Name, i.CoreAPI)
+	}
+
+	if i.Attach.PIDFile == "" && i.Attach.Process == "" {
+		return fmt.Errorf("instance %q: attached core needs a pid file or a process name", i.Name)
+	}
+
+	return nil
+}
+
+// Instances - returns core instances from the instances file or the single default instance from core flags.
+func (c Core) Instances() ([]Instance, error) {
+	if c.InstancesFile == "" {
+		inst := Instance{
+			Name:       DefaultInstance,
+			ConfigFile: c.ConfigFile,
+			CoreAccess: c.CoreAccess,
+			CoreLog:    c.CoreLog,
+			CoreStderr: c.CoreStderr,
+			CoreAPI:    c.CoreAPI,
+		}
+		if c.Attach {
+			inst.Attach = &Attach{
+				PIDFile:    c.AttachPIDFile,
+				Process:    c.AttachProcess,
+				AccessFile: c.AttachAccess,
+				ErrorFile:  c.AttachError,
+			}
+		}
+		return []Instance{inst}, inst.validate()
+	}
+
+	data, err := os.ReadFile(c.InstancesFile)
+	if err != nil {
+		return nil, fmt.Errorf("read instances file: %w", err)
+	}
+
+	var list []Instance
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("decode instances file: %w", err)
+	}
+
+	if len(list) == 0 {
+		return nil, errors.New("instances file has no instances")
+	}
+
+	for _, inst := range list {
+		if inst.Attach != nil && inst.Attach.Process == "" {
+			inst.Attach.Process = defaultAttachProcess
+		}
+	}
+
+	var (
+		names = make(map[string]struct{}, len(list))
+		files = make(map[string]string, len(list)*4)
+		apis  = make(map[string]string, len(list))
+	)
+
+	for _, inst := range list {
+		if err := inst.validate(); err != nil {
+			return nil, err
+		}
+
+		if _, ok := names[inst.Name]; ok {
+			return nil, fmt.Errorf("duplicate instance name %q", inst.Name)
+		}
+		names[inst.Name] = struct{}{}
+
+		for _, f := range []string{inst.ConfigFile, inst.CoreAccess, inst.CoreLog, inst.StderrLog()} {
+			if owner, ok := files[f]; ok {
+				return nil, fmt.Errorf("instances %q and %q share file %q", owner, inst.Name, f)
+			}
+			files[f] = inst.Name
+		}
+
+		// allocated endpoints are per instance by construction
+		if inst.CoreAPI == APIAuto || inst.CoreAPI == APIUnix {
+			continue
+		}
+
+		if owner, ok := apis[inst.CoreAPI]; ok {
+			return nil, fmt.Errorf("instances %q and %q share api address %q", owner, inst.Name, inst.CoreAPI)
+		}
+		apis[inst.CoreAPI] = inst.Name
+	}
+
+	return list, nil
+}
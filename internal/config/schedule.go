@@ -0,0 +1,51 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ScheduleWindow - named maintenance window opened by cron for duration, e.g. "2h".
+type ScheduleWindow struct {
+	Name     string `json:"name"`
+	Cron     string `json:"cron"`
+	Duration string `json:"duration"`
+}
+
+// ScheduleJob - action (restart|rotate|apply) on an instance by cron, window or both.
+type ScheduleJob struct {
+	Name     string `json:"name"`
+	Instance string `json:"instance"`
+	Action   string `json:"action"`
+	Cron     string `json:"cron"`
+	Window   string `json:"window"`
+}
+
+type Schedule struct {
+	Windows []ScheduleWindow `json:"windows"`
+	Jobs    []ScheduleJob    `json:"jobs"`
+}
+
+// Schedule - returns scheduled jobs from the schedule file, empty when it is not set.
+func (c Core) Schedule() (Schedule, error) {
+	var s Schedule
+
+	if c.ScheduleFile == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(c.ScheduleFile)
+	if err != nil {
+		return s, fmt.Errorf("read schedule file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("decode schedule file: %w", err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrBinaryRejected - core binary failed verification: bad name, checksum mismatch or not executable.
+var ErrBinaryRejected = errors.New("core binary rejected")
+
+// ErrBinaryExists - a binary with the name is already installed. Installed binaries are never replaced:
+// an instance may run it or keep it as the rollback target, a new build needs a new name.
+var ErrBinaryExists = errors.New("core binary already exists")
+
+// CoreBinary - core executable in the cores directory.
+type CoreBinary struct {
+	Name    string
+	Path    string
+	Version string // first line of the "version" command output, empty when it failed
+	SHA256  string
+	Size    int64
+	ModTime time.Time
+}
+
+// CoreBinaryStore - inventory of core executables.
+type CoreBinaryStore interface {
+	List(ctx context.Context) ([]CoreBinary, error)
+	// Install - stores binary read from r under name, sum is the expected hex SHA-256.
+	// A name already in use fails with ErrBinaryExists.
+	Install(ctx context.Context, name string, r io.Reader, sum string) (CoreBinary, error)
+}
+
+// CoreBinarySelector - persisted choice of the active core binary of one instance.
+type CoreBinarySelector interface {
+	Active() string
+	Path(name string) (string, error)
+	Activate(name string) error
+}
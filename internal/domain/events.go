@@ -0,0 +1,60 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package domain
+
+import "time"
+
+// EventKind - type of the lifecycle event.
+type EventKind string
+
+const (
+	EventCoreStarted    EventKind = "core_started"
+	EventCoreExited     EventKind = "core_exited"
+	EventCoreBackoff    EventKind = "core_backoff"
+	EventCoreFailed     EventKind = "core_failed"
+	EventConfigSaved    EventKind = "config_saved"
+	EventConfigRollback EventKind = "config_rollback"
+	EventConfigApplied  EventKind = "config_applied"
+	EventJournalRotated EventKind = "journal_rotated"
+	EventStatsCollected EventKind = "stats_collected"
+	EventBinarySwitched EventKind = "binary_switched"
+	EventBinaryRollback EventKind = "binary_rollback"
+	EventCoreUnhealthy  EventKind = "core_unhealthy"
+	EventScheduled      EventKind = "scheduled_action"
+	EventDrainStarted   EventKind = "drain_started"
+	EventDrainProgress  EventKind = "drain_progress"
+	EventDrainFinished  EventKind = "drain_finished"
+)
+
+type Event struct {
+	Kind     EventKind
+	Instance string // name of the core instance, empty for process-wide events
+	Time     time.Time
+	Message  string
+
+	ExitCode int           // core_exited: process exit code, -1 when killed by signal
+	Attempt  int           // core_backoff, core_failed: consecutive crash number; core_unhealthy: failed probes
+	Delay    time.Duration // core_backoff: delay before the next start; drain_progress, drain_finished: time spent draining
+	Journal  string        // journal_rotated: rotated journal name
+	Rate     uint64        // drain_progress, drain_finished: inbound traffic in bytes per second
+}
+
+func NewEvent(kind EventKind, msg string) Event {
+	return Event{
+		Kind:    kind,
+		Time:    time.Now(),
+		Message: msg,
+	}
+}
+
+type EventPublisher interface {
+	Publish(Event)
+}
+
+type nopPublisher struct{}
+
+func (nopPublisher) Publish(Event) {}
+
+// NopPublisher - publisher that drops every event.
+var NopPublisher EventPublisher = nopPublisher{}
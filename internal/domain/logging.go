@@ -0,0 +1,43 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+var (
+	coreLogLevels   = []string{"debug", "info", "warning", "error", "none"}
+	coreMaskOptions = []string{"", "quarter", "half", "full"}
+)
+
+// LogSettings - core logging settings injected into the "log" config section.
+type LogSettings struct {
+	Level       string `json:"level"`
+	DNSLog      bool   `json:"dns_log"`
+	MaskAddress string `json:"mask_address"`
+}
+
+func (ls LogSettings) Validate() error {
+	if !slices.Contains(coreLogLevels, ls.Level) {
+		return fmt.Errorf("invalid log level %q, expected one of %v", ls.Level, coreLogLevels)
+	}
+	if !slices.Contains(coreMaskOptions, ls.MaskAddress) {
+		return fmt.Errorf("invalid mask address %q, expected one of %q", ls.MaskAddress, coreMaskOptions)
+	}
+	return nil
+}
+
+type LogSettingsStore interface {
+	LoadLogSettings() (LogSettings, error)
+	SaveLogSettings(LogSettings) error
+}
+
+// JournalLine - raw core output line kept in a journal.
+type JournalLine struct {
+	Time time.Time
+	Line string
+}
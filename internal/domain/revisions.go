@@ -0,0 +1,61 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrRevisionNotFound - the requested config revision doesn't exist or was dropped by retention.
+var ErrRevisionNotFound = errors.New("config revision not found")
+
+// SystemAuthor - author of configs saved by xraymon itself: startup normalization and automatic rollbacks.
+const SystemAuthor = "xraymon"
+
+// ConfigChange - who saved a config and why.
+type ConfigChange struct {
+	Author  string
+	Comment string
+}
+
+// ConfigRevision - numbered copy of a saved config.
+type ConfigRevision struct {
+	Number  uint64
+	Time    time.Time
+	Author  string
+	Comment string
+	Hash    string            // CoreConfiguration.Hash of the content
+	Config  CoreConfiguration // not set in revision listings
+}
+
+// ConfigHistory - keeps every saved config as a numbered revision.
+type ConfigHistory interface {
+	// SaveRevision - saves the config as the current one and records it. A config equal to the
+	// latest revision is saved without a new revision, the latest one is returned.
+	SaveRevision(cfg CoreConfiguration, change ConfigChange) (ConfigRevision, error)
+	// Revisions - returns kept revisions newest first, without configs.
+	Revisions() ([]ConfigRevision, error)
+	// Revision - returns the revision with its config.
+	Revision(number uint64) (ConfigRevision, error)
+}
+
+// Hash - returns short content hash of the config, map keys are marshaled sorted.
+func (c CoreConfiguration) Hash() string {
+	// attached cores run a config xraymon doesn't know
+	if len(c) == 0 {
+		return ""
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
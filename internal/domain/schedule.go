@@ -0,0 +1,22 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package domain
+
+import "time"
+
+// ScheduledJob - scheduled core action with its run times.
+type ScheduledJob struct {
+	Name     string
+	Instance string
+	Action   string
+	Cron     string
+
+	Window         string
+	WindowCron     string
+	WindowDuration time.Duration
+
+	NextRun   time.Time // zero when the job will never run again
+	LastRun   time.Time
+	LastError string
+}
@@ -4,12 +4,61 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"slices"
 	"time"
 )
 
+// ErrConfigRejected - the core refused the configuration in test mode.
+var ErrConfigRejected = errors.New("config rejected by core")
+
+// ErrAttached - the operation needs a core owned by xraymon, the attached core is managed externally.
+var ErrAttached = errors.New("core is managed externally")
+
 type CoreConfiguration map[string]json.RawMessage
 
+// configSections - top-level sections xraymon stores, other keys are dropped on save.
+var configSections = map[string]struct{}{
+	"log":              {},
+	"api":              {},
+	"dns":              {},
+	"routing":          {},
+	"policy":           {},
+	"inbounds":         {},
+	"outbounds":        {},
+	"transport":        {},
+	"stats":            {},
+	"reverse":          {},
+	"fakedns":          {},
+	"metrics":          {},
+	"observatory":      {},
+	"burstObservatory": {},
+}
+
+// DroppedKeys - returns sorted top-level keys that Clear removes.
+func (c CoreConfiguration) DroppedKeys() []string {
+	var keys []string
+	for key := range c {
+		if _, ok := configSections[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+	return keys
+}
+
+// Clear - removes top-level keys that are not config sections.
+func (c CoreConfiguration) Clear() {
+	for key := range c {
+		if _, ok := configSections[key]; !ok {
+			delete(c, key)
+		}
+	}
+}
+
 type ConfigLoader interface {
 	LoadConfig() (CoreConfiguration, error)
 }
@@ -18,13 +67,117 @@ type ConfigSaver interface {
 	SaveConfig(CoreConfiguration) error
 }
 
+// ConfigKeeper - keeps a last-known-good copy of the config.
+type ConfigKeeper interface {
+	// MarkGood - stores cfg, the config a run survived with, as last-known-good.
+	MarkGood(cfg CoreConfiguration) error
+	RestoreGood() error
+}
+
+// HandlerApplier - applies inbound and outbound changes to the running core without restart.
+type HandlerApplier interface {
+	AddInbound(ctx context.Context, raw json.RawMessage) error
+	RemoveInbound(ctx context.Context, tag string) error
+	AddOutbound(ctx context.Context, raw json.RawMessage) error
+	RemoveOutbound(ctx context.Context, tag string) error
+}
+
+type ConfigTester interface {
+	TestConfig(ctx context.Context, cfg CoreConfiguration) error
+}
+
+// CoreRunState - lifecycle state of the supervised core process.
+type CoreRunState string
+
+const (
+	CoreStopped CoreRunState = "stopped" // stopped on purpose or not started yet
+	CoreRunning CoreRunState = "running"
+	CoreCrashed CoreRunState = "crashed" // exited with error, restart is pending
+	CoreBackoff CoreRunState = "backoff" // waiting for the crash restart delay
+	CoreFailed  CoreRunState = "failed"  // crash limit reached, waits for manual restart
+)
+
 type CoreStatus struct {
+	State       CoreRunState
 	Working     bool
 	LastLog     string
 	WorkingTime time.Duration
+	Crashes     int    // consecutive crashes since the last stable run
+	Restarts    int    // total restarts since xraymon start
+	Binary      string // active core binary name
+	Attached    bool   // externally managed core, xraymon only monitors it
+
+	Process ProcessStats // zero when the core is not running
+
+	Healthy      bool          // running core answers liveness probes, true when probing is disabled
+	ProbeLatency time.Duration // latency of the last successful probe
+
+	Draining  bool   // connections are drained before a restart
+	DrainRate uint64 // inbound traffic in bytes per second measured by the drain
+
+	LastRollback   time.Time // zero when config was never rolled back
+	RollbackReason string
 }
 
 type CoreState interface {
+	Start() error
+	Stop() error
 	Restart() error
+	// ApplyConfig - applies the saved config: hot-applies handler changes when possible,
+	// otherwise restarts the core. Early crash of the new run restores the last-known-good config.
+	ApplyConfig() error
+	// DrainRestart - Restart that first drains connections of the running core.
+	DrainRestart() error
+	// DrainApplyConfig - ApplyConfig that drains connections when the change needs a restart.
+	DrainApplyConfig() error
 	Status() CoreStatus
 }
+
+// CoreProber - liveness check of the running core.
+type CoreProber interface {
+	Probe(ctx context.Context) error
+}
+
+// ProcessStats - resource usage of the core process.
+type ProcessStats struct {
+	PID        int
+	RSS        uint64        // resident set size in bytes
+	CPUTime    time.Duration // user + system time
+	CPUPercent float64       // since the previous sample or over the process lifetime
+	Threads    int
+	OpenFDs    int
+}
+
+// CoreExitError - abnormal exit of the core process with the tail of its output.
+type CoreExitError struct {
+	Err      error
+	ExitCode int    // -1 when killed by signal
+	Signal   string // empty when exited normally
+	Output   []string
+}
+
+func (e *CoreExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CoreExitError) Unwrap() error {
+	return e.Err
+}
+
+// CrashRecord - core crash kept in the crash history.
+type CrashRecord struct {
+	Time       time.Time
+	ExitCode   int
+	Signal     string
+	Reason     string
+	Uptime     time.Duration // run time before the crash
+	ConfigHash string        // hash of the config the core was running
+	ConfigRev  uint64        // revision of the config the core was running, 0 - unknown
+	Binary     string
+	Output     []string // last lines of the core output
+}
+
+type CrashHistory interface {
+	// Crashes - returns crash records, newest first.
+	Crashes() []CrashRecord
+}
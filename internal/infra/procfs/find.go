@@ -0,0 +1,62 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package procfs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// commLen - kernel truncates process names in /proc/<pid>/comm to 15 bytes.
+const commLen = 15
+
+// FindByName - returns pids of processes with the given name, compared the way the kernel stores it.
+func FindByName(name string) ([]int, error) {
+	if len(name) > commLen {
+		name = name[:commLen]
+	}
+
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var pids []int
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || !e.IsDir() {
+			continue
+		}
+
+		comm, err := os.ReadFile(filepath.Join(procRoot, e.Name(), "comm"))
+		if err != nil {
+			continue // process exited while scanning
+		}
+
+		if string(bytes.TrimSpace(comm)) == name {
+			pids = append(pids, pid)
+		}
+	}
+
+	return pids, nil
+}
+
+// StartTime - returns process start time in clock ticks after boot.
+// A pid with another start time belongs to a different process.
+func StartTime(pid int) (uint64, error) {
+	data, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return 0, fmt.Errorf("read stat: %w", err)
+	}
+
+	f, err := parseStat(data)
+	if err != nil {
+		return 0, err
+	}
+
+	return f.starttime, nil
+}
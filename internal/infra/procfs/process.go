@@ -0,0 +1,191 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package procfs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/eterline/xraymon/internal/domain"
+)
+
+// clockTicks - USER_HZ used by the kernel for /proc time values.
+const clockTicks = 100
+
+var procRoot = "/proc"
+
+type statFields struct {
+	utime     uint64 // clock ticks
+	stime     uint64 // clock ticks
+	threads   int
+	starttime uint64 // clock ticks after boot
+	rssPages  int64
+}
+
+// parseStat - parses /proc/<pid>/stat content. The comm field may contain spaces and parentheses.
+func parseStat(data []byte) (statFields, error) {
+	var f statFields
+
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return f, errors.New("malformed stat: no comm field")
+	}
+
+	// fields after comm start with field 3 (state)
+	fields := bytes.Fields(data[end+1:])
+	if len(fields) < 22 {
+		return f, fmt.Errorf("malformed stat: %d fields after comm", len(fields))
+	}
+
+	field := func(n int) []byte { return fields[n-3] }
+
+	var err error
+	if f.utime, err = strconv.ParseUint(string(field(14)), 10, 64); err != nil {
+		return f, fmt.Errorf("utime: %w", err)
+	}
+	if f.stime, err = strconv.ParseUint(string(field(15)), 10, 64); err != nil {
+		return f, fmt.Errorf("stime: %w", err)
+	}
+	if f.threads, err = strconv.Atoi(string(field(20))); err != nil {
+		return f, fmt.Errorf("num_threads: %w", err)
+	}
+	if f.starttime, err = strconv.ParseUint(string(field(22)), 10, 64); err != nil {
+		return f, fmt.Errorf("starttime: %w", err)
+	}
+	if f.rssPages, err = strconv.ParseInt(string(field(24)), 10, 64); err != nil {
+		return f, fmt.Errorf("rss: %w", err)
+	}
+
+	return f, nil
+}
+
+func ticks(n uint64) time.Duration {
+	return time.Duration(n) * time.Second / clockTicks
+}
+
+// uptime - returns system uptime from /proc/uptime.
+func uptime() (time.Duration, error) {
+	data, err := os.ReadFile(filepath.Join(procRoot, "uptime"))
+	if err != nil {
+		return 0, err
+	}
+
+	fields := bytes.Fields(data)
+	if len(fields) == 0 {
+		return 0, errors.New("malformed uptime")
+	}
+
+	sec, err := strconv.ParseFloat(string(fields[0]), 64)
+	if err != nil {
+		return 0, fmt.Errorf("uptime: %w", err)
+	}
+
+	return time.Duration(sec * float64(time.Second)), nil
+}
+
+func countFDs(pid int) (int, error) {
+	entries, err := os.ReadDir(filepath.Join(procRoot, strconv.Itoa(pid), "fd"))
+	if err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// ReadProcess - reads resource usage of the process from /proc/<pid>.
+// CPUPercent is averaged over the whole process lifetime.
+func ReadProcess(pid int) (domain.ProcessStats, error) {
+	stats, _, err := readProcess(pid)
+	return stats, err
+}
+
+func readProcess(pid int) (domain.ProcessStats, time.Duration, error) {
+	var stats domain.ProcessStats
+
+	data, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return stats, 0, fmt.Errorf("read stat: %w", err)
+	}
+
+	f, err := parseStat(data)
+	if err != nil {
+		return stats, 0, err
+	}
+
+	fds, err := countFDs(pid)
+	if err != nil {
+		return stats, 0, fmt.Errorf("read fds: %w", err)
+	}
+
+	stats = domain.ProcessStats{
+		PID:     pid,
+		RSS:     uint64(f.rssPages) * uint64(os.Getpagesize()),
+		CPUTime: ticks(f.utime + f.stime),
+		Threads: f.threads,
+		OpenFDs: fds,
+	}
+
+	up, err := uptime()
+	if err != nil {
+		return stats, 0, fmt.Errorf("read uptime: %w", err)
+	}
+
+	age := up - ticks(f.starttime)
+	if age > 0 {
+		stats.CPUPercent = float64(stats.CPUTime) / float64(age) * 100
+	}
+
+	return stats, age, nil
+}
+
+// minWindow - shortest interval CPU percent is computed over, shorter ones are too coarse for clock ticks.
+const minWindow = time.Second
+
+// Sampler - reads process stats and computes CPU percent between consecutive samples.
+type Sampler struct {
+	mu      sync.Mutex
+	pid     int
+	cpu     time.Duration
+	at      time.Time
+	percent float64
+}
+
+func NewSampler() *Sampler {
+	return &Sampler{}
+}
+
+// Sample - reads process stats. The first sample of a pid reports lifetime average CPU percent,
+// samples taken within minWindow of the previous one repeat its CPU percent.
+func (s *Sampler) Sample(pid int) (domain.ProcessStats, error) {
+	stats, _, err := readProcess(pid)
+	if err != nil {
+		return stats, err
+	}
+
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pid == pid && !s.at.IsZero() {
+		wall := now.Sub(s.at)
+		if wall < minWindow {
+			stats.CPUPercent = s.percent
+			return stats, nil
+		}
+		stats.CPUPercent = float64(stats.CPUTime-s.cpu) / float64(wall) * 100
+	}
+
+	s.pid = pid
+	s.cpu = stats.CPUTime
+	s.at = now
+	s.percent = stats.CPUPercent
+
+	return stats, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package procfs
+
+import (
+	"os"
+	"runtime"
+	"testing"
+)
+
+func Test_parseStat(t *testing.T) {
+	line := []byte("4242 (Xray (core) x) S 1 4242 4242 0 -1 4194560 1993 0 0 0 " +
+		"150 50 0 0 20 0 12 0 3500 1316184064 4096 18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 3 0 0 0 0 0")
+
+	f, err := parseStat(line)
+	if err != nil {
+		t.Fatalf("parseStat: %v", err)
+	}
+
+	if f.utime != 150 || f.stime != 50 {
+		t.Errorf("utime/stime = %d/%d, want 150/50", f.utime, f.stime)
+	}
+	if f.threads != 12 {
+		t.Errorf("threads = %d, want 12", f.threads)
+	}
+	if f.starttime != 3500 {
+		t.Errorf("starttime = %d, want 3500", f.starttime)
+	}
+	if f.rssPages != 4096 {
+		t.Errorf("rss = %d, want 4096", f.rssPages)
+	}
+}
+
+func Test_parseStatMalformed(t *testing.T) {
+	for _, line := range []string{"", "1 (x S 1", "1 (x) S 1 2 3"} {
+		if _, err := parseStat([]byte(line)); err == nil {
+			t.Errorf("parseStat(%q) succeeded, want error", line)
+		}
+	}
+}
+
+func Test_SamplerSelf(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("procfs is linux only")
+	}
+
+	s := NewSampler()
+
+	stats, err := s.Sample(os.Getpid())
+	if err != nil {
+		t.Fatalf("Sample: %v", err)
+	}
+
+	if stats.PID != os.Getpid() || stats.RSS == 0 || stats.Threads == 0 || stats.OpenFDs == 0 {
+		t.Errorf("unexpected stats of own process: %+v", stats)
+	}
+}
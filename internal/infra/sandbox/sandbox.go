@@ -0,0 +1,20 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package sandbox
+
+// fallbackUser - account the core runs as when xraymon runs as root and no user is configured.
+const fallbackUser = "nobody"
+
+// Config - restrictions of the core child process.
+type Config struct {
+	User  string // user name or uid, fallbackUser when xraymon runs as root
+	Group string // group name or gid, primary group of User by default
+
+	NoFile       uint64 // RLIMIT_NOFILE, 0 - inherited
+	AddressSpace uint64 // RLIMIT_AS in bytes, 0 - inherited
+
+	Cgroup    string  // cgroup v2 directory the instance sub-tree is created in, empty - disabled
+	MemoryMax uint64  // memory.max in bytes, 0 - unlimited
+	CPUMax    float64 // cpu.max in cores, 0 - unlimited
+}
@@ -0,0 +1,342 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+
+//go:build linux
+
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+const cpuPeriod = 100000 // cpu.max period in microseconds
+
+// limitsEnv - marks xraymon started as the launcher of a sandboxed process, holds the rlimits to set.
+const limitsEnv = "XRAYMON_SANDBOX_RLIMITS"
+
+// launchFailed - exit code of the launcher when the target is not executed.
+const launchFailed = 127
+
+func init() {
+	if limits, ok := os.LookupEnv(limitsEnv); ok {
+		launch(limits)
+	}
+}
+
+// launch - runs in the launcher: sets the rlimits on itself and executes the target in its place,
+// the target starts with the limits already applied.
+func launch(limits string) {
+	if err := setLimits(limits); err != nil {
+		fmt.Fprintf(os.Stderr, "sandbox: %v\n", err)
+		os.Exit(launchFailed)
+	}
+
+	os.Unsetenv(limitsEnv)
+
+	if len(os.Args) < 3 {
+		fmt.Fprintln(os.Stderr, "sandbox: no target to execute")
+		os.Exit(launchFailed)
+	}
+
+	err := syscall.Exec(os.Args[1], os.Args[2:], os.Environ())
+	fmt.Fprintf(os.Stderr, "sandbox: exec %s: %v\n", os.Args[1], err)
+	os.Exit(launchFailed)
+}
+
+// setLimits - parses "<resource>=<value>,..." and sets both soft and hard limits of the process.
+func setLimits(limits string) error {
+	for _, field := range strings.Split(limits, ",") {
+		resStr, valueStr, _ := strings.Cut(field, "=")
+
+		res, err := strconv.Atoi(resStr)
+		if err != nil {
+			return fmt.Errorf("invalid rlimit %q", field)
+		}
+		value, err := strconv.ParseUint(valueStr, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid rlimit %q", field)
+		}
+
+		// syscall.Setrlimit keeps RLIMIT_NOFILE through exec, the runtime restores the startup one otherwise
+		if err := syscall.Setrlimit(res, &syscall.Rlimit{Cur: value, Max: value}); err != nil {
+			return fmt.Errorf("setrlimit %s: %w", rlimitName(res), err)
+		}
+	}
+	return nil
+}
+
+// Sandbox - applies credentials, capabilities, rlimits and cgroup placement to the core process.
+type Sandbox struct {
+	cred     *syscall.Credential
+	caps     []uintptr
+	limits   map[int]uint64
+	cgroup   *os.File
+	launcher string // xraymon executable, started in place of the target when rlimits are set
+}
+
+// New - prepares sandbox of the named instance. Creates the instance cgroup and writes its limits.
+func New(conf Config, instance string) (*Sandbox, error) {
+	sb := &Sandbox{
+		limits: map[int]uint64{},
+	}
+
+	cred, err := credential(conf.User, conf.Group)
+	if err != nil {
+		return nil, err
+	}
+
+	if cred != nil {
+		sb.cred = cred
+		// only root can hand the capability over, the core gets it as the single ambient one
+		sb.caps = []uintptr{unix.CAP_NET_BIND_SERVICE}
+	}
+
+	if conf.NoFile > 0 {
+		sb.limits[unix.RLIMIT_NOFILE] = conf.NoFile
+	}
+	if conf.AddressSpace > 0 {
+		sb.limits[unix.RLIMIT_AS] = conf.AddressSpace
+	}
+
+	if len(sb.limits) > 0 {
+		if err := raiseHardLimits(sb.limits); err != nil {
+			return nil, err
+		}
+		if sb.launcher, err = os.Executable(); err != nil {
+			return nil, fmt.Errorf("sandbox launcher: %w", err)
+		}
+	}
+
+	if conf.Cgroup != "" {
+		if sb.cgroup, err = openCgroup(filepath.Join(conf.Cgroup, instance), conf); err != nil {
+			return nil, fmt.Errorf("cgroup: %w", err)
+		}
+	}
+
+	return sb, nil
+}
+
+// credential - resolves the target uid and gid. Returns nil when the process identity is kept.
+func credential(userName, groupName string) (*syscall.Credential, error) {
+	root := os.Geteuid() == 0
+
+	if !root {
+		if userName != "" || groupName != "" {
+			return nil, errors.New("core user and group can be changed only when xraymon runs as root")
+		}
+		return nil, nil
+	}
+
+	if userName == "" {
+		userName = fallbackUser
+	}
+
+	u, err := lookupUser(userName)
+	if err != nil {
+		return nil, err
+	}
+
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("user %q: invalid uid %q", userName, u.Uid)
+	}
+	if uid == 0 {
+		return nil, fmt.Errorf("user %q is root, core must not run as root", userName)
+	}
+
+	gidStr := u.Gid
+	if groupName != "" {
+		g, err := lookupGroup(groupName)
+		if err != nil {
+			return nil, err
+		}
+		gidStr = g.Gid
+	}
+
+	gid, err := strconv.ParseUint(gidStr, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gid %q", gidStr)
+	}
+
+	return &syscall.Credential{
+		Uid:    uint32(uid),
+		Gid:    uint32(gid),
+		Groups: []uint32{},
+	}, nil
+}
+
+func lookupUser(s string) (*user.User, error) {
+	if _, err := strconv.Atoi(s); err == nil {
+		return user.LookupId(s)
+	}
+	return user.Lookup(s)
+}
+
+func lookupGroup(s string) (*user.Group, error) {
+	if _, err := strconv.Atoi(s); err == nil {
+		return user.LookupGroupId(s)
+	}
+	return user.LookupGroup(s)
+}
+
+// openCgroup - creates cgroup directory, writes limits and returns it opened for clone placement.
+func openCgroup(dir string, conf Config) (*os.File, error) {
+	// controllers must be enabled in the parent to be available for the instance cgroup
+	if conf.MemoryMax > 0 || conf.CPUMax > 0 {
+		_ = os.WriteFile(filepath.Join(filepath.Dir(dir), "cgroup.subtree_control"), []byte("+memory +cpu"), 0)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	memory := "max"
+	if conf.MemoryMax > 0 {
+		memory = strconv.FormatUint(conf.MemoryMax, 10)
+	}
+	if err := writeControl(dir, "memory.max", memory, conf.MemoryMax > 0); err != nil {
+		return nil, err
+	}
+
+	cpu := fmt.Sprintf("max %d", cpuPeriod)
+	if conf.CPUMax > 0 {
+		cpu = fmt.Sprintf("%d %d", int64(conf.CPUMax*cpuPeriod), cpuPeriod)
+	}
+	if err := writeControl(dir, "cpu.max", cpu, conf.CPUMax > 0); err != nil {
+		return nil, err
+	}
+
+	return os.Open(dir)
+}
+
+// writeControl - writes cgroup control file. A missing controller is an error only when the limit is set.
+func writeControl(dir, name, value string, required bool) error {
+	err := os.WriteFile(filepath.Join(dir, name), []byte(value), 0)
+	if err != nil && (required || !errors.Is(err, os.ErrNotExist)) {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
+
+// Owner - returns uid and gid the core runs as, false when the process identity is kept.
+func (sb *Sandbox) Owner() (uid, gid int, ok bool) {
+	if sb.cred == nil {
+		return 0, 0, false
+	}
+	return int(sb.cred.Uid), int(sb.cred.Gid), true
+}
+
+// Start - starts cmd inside the sandbox. With rlimits set, the xraymon launcher is started instead,
+// it sets the limits on itself and executes the target, so the target never runs with the inherited ones.
+// The pid of the launcher becomes the pid of the target.
+func (sb *Sandbox) Start(cmd *exec.Cmd) error {
+	sb.prepare(cmd)
+
+	if len(sb.limits) > 0 {
+		cmd.Env = append(cmd.Environ(), limitsEnv+"="+sb.encodeLimits())
+		cmd.Args = append([]string{sb.launcher, cmd.Path}, cmd.Args...)
+		cmd.Path = sb.launcher
+	}
+
+	return cmd.Start()
+}
+
+// prepare - sets child process attributes before start.
+func (sb *Sandbox) prepare(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+
+	attr := cmd.SysProcAttr
+
+	// the core must not outlive xraymon
+	attr.Pdeathsig = syscall.SIGKILL
+
+	if sb.cred != nil {
+		attr.Credential = sb.cred
+		attr.AmbientCaps = sb.caps
+	}
+
+	if sb.cgroup != nil {
+		attr.UseCgroupFD = true
+		attr.CgroupFD = int(sb.cgroup.Fd())
+	}
+}
+
+// encodeLimits - formats the limits for the launcher environment.
+func (sb *Sandbox) encodeLimits() string {
+	fields := make([]string, 0, len(sb.limits))
+	for res, value := range sb.limits {
+		fields = append(fields, fmt.Sprintf("%d=%d", res, value))
+	}
+	return strings.Join(fields, ",")
+}
+
+// raiseHardLimits - raises hard limits of xraymon that are below the configured ones. The launcher runs
+// with the core credentials and can't go above the hard limit it inherits.
+func raiseHardLimits(limits map[int]uint64) error {
+	for res, value := range limits {
+		var lim unix.Rlimit
+		if err := unix.Getrlimit(res, &lim); err != nil {
+			return fmt.Errorf("getrlimit %s: %w", rlimitName(res), err)
+		}
+		if lim.Max >= value {
+			continue
+		}
+
+		lim.Max = value
+		if err := unix.Setrlimit(res, &lim); err != nil {
+			return fmt.Errorf("raise %s hard limit to %d: %w", rlimitName(res), value, err)
+		}
+	}
+	return nil
+}
+
+func rlimitName(res int) string {
+	switch res {
+	case unix.RLIMIT_NOFILE:
+		return "RLIMIT_NOFILE"
+	case unix.RLIMIT_AS:
+		return "RLIMIT_AS"
+	default:
+		return strconv.Itoa(res)
+	}
+}
+
+// String - describes the sandbox for logs.
+func (sb *Sandbox) String() string {
+	var parts []string
+
+	if sb.cred != nil {
+		parts = append(parts, fmt.Sprintf("uid=%d gid=%d", sb.cred.Uid, sb.cred.Gid))
+	}
+	for res, value := range sb.limits {
+		parts = append(parts, fmt.Sprintf("%s=%d", rlimitName(res), value))
+	}
+	if sb.cgroup != nil {
+		parts = append(parts, "cgroup="+sb.cgroup.Name())
+	}
+
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, " ")
+}
+
+func (sb *Sandbox) Close() error {
+	if sb.cgroup != nil {
+		return sb.cgroup.Close()
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+
+//go:build !linux
+
+package sandbox
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// Sandbox - process restrictions are supported on linux only.
+type Sandbox struct{}
+
+// New - fails when any restriction is configured, the core must not silently run unrestricted.
+func New(conf Config, instance string) (*Sandbox, error) {
+	if conf != (Config{}) {
+		return nil, errors.New("core sandbox is supported on linux only")
+	}
+	if os.Geteuid() == 0 {
+		return nil, errors.New("core must not run as root")
+	}
+	return &Sandbox{}, nil
+}
+
+func (sb *Sandbox) Owner() (uid, gid int, ok bool) { return 0, 0, false }
+
+func (sb *Sandbox) Start(cmd *exec.Cmd) error { return cmd.Start() }
+
+func (sb *Sandbox) String() string { return "none" }
+
+func (sb *Sandbox) Close() error { return nil }
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package sdnotify
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Notifier - sends service state to systemd over the sd_notify datagram socket.
+// A nil Notifier is valid and does nothing, so callers don't check whether they run under systemd.
+type Notifier struct {
+	addr     *net.UnixAddr
+	watchdog time.Duration
+}
+
+// FromEnv - creates notifier from NOTIFY_SOCKET, WATCHDOG_USEC and WATCHDOG_PID and removes them from
+// the environment, so spawned cores don't notify systemd on behalf of xraymon.
+// Returns nil when the service is not started with Type=notify.
+func FromEnv() (*Notifier, error) {
+	sock := os.Getenv("NOTIFY_SOCKET")
+	usec := os.Getenv("WATCHDOG_USEC")
+	pid := os.Getenv("WATCHDOG_PID")
+
+	os.Unsetenv("NOTIFY_SOCKET")
+	os.Unsetenv("WATCHDOG_USEC")
+	os.Unsetenv("WATCHDOG_PID")
+
+	if sock == "" {
+		return nil, nil
+	}
+
+	// '@' - abstract namespace socket, net translates it itself
+	if !strings.HasPrefix(sock, "/") && !strings.HasPrefix(sock, "@") {
+		return nil, fmt.Errorf("unsupported NOTIFY_SOCKET %q", sock)
+	}
+
+	n := &Notifier{
+		addr: &net.UnixAddr{Name: sock, Net: "unixgram"},
+	}
+
+	if usec == "" {
+		return n, nil
+	}
+
+	us, err := strconv.ParseUint(usec, 10, 64)
+	if err != nil || us == 0 {
+		return nil, fmt.Errorf("invalid WATCHDOG_USEC %q", usec)
+	}
+
+	// watchdog of another process, e.g. inherited from a parent service
+	if pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return n, nil
+	}
+
+	n.watchdog = time.Duration(us) * time.Microsecond
+
+	return n, nil
+}
+
+// WatchdogInterval - returns systemd watchdog timeout, false when the watchdog is disabled.
+// Pings should be sent at least twice per timeout.
+func (n *Notifier) WatchdogInterval() (time.Duration, bool) {
+	if n == nil || n.watchdog == 0 {
+		return 0, false
+	}
+	return n.watchdog, true
+}
+
+// Send - sends state assignments like "READY=1" in one datagram.
+func (n *Notifier) Send(states ...string) error {
+	if n == nil {
+		return nil
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, n.addr)
+	if err != nil {
+		return fmt.Errorf("dial notify socket: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(strings.Join(states, "\n"))); err != nil {
+		return fmt.Errorf("write notify socket: %w", err)
+	}
+
+	return nil
+}
+
+// Ready - reports service startup finished.
+func (n *Notifier) Ready(status string) error {
+	return n.Send("READY=1", "STATUS="+status)
+}
+
+// Watchdog - keeps the service watchdog alive.
+func (n *Notifier) Watchdog() error {
+	return n.Send("WATCHDOG=1")
+}
+
+// Status - sets free form service status shown by systemctl status.
+func (n *Notifier) Status(status string) error {
+	return n.Send("STATUS=" + status)
+}
+
+// Stopping - reports service shutdown started.
+func (n *Notifier) Stopping() error {
+	return n.Send("STOPPING=1")
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package sdnotify_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/eterline/xraymon/internal/infra/sdnotify"
+)
+
+func listen(t *testing.T) (*net.UnixConn, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn, path
+}
+
+func receive(t *testing.T, conn *net.UnixConn) string {
+	t.Helper()
+
+	buf := make([]byte, 4096)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	return string(buf[:n])
+}
+
+func Test_Notify(t *testing.T) {
+	conn, path := listen(t)
+
+	t.Setenv("NOTIFY_SOCKET", path)
+	t.Setenv("WATCHDOG_USEC", "3000000")
+	t.Setenv("WATCHDOG_PID", strconv.Itoa(os.Getpid()))
+
+	n, err := sdnotify.FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv: %v", err)
+	}
+
+	if os.Getenv("NOTIFY_SOCKET") != "" {
+		t.Error("NOTIFY_SOCKET is left in the environment")
+	}
+
+	if wd, ok := n.WatchdogInterval(); !ok || wd != 3*time.Second {
+		t.Errorf("WatchdogInterval = %v, %v, want 3s, true", wd, ok)
+	}
+
+	steps := []struct {
+		send func() error
+		want string
+	}{
+		{func() error { return n.Ready("default: running") }, "READY=1\nSTATUS=default: running"},
+		{n.Watchdog, "WATCHDOG=1"},
+		{func() error { return n.Status("default: backoff") }, "STATUS=default: backoff"},
+		{n.Stopping, "STOPPING=1"},
+	}
+
+	for _, s := range steps {
+		if err := s.send(); err != nil {
+			t.Fatalf("send %q: %v", s.want, err)
+		}
+		if got := receive(t, conn); got != s.want {
+			t.Errorf("datagram = %q, want %q", got, s.want)
+		}
+	}
+}
+
+func Test_NotifyEnv(t *testing.T) {
+	_, path := listen(t)
+
+	tests := []struct {
+		name     string
+		socket   string
+		usec     string
+		pid      string
+		wantNil  bool
+		wantErr  bool
+		watchdog time.Duration
+	}{
+		{name: "not under systemd", wantNil: true},
+		{name: "no watchdog", socket: path},
+		{name: "watchdog of another process", socket: path, usec: "1000000", pid: "1"},
+		{name: "watchdog without pid", socket: path, usec: "1000000", watchdog: time.Second},
+		{name: "invalid watchdog", socket: path, usec: "soon", wantErr: true},
+		{name: "vsock", socket: "vsock:2:1234", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NOTIFY_SOCKET", tt.socket)
+			t.Setenv("WATCHDOG_USEC", tt.usec)
+			t.Setenv("WATCHDOG_PID", tt.pid)
+
+			n, err := sdnotify.FromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromEnv error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if (n == nil) != tt.wantNil {
+				t.Fatalf("FromEnv = %v, want nil %v", n, tt.wantNil)
+			}
+
+			// nil notifier is a no-op
+			if err := n.Ready("ok"); err != nil {
+				t.Errorf("Ready: %v", err)
+			}
+
+			if wd, _ := n.WatchdogInterval(); wd != tt.watchdog {
+				t.Errorf("WatchdogInterval = %v, want %v", wd, tt.watchdog)
+			}
+		})
+	}
+}
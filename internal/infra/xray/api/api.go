@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"net/netip"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -25,6 +27,14 @@ var (
 )
 
 func validateSocket(s string) error {
+	// unix socket target, the socket appears when the core starts
+	if path, ok := strings.CutPrefix(s, "unix://"); ok {
+		if _, err := os.Stat(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("unix socket dir: %w", err)
+		}
+		return nil
+	}
+
 	if addrPort, err := netip.ParseAddrPort(s); err == nil {
 		_ = addrPort
 		return nil
@@ -115,3 +125,16 @@ func (x *XrayAPI) GetTraffic(ctx context.Context, reset bool) ([]Traffic, []Clie
 
 	return t, ct, nil
 }
+
+// SysStats - returns runtime stats of the core process, used as a cheap liveness call.
+func (x *XrayAPI) SysStats(ctx context.Context) (*statsService.SysStatsResponse, error) {
+	if err := x.grpcNotNil(); err != nil {
+		return nil, err
+	}
+
+	if x.StatsServiceClient == nil {
+		return nil, errors.New("xray StatusServiceClient is not initialized")
+	}
+
+	return (*x.StatsServiceClient).GetSysStats(ctx, &statsService.SysStatsRequest{})
+}
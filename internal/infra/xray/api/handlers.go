@@ -0,0 +1,79 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package xrayapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	handlerService "github.com/xtls/xray-core/app/proxyman/command"
+	"github.com/xtls/xray-core/core"
+)
+
+const handlerCallTimeout = 10 * time.Second
+
+func (x *XrayAPI) handlerClient() (handlerService.HandlerServiceClient, error) {
+	if err := x.grpcNotNil(); err != nil {
+		return nil, err
+	}
+
+	if x.HandlerServiceClient == nil {
+		return nil, errors.New("xray HandlerServiceClient is not initialized")
+	}
+
+	return *x.HandlerServiceClient, nil
+}
+
+func (x *XrayAPI) AddInbound(ctx context.Context, cfg *core.InboundHandlerConfig) error {
+	hs, err := x.handlerClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, handlerCallTimeout)
+	defer cancel()
+
+	_, err = hs.AddInbound(ctx, &handlerService.AddInboundRequest{Inbound: cfg})
+	return err
+}
+
+func (x *XrayAPI) RemoveInbound(ctx context.Context, tag string) error {
+	hs, err := x.handlerClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, handlerCallTimeout)
+	defer cancel()
+
+	_, err = hs.RemoveInbound(ctx, &handlerService.RemoveInboundRequest{Tag: tag})
+	return err
+}
+
+func (x *XrayAPI) AddOutbound(ctx context.Context, cfg *core.OutboundHandlerConfig) error {
+	hs, err := x.handlerClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, handlerCallTimeout)
+	defer cancel()
+
+	_, err = hs.AddOutbound(ctx, &handlerService.AddOutboundRequest{Outbound: cfg})
+	return err
+}
+
+func (x *XrayAPI) RemoveOutbound(ctx context.Context, tag string) error {
+	hs, err := x.handlerClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, handlerCallTimeout)
+	defer cancel()
+
+	_, err = hs.RemoveOutbound(ctx, &handlerService.RemoveOutboundRequest{Tag: tag})
+	return err
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package xraycommon
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/infra/procfs"
+)
+
+const (
+	attachCheckInterval = time.Second
+	followPollInterval  = 500 * time.Millisecond
+)
+
+var errAttachedExited = errors.New("attached core process exited")
+
+// AttachTarget - location of an externally managed core.
+type AttachTarget struct {
+	PIDFile    string // pid file of the core, preferred over Process
+	Process    string // process name looked up in the process table
+	AccessFile string // access log of the core, empty - not followed
+	ErrorFile  string // error log of the core, empty - not followed
+}
+
+// AttachedCore - CoreRunner for a core started by someone else: systemd, a container runtime.
+// It never execs the core, a run follows the core log files into the journals and ends when the process is gone.
+type AttachedCore struct {
+	target       AttachTarget
+	acceptStream io.Writer
+	coreStream   io.Writer
+
+	pid atomic.Int64 // pid of the attached core, 0 - not attached
+}
+
+func NewAttachedCore(target AttachTarget, accept, core io.Writer) *AttachedCore {
+	return &AttachedCore{
+		target:       target,
+		acceptStream: accept,
+		coreStream:   core,
+	}
+}
+
+// Run - attaches to the running core. conf and logs are ignored, the core is configured externally.
+// Returns nil when ctx is done and domain.CoreExitError when the core process disappears.
+func (ac *AttachedCore) Run(ctx context.Context, conf domain.CoreConfiguration, logs domain.LogSettings) error {
+	pid, err := ac.findPID()
+	if err != nil {
+		return fmt.Errorf("attach: %w", err)
+	}
+
+	started, err := procfs.StartTime(pid)
+	if err != nil {
+		return fmt.Errorf("attach: %w", err)
+	}
+
+	ac.pid.Store(int64(pid))
+	defer ac.pid.CompareAndSwap(int64(pid), 0)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lines := make(chan outputLine)
+	if ac.target.AccessFile != "" {
+		go followFile(ctx, ac.target.AccessFile, lines)
+	}
+	if ac.target.ErrorFile != "" {
+		go followFile(ctx, ac.target.ErrorFile, lines)
+	}
+
+	tail := newLineTail(outputTailLines)
+
+	ticker := time.NewTicker(attachCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case line := <-lines:
+			tail.add(line.data)
+			if err := ac.streamLog(line); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			// a reused pid has another start time
+			if st, err := procfs.StartTime(pid); err != nil || st != started {
+				return &domain.CoreExitError{
+					Err:      errAttachedExited,
+					ExitCode: -1,
+					Output:   tail.lines(),
+				}
+			}
+		}
+	}
+}
+
+// Test - attached core config is not managed by xraymon.
+func (ac *AttachedCore) Test(ctx context.Context, conf domain.CoreConfiguration, logs domain.LogSettings) error {
+	return domain.ErrAttached
+}
+
+// PID - returns pid of the attached core, 0 when not attached.
+func (ac *AttachedCore) PID() int {
+	return int(ac.pid.Load())
+}
+
+// SetBinary - attached core binary is not managed by xraymon.
+func (ac *AttachedCore) SetBinary(path string) {}
+
+func (ac *AttachedCore) findPID() (int, error) {
+	if ac.target.PIDFile != "" {
+		data, err := os.ReadFile(ac.target.PIDFile)
+		if err != nil {
+			return 0, fmt.Errorf("read pid file: %w", err)
+		}
+
+		pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil || pid <= 0 {
+			return 0, fmt.Errorf("invalid pid file %q", ac.target.PIDFile)
+		}
+
+		return pid, nil
+	}
+
+	pids, err := procfs.FindByName(ac.target.Process)
+	if err != nil {
+		return 0, fmt.Errorf("scan processes: %w", err)
+	}
+
+	switch len(pids) {
+	case 0:
+		return 0, fmt.Errorf("no process named %q", ac.target.Process)
+	case 1:
+		return pids[0], nil
+	default:
+		return 0, fmt.Errorf("%d processes named %q, set a pid file", len(pids), ac.target.Process)
+	}
+}
+
+func (ac *AttachedCore) streamLog(line outputLine) error {
+	w := ac.coreStream
+	if classifyLine(line.data) == lineAccess {
+		w = ac.acceptStream
+	}
+
+	_, err := w.Write(line.data)
+	return err
+}
+
+// followFile - sends lines appended to the file after the call until ctx is done.
+// A missing file is waited for, a rotated or truncated one is reread from the start.
+func followFile(ctx context.Context, path string, out chan<- outputLine) {
+	var (
+		f       *os.File
+		rd      *bufio.Reader
+		offset  int64
+		partial []byte
+		opened  bool // the first open skips existing content, later ones read files from the start
+	)
+
+	defer func() {
+		if f != nil {
+			f.Close()
+		}
+	}()
+
+	ticker := time.NewTicker(followPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if f == nil {
+			if nf, err := os.Open(path); err == nil {
+				f, rd, offset, partial = nf, bufio.NewReader(nf), 0, nil
+				if !opened {
+					offset, _ = f.Seek(0, io.SeekEnd)
+				}
+			}
+			opened = true
+		}
+
+		if f != nil {
+			for {
+				b, err := rd.ReadBytes('\n')
+				offset += int64(len(b))
+				if err != nil {
+					partial = append(partial, b...) // incomplete line, the rest is not written yet
+					break
+				}
+
+				line := bytes.TrimRight(append(partial, b...), "\r\n")
+				partial = nil
+				if len(line) == 0 {
+					continue
+				}
+
+				select {
+				case out <- outputLine{data: line}:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if fileReplaced(f, path, offset) {
+				f.Close()
+				f = nil
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// fileReplaced - reports whether path now names another file or the file was truncated below offset.
+func fileReplaced(f *os.File, path string, offset int64) bool {
+	cur, err := os.Stat(path)
+	if err != nil {
+		return false // rotated away, keep the old file until the new one appears
+	}
+
+	old, err := f.Stat()
+	if err != nil {
+		return true
+	}
+
+	return !os.SameFile(old, cur) || cur.Size() < offset
+}
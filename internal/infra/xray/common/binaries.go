@@ -0,0 +1,331 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package xraycommon
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eterline/xraymon/internal/domain"
+)
+
+const (
+	versionTimeout  = 10 * time.Second
+	maxBinarySize   = 256 << 20
+	activeStateFile = ".active.json"
+)
+
+// DefaultCoreName - binary name used when an instance has no stored choice.
+func DefaultCoreName() string {
+	return filepath.Base(xrayCore())
+}
+
+// coreRegistry - core binaries in a directory with per instance active selection.
+type coreRegistry struct {
+	dir     string
+	sandbox ProcessSandbox // runs version checks
+
+	mu     sync.Mutex
+	active map[string]string // instance -> binary name
+}
+
+// RegistryOption - functional option for the core registry.
+type RegistryOption func(*coreRegistry)
+
+// WithVersionSandbox - runs version checks of listed and uploaded binaries inside the sandbox,
+// the binaries are executed with the restrictions of the core.
+func WithVersionSandbox(sb ProcessSandbox) RegistryOption {
+	return func(cr *coreRegistry) {
+		cr.sandbox = sb
+	}
+}
+
+func NewCoreRegistry(dir string, opts ...RegistryOption) (*coreRegistry, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create cores dir: %w", err)
+	}
+
+	cr := &coreRegistry{
+		dir:    dir,
+		active: map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt(cr)
+	}
+
+	data, err := os.ReadFile(cr.statePath())
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("read active cores: %w", err)
+	default:
+		if err := json.Unmarshal(data, &cr.active); err != nil {
+			return nil, fmt.Errorf("decode active cores: %w", err)
+		}
+	}
+
+	return cr, nil
+}
+
+func (cr *coreRegistry) statePath() string {
+	return filepath.Join(cr.dir, activeStateFile)
+}
+
+// validName - binary names are plain file names inside the cores dir.
+func validName(name string) error {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("%w: invalid name %q", domain.ErrBinaryRejected, name)
+	}
+	return nil
+}
+
+func (cr *coreRegistry) path(name string) (string, error) {
+	if err := validName(name); err != nil {
+		return "", err
+	}
+
+	p := filepath.Join(cr.dir, name)
+
+	st, err := os.Stat(p)
+	if err != nil {
+		return "", fmt.Errorf("core binary %q: %w", name, err)
+	}
+	if !st.Mode().IsRegular() || st.Mode().Perm()&0o111 == 0 {
+		return "", fmt.Errorf("core binary %q is not an executable file", name)
+	}
+
+	return p, nil
+}
+
+// List - returns binaries of the cores dir with their versions and checksums.
+func (cr *coreRegistry) List(ctx context.Context) ([]domain.CoreBinary, error) {
+	entries, err := os.ReadDir(cr.dir)
+	if err != nil {
+		return nil, fmt.Errorf("read cores dir: %w", err)
+	}
+
+	list := make([]domain.CoreBinary, 0, len(entries))
+
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") || !e.Type().IsRegular() {
+			continue
+		}
+
+		info, err := e.Info()
+		if err != nil || info.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+
+		bin, err := cr.describeBinary(ctx, filepath.Join(cr.dir, e.Name()), info)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, bin)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	return list, nil
+}
+
+func (cr *coreRegistry) describeBinary(ctx context.Context, path string, info os.FileInfo) (domain.CoreBinary, error) {
+	sum, err := fileSHA256(path)
+	if err != nil {
+		return domain.CoreBinary{}, err
+	}
+
+	version, _ := cr.binaryVersion(ctx, path)
+
+	return domain.CoreBinary{
+		Name:    filepath.Base(path),
+		Path:    path,
+		Version: version,
+		SHA256:  sum,
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}, nil
+}
+
+// Install - verifies checksum and executability of the uploaded binary and atomically puts it into the cores dir.
+// Existing binaries are never replaced.
+func (cr *coreRegistry) Install(ctx context.Context, name string, r io.Reader, sum string) (domain.CoreBinary, error) {
+	if err := validName(name); err != nil {
+		return domain.CoreBinary{}, err
+	}
+
+	dst := filepath.Join(cr.dir, name)
+	if _, err := os.Lstat(dst); err == nil {
+		return domain.CoreBinary{}, fmt.Errorf("%w: %q", domain.ErrBinaryExists, name)
+	}
+
+	want, err := hex.DecodeString(strings.TrimSpace(sum))
+	if err != nil || len(want) != sha256.Size {
+		return domain.CoreBinary{}, fmt.Errorf("%w: invalid sha256 %q", domain.ErrBinaryRejected, sum)
+	}
+
+	tmp, err := os.CreateTemp(cr.dir, ".upload-*")
+	if err != nil {
+		return domain.CoreBinary{}, fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, h), io.LimitReader(r, maxBinarySize+1))
+	if err != nil {
+		tmp.Close()
+		return domain.CoreBinary{}, fmt.Errorf("write binary: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return domain.CoreBinary{}, fmt.Errorf("close temp: %w", err)
+	}
+
+	if n > maxBinarySize {
+		return domain.CoreBinary{}, fmt.Errorf("%w: larger than %d bytes", domain.ErrBinaryRejected, maxBinarySize)
+	}
+
+	if got := h.Sum(nil); !bytes.Equal(got, want) {
+		return domain.CoreBinary{}, fmt.Errorf("%w: sha256 mismatch: got %x", domain.ErrBinaryRejected, got)
+	}
+
+	if err := os.Chmod(tmpPath, 0o755); err != nil {
+		return domain.CoreBinary{}, fmt.Errorf("chmod: %w", err)
+	}
+
+	if _, err := cr.binaryVersion(ctx, tmpPath); err != nil {
+		return domain.CoreBinary{}, fmt.Errorf("%w: %v", domain.ErrBinaryRejected, err)
+	}
+
+	// link fails on an existing name, unlike rename, so a concurrent upload can't replace the binary either
+	if err := os.Link(tmpPath, dst); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return domain.CoreBinary{}, fmt.Errorf("%w: %q", domain.ErrBinaryExists, name)
+		}
+		return domain.CoreBinary{}, fmt.Errorf("link: %w", err)
+	}
+
+	info, err := os.Stat(dst)
+	if err != nil {
+		return domain.CoreBinary{}, err
+	}
+
+	return cr.describeBinary(ctx, dst, info)
+}
+
+// Instance - returns active binary selector of the named instance.
+func (cr *coreRegistry) Instance(name string) *coreSelection {
+	return &coreSelection{reg: cr, instance: name}
+}
+
+func (cr *coreRegistry) activate(instance, name string) error {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	next := make(map[string]string, len(cr.active)+1)
+	for k, v := range cr.active {
+		next[k] = v
+	}
+	next[instance] = name
+
+	data, err := json.MarshalIndent(next, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := cr.statePath() + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("write temp file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, cr.statePath()); err != nil {
+		return fmt.Errorf("rename: %w", err)
+	}
+
+	cr.active = next
+
+	return nil
+}
+
+// coreSelection - active core binary of one instance.
+type coreSelection struct {
+	reg      *coreRegistry
+	instance string
+}
+
+// Active - returns the active binary name, the platform default when none was chosen.
+func (cs *coreSelection) Active() string {
+	cs.reg.mu.Lock()
+	defer cs.reg.mu.Unlock()
+
+	if name, ok := cs.reg.active[cs.instance]; ok {
+		return name
+	}
+	return DefaultCoreName()
+}
+
+func (cs *coreSelection) Path(name string) (string, error) {
+	return cs.reg.path(name)
+}
+
+// Activate - persists name as the active binary of the instance.
+func (cs *coreSelection) Activate(name string) error {
+	if err := validName(name); err != nil {
+		return err
+	}
+	return cs.reg.activate(cs.instance, name)
+}
+
+// ----------------- Helpers -----------------
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("hash %s: %w", path, err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// binaryVersion - runs "<bin> version" inside the sandbox and returns the first output line.
+func (cr *coreRegistry) binaryVersion(ctx context.Context, path string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, versionTimeout)
+	defer cancel()
+
+	var out bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, path, "version")
+	cmd.Stdout = &out
+
+	if err := startProcess(cmd, cr.sandbox); err != nil {
+		return "", fmt.Errorf("version check: %w", err)
+	}
+	if err := cmd.Wait(); err != nil {
+		return "", fmt.Errorf("version check: %w", err)
+	}
+
+	line, _, _ := strings.Cut(out.String(), "\n")
+	return strings.TrimSpace(line), nil
+}
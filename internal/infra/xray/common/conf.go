@@ -13,36 +13,8 @@ import (
 	"sync"
 
 	"github.com/eterline/xraymon/internal/domain"
-	"github.com/eterline/xraymon/internal/utils/usecase"
 )
 
-const apiListenAddr = "127.0.0.1:8000"
-
-var allowedFields = usecase.NewWhitelist(
-	"log",
-	"api",
-	"dns",
-	"routing",
-	"policy",
-	"inbounds",
-	"outbounds",
-	"transport",
-	"stats",
-	"reverse",
-	"fakedns",
-	"metrics",
-	"observatory",
-	"burstObservatory",
-)
-
-func clearConfig(ccf *domain.CoreConfiguration) {
-	for key := range *ccf {
-		if !allowedFields.Allowed(key) {
-			delete(*ccf, key)
-		}
-	}
-}
-
 // ==============
 
 type logObject struct {
@@ -54,7 +26,7 @@ type logObject struct {
 }
 
 func defineLevel(l string) string {
-	levels := []string{"debug", "info", "warning", "error"}
+	levels := []string{"debug", "info", "warning", "error", "none"}
 	for _, lv := range levels {
 		if lv == l {
 			return l
@@ -63,13 +35,13 @@ func defineLevel(l string) string {
 	return "info"
 }
 
-func initLogging(level string) *logObject {
+func initLogging(ls domain.LogSettings) *logObject {
 	return &logObject{
 		Access:      "",
 		Error:       "",
-		Loglevel:    defineLevel(level),
-		DNSLog:      false,
-		MaskAddress: "",
+		Loglevel:    defineLevel(ls.Level),
+		DNSLog:      ls.DNSLog,
+		MaskAddress: ls.MaskAddress,
 	}
 }
 
@@ -81,17 +53,16 @@ func initStats() *statsObject {
 
 type apiObject struct {
 	Tag      string   `json:"tag"`
-	Listen   string   `json:"listen"`
+	Listen   string   `json:"listen,omitempty"`
 	Services []string `json:"services"`
 }
 
-func initApiObject() *apiObject {
+func initApiObject(listen string) *apiObject {
 	return &apiObject{
 		Tag:    "api",
-		Listen: apiListenAddr,
+		Listen: listen,
 		Services: []string{
 			"HandlerService",
-			"LoggerService",
 			"StatsService",
 			"RoutingService",
 		},
@@ -103,11 +74,25 @@ func initApiObject() *apiObject {
 type configFileProvider struct {
 	path     string
 	confFile *os.File
+	events   domain.EventPublisher
+	revs     *revisionStore
 
 	mu sync.RWMutex
 }
 
-func NewConfigFileProvider(path string) (*configFileProvider, error) {
+// ConfigFileOption - functional option for the config file provider.
+type ConfigFileOption func(*configFileProvider)
+
+// WithRevisionRetention - sets number of kept config revisions, 0 - unlimited.
+func WithRevisionRetention(keep int) ConfigFileOption {
+	return func(cfp *configFileProvider) {
+		cfp.revs.keep = keep
+	}
+}
+
+// NewConfigFileProvider - config stored in a JSON file. Every saved config is also kept as a numbered
+// revision in the "<path>.revisions" directory.
+func NewConfigFileProvider(path string, pub domain.EventPublisher, opts ...ConfigFileOption) (*configFileProvider, error) {
 	if filepath.Base(path) == "config.json" {
 		return nil, errors.New("core settings can't have name 'config.json'")
 	}
@@ -120,6 +105,14 @@ func NewConfigFileProvider(path string) (*configFileProvider, error) {
 	cfp := &configFileProvider{
 		path:     path,
 		confFile: f,
+		revs: &revisionStore{
+			dir:  path + ".revisions",
+			keep: DefaultConfigRevisions,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(cfp)
 	}
 
 	cfg, err := cfp.LoadConfig()
@@ -127,11 +120,14 @@ func NewConfigFileProvider(path string) (*configFileProvider, error) {
 		return nil, fmt.Errorf("failed test config: %w", err)
 	}
 
-	err = cfp.SaveConfig(cfg)
+	// a config edited by hand while xraymon was down gets its own revision
+	_, err = cfp.SaveRevision(cfg, domain.ConfigChange{Author: domain.SystemAuthor, Comment: "loaded at startup"})
 	if err != nil {
 		return nil, fmt.Errorf("failed test config: %w", err)
 	}
 
+	cfp.events = pub
+
 	return cfp, nil
 }
 
@@ -152,15 +148,63 @@ func (cfp *configFileProvider) LoadConfig() (domain.CoreConfiguration, error) {
 		return nil, fmt.Errorf("decode: %w", err)
 	}
 
-	clearConfig(&cfg)
+	cfg.Clear()
 
 	return cfg, nil
 }
 
 func (cfp *configFileProvider) SaveConfig(cfg domain.CoreConfiguration) error {
+	_, err := cfp.SaveRevision(cfg, domain.ConfigChange{Author: domain.SystemAuthor})
+	return err
+}
+
+// SaveRevision - saves the config and records it as a revision.
+func (cfp *configFileProvider) SaveRevision(cfg domain.CoreConfiguration, change domain.ConfigChange) (domain.ConfigRevision, error) {
 	cfp.mu.Lock()
 	defer cfp.mu.Unlock()
 
+	if err := cfp.writeConfig(cfg); err != nil {
+		return domain.ConfigRevision{}, err
+	}
+
+	rev, err := cfp.revs.record(cfg, change)
+	if err != nil {
+		return domain.ConfigRevision{}, fmt.Errorf("record revision: %w", err)
+	}
+
+	if cfp.events != nil {
+		cfp.events.Publish(domain.NewEvent(
+			domain.EventConfigSaved,
+			fmt.Sprintf("config saved to %s, revision %d", cfp.path, rev.Number),
+		))
+	}
+
+	return rev, nil
+}
+
+// Revisions - returns kept config revisions newest first, without configs.
+func (cfp *configFileProvider) Revisions() ([]domain.ConfigRevision, error) {
+	cfp.mu.RLock()
+	defer cfp.mu.RUnlock()
+
+	return cfp.revs.list()
+}
+
+// Revision - returns the config revision with its config.
+func (cfp *configFileProvider) Revision(number uint64) (domain.ConfigRevision, error) {
+	cfp.mu.RLock()
+	defer cfp.mu.RUnlock()
+
+	rf, err := cfp.revs.read(number)
+	if err != nil {
+		return domain.ConfigRevision{}, err
+	}
+
+	return rf.revision(), nil
+}
+
+// writeConfig - replaces the config file with cfg. Must be called with cfp.mu held.
+func (cfp *configFileProvider) writeConfig(cfg domain.CoreConfiguration) error {
 	tmpPath := cfp.path + ".tmp"
 
 	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
@@ -171,7 +215,7 @@ func (cfp *configFileProvider) SaveConfig(cfg domain.CoreConfiguration) error {
 	enc := json.NewEncoder(tmp)
 	enc.SetIndent("", "    ")
 
-	clearConfig(&cfg)
+	cfg.Clear()
 
 	if err := enc.Encode(cfg); err != nil {
 		tmp.Close()
@@ -199,6 +243,56 @@ func (cfp *configFileProvider) SaveConfig(cfg domain.CoreConfiguration) error {
 	return nil
 }
 
+func (cfp *configFileProvider) goodPath() string {
+	return cfp.path + ".good"
+}
+
+// MarkGood - stores cfg as last-known-good. The config file may already hold a newer config
+// that never ran, so the copy is made from cfg and not from the file.
+func (cfp *configFileProvider) MarkGood(cfg domain.CoreConfiguration) error {
+	cfp.mu.Lock()
+	defer cfp.mu.Unlock()
+
+	data, err := json.MarshalIndent(cfg, "", "    ")
+	if err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+
+	tmpPath := cfp.goodPath() + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("write temp file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, cfp.goodPath()); err != nil {
+		return fmt.Errorf("rename: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreGood - replaces the current config with the last-known-good copy.
+func (cfp *configFileProvider) RestoreGood() error {
+	f, err := os.Open(cfp.goodPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return errors.New("no last-known-good config")
+		}
+		return fmt.Errorf("open last-known-good config: %w", err)
+	}
+	defer f.Close()
+
+	cfg := domain.CoreConfiguration{}
+	if err := json.NewDecoder(f).Decode(&cfg); err != nil {
+		return fmt.Errorf("decode last-known-good config: %w", err)
+	}
+
+	_, err = cfp.SaveRevision(cfg, domain.ConfigChange{
+		Author:  domain.SystemAuthor,
+		Comment: "restored last-known-good config",
+	})
+	return err
+}
+
 func (cfp *configFileProvider) Close() error {
 	cfp.mu.Lock()
 	defer cfp.mu.Unlock()
@@ -8,15 +8,26 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/eterline/xraymon/internal/domain"
 )
 
+const (
+	configTestTimeout = 30 * time.Second
+	outputTailLines   = 20
+)
+
 func xrayCore() string {
 	return filepath.Join(
 		"cores",
@@ -25,26 +36,92 @@ func xrayCore() string {
 }
 
 type XrayDispatcher struct {
-	bin          string
+	bin          atomic.Pointer[string]
+	api          *apiEndpoint
 	acceptStream io.Writer
-	errorStream  io.Writer
+	coreStream   io.Writer
+	stderrStream io.Writer
+
+	pid atomic.Int64 // pid of the running core, 0 - not running
+
+	sandbox   ProcessSandbox
+	stopGrace time.Duration
+	pidFile   string
+}
+
+// ProcessSandbox - restrictions of the core process.
+type ProcessSandbox interface {
+	// Start - starts the process with the restrictions applied before it executes.
+	Start(cmd *exec.Cmd) error
+}
+
+// DispatcherOption - functional option for XrayDispatcher.
+type DispatcherOption func(*XrayDispatcher)
+
+// WithSandbox - runs the core and its config tests inside the sandbox.
+func WithSandbox(sb ProcessSandbox) DispatcherOption {
+	return func(xd *XrayDispatcher) {
+		xd.sandbox = sb
+	}
+}
+
+// WithStopGrace - sets time the core gets to exit after SIGTERM before its process group is killed.
+func WithStopGrace(d time.Duration) DispatcherOption {
+	return func(xd *XrayDispatcher) {
+		if d > 0 {
+			xd.stopGrace = d
+		}
+	}
 }
 
-func NewXrayDispatcher(accept, err io.Writer) *XrayDispatcher {
-	return &XrayDispatcher{
-		bin:          xrayCore(),
+// WithPIDFile - records the running core in the pid file for TerminateStale of the next xraymon run.
+func WithPIDFile(path string) DispatcherOption {
+	return func(xd *XrayDispatcher) {
+		xd.pidFile = path
+	}
+}
+
+// NewXrayDispatcher - creates core runner. Core stdout is split into access and core log lines,
+// stderr is written to its own stream as is.
+func NewXrayDispatcher(api *apiEndpoint, accept, core, stderr io.Writer, opts ...DispatcherOption) *XrayDispatcher {
+	xd := &XrayDispatcher{
+		api:          api,
 		acceptStream: accept,
-		errorStream:  err,
+		coreStream:   core,
+		stderrStream: stderr,
+		stopGrace:    DefaultStopGrace,
 	}
+	xd.SetBinary(xrayCore())
+
+	for _, opt := range opts {
+		opt(xd)
+	}
+
+	return xd
 }
 
-func (xd *XrayDispatcher) Run(ctx context.Context, conf domain.CoreConfiguration, level string) error {
+// SetBinary - sets core executable used by the next Run and Test calls.
+func (xd *XrayDispatcher) SetBinary(path string) {
+	xd.bin.Store(&path)
+}
 
-	conf["log"] = structToRawJSON(initLogging(level))
-	conf["stats"] = structToRawJSON(initStats())
-	conf["api"] = structToRawJSON(initApiObject())
+func (xd *XrayDispatcher) binary() string {
+	return *xd.bin.Load()
+}
+
+func (xd *XrayDispatcher) Run(ctx context.Context, conf domain.CoreConfiguration, logs domain.LogSettings) error {
 
-	cmd := exec.CommandContext(ctx, xd.bin)
+	if err := xd.api.allocate(); err != nil {
+		return err
+	}
+
+	conf, err := assembleConfig(conf, logs, xd.api)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(xd.binary())
+	setProcessGroup(cmd)
 
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
@@ -57,53 +134,236 @@ func (xd *XrayDispatcher) Run(ctx context.Context, conf domain.CoreConfiguration
 		return err
 	}
 
-	if err := cmd.Start(); err != nil {
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
 		stdin.Close()
 		return err
 	}
 
+	if err := startProcess(cmd, xd.sandbox); err != nil {
+		stdin.Close()
+		return err
+	}
+
+	// from here on every return goes through terminate or reap, so the core is never left unreaped
+	lines := make(chan outputLine)
+	go streamOutput(stdout, stderr, lines)
+
+	pid := int64(cmd.Process.Pid)
+	xd.pid.Store(pid)
+	defer xd.pid.CompareAndSwap(pid, 0)
+
+	if err := xd.writePIDFile(cmd.Process.Pid); err != nil {
+		stdin.Close()
+		_ = xd.terminate(cmd, lines)
+		return fmt.Errorf("write pid file: %w", err)
+	}
+
 	if err := json.NewEncoder(stdin).Encode(conf); err != nil {
-		_ = stdin.Close()
+		stdin.Close()
+		_ = xd.terminate(cmd, lines)
 		return err
 	}
 	stdin.Close()
 
-	lines := make(chan []byte)
-	go streamLines(stdout, lines)
+	tail := newLineTail(outputTailLines)
 
 	for {
 		select {
 		case <-ctx.Done():
-			return cmd.Process.Kill()
+			_ = xd.terminate(cmd, lines)
+			return nil
 		case line, ok := <-lines:
 			if !ok {
-				return cmd.Wait()
+				return exitError(xd.reap(cmd, lines), tail)
 			}
+			tail.add(line.data)
 			if err := xd.streamLog(line); err != nil {
+				_ = xd.terminate(cmd, lines)
 				return err
 			}
 		}
 	}
 }
 
+// PID - returns pid of the running core process, 0 when the core is not running.
+func (xd *XrayDispatcher) PID() int {
+	return int(xd.pid.Load())
+}
+
+// Test - runs the core binary in test mode against the fully assembled config.
+// Config errors reported by the core are wrapped into domain.ErrConfigRejected.
+func (xd *XrayDispatcher) Test(ctx context.Context, conf domain.CoreConfiguration, logs domain.LogSettings) error {
+	full, err := assembleConfig(conf, logs, xd.api)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(full)
+	if err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, configTestTimeout)
+	defer cancel()
+
+	var out bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, xd.binary(), "run", "-test")
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	if err := startProcess(cmd, xd.sandbox); err != nil {
+		return fmt.Errorf("config test: %w", err)
+	}
+
+	err = cmd.Wait()
+	if err == nil {
+		return nil
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && ctx.Err() == nil {
+		return fmt.Errorf("%w: %s", domain.ErrConfigRejected, testFailure(out.Bytes()))
+	}
+
+	return fmt.Errorf("config test: %w", err)
+}
+
 // ----------------- Helpers -----------------
 
-func streamLines(r io.Reader, out chan<- []byte) {
-	sc := bufio.NewScanner(r)
-	defer close(out)
+// startProcess - starts cmd inside the sandbox when there is one.
+func startProcess(cmd *exec.Cmd, sb ProcessSandbox) error {
+	if sb == nil {
+		return cmd.Start()
+	}
+	if err := sb.Start(cmd); err != nil {
+		return fmt.Errorf("sandbox: %w", err)
+	}
+	return nil
+}
 
-	for sc.Scan() {
-		b := append([]byte(nil), sc.Bytes()...) // copy
-		out <- b
+// assembleConfig - returns a copy of conf with injected log, stats and api sections.
+func assembleConfig(conf domain.CoreConfiguration, logs domain.LogSettings, api *apiEndpoint) (domain.CoreConfiguration, error) {
+	full := make(domain.CoreConfiguration, len(conf)+3)
+	for key, value := range conf {
+		full[key] = value
 	}
+
+	full.Clear()
+
+	full["log"] = structToRawJSON(initLogging(logs))
+	full["stats"] = structToRawJSON(initStats())
+
+	if err := api.inject(full); err != nil {
+		return nil, fmt.Errorf("inject api: %w", err)
+	}
+
+	return full, nil
 }
 
-func (xd *XrayDispatcher) streamLog(p []byte) error {
-	if bytes.Contains(p, []byte("accepted")) {
-		_, err := xd.acceptStream.Write(p)
-		return err
+// testFailure - extracts the core error text from the test mode output.
+func testFailure(out []byte) string {
+	const prefix = "Failed to start:"
+
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, prefix))
+		}
 	}
-	_, err := xd.errorStream.Write(p)
+
+	return strings.TrimSpace(string(out))
+}
+
+// exitError - wraps process exit error with exit code or signal and the output tail.
+func exitError(err error, tail *lineTail) error {
+	if err == nil {
+		return nil
+	}
+
+	ee := &domain.CoreExitError{
+		Err:      err,
+		ExitCode: -1,
+		Output:   tail.lines(),
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		ee.ExitCode = exitErr.ExitCode()
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			ee.Signal = ws.Signal().String()
+		}
+	}
+
+	return ee
+}
+
+// lineTail - ring of the last output lines.
+type lineTail struct {
+	buf  []string
+	next int
+	full bool
+}
+
+func newLineTail(n int) *lineTail {
+	return &lineTail{buf: make([]string, n)}
+}
+
+func (lt *lineTail) add(line []byte) {
+	lt.buf[lt.next] = string(line)
+	lt.next = (lt.next + 1) % len(lt.buf)
+	if lt.next == 0 {
+		lt.full = true
+	}
+}
+
+func (lt *lineTail) lines() []string {
+	if !lt.full {
+		return append([]string(nil), lt.buf[:lt.next]...)
+	}
+	return append(append([]string(nil), lt.buf[lt.next:]...), lt.buf[:lt.next]...)
+}
+
+// outputLine - core output line with its source stream.
+type outputLine struct {
+	data   []byte
+	stderr bool
+}
+
+// streamOutput - reads both core streams line by line, out is closed when both reach EOF.
+func streamOutput(stdout, stderr io.Reader, out chan<- outputLine) {
+	var wg sync.WaitGroup
+
+	scan := func(r io.Reader, isStderr bool) {
+		defer wg.Done()
+
+		sc := bufio.NewScanner(r)
+		for sc.Scan() {
+			b := append([]byte(nil), sc.Bytes()...) // copy
+			out <- outputLine{data: b, stderr: isStderr}
+		}
+	}
+
+	wg.Add(2)
+	go scan(stdout, false)
+	go scan(stderr, true)
+
+	wg.Wait()
+	close(out)
+}
+
+func (xd *XrayDispatcher) streamLog(line outputLine) error {
+	w := xd.coreStream
+	switch {
+	case line.stderr:
+		w = xd.stderrStream
+	case classifyLine(line.data) == lineAccess:
+		w = xd.acceptStream
+	}
+
+	_, err := w.Write(line.data)
 	return err
 }
 
@@ -0,0 +1,235 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package xraycommon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/eterline/xraymon/internal/domain"
+	xrayapi "github.com/eterline/xraymon/internal/infra/xray/api"
+)
+
+const (
+	// apiAuto - endpoint spec, same as config.APIAuto: free loopback TCP port picked before every core start.
+	apiAuto = "auto"
+	// apiUnix - endpoint spec, same as config.APIUnix: unix socket in the instance runtime directory.
+	apiUnix = "unix"
+
+	apiSocketName = "api.sock"
+	apiInboundTag = "xraymon-api"
+)
+
+// apiEndpoint - core API endpoint of an instance with the client connected to it.
+// The endpoint is allocated and the client re-dialed before every core start.
+type apiEndpoint struct {
+	spec string // fixed "host:port", apiAuto, apiUnix or "unix:///path" of an attached core
+	dir  string // instance runtime directory for apiUnix
+
+	mu     sync.RWMutex
+	listen string // current TCP listen address, empty for apiUnix
+	api    *xrayapi.XrayAPI
+}
+
+// NewAPIEndpoint - creates endpoint by spec. runtimeDir is used for unix sockets only.
+func NewAPIEndpoint(spec, runtimeDir string) (*apiEndpoint, error) {
+	ep := &apiEndpoint{spec: spec}
+
+	switch {
+	case spec == apiUnix:
+		ep.dir = runtimeDir
+		if err := ensureRuntimeDir(ep.dir); err != nil {
+			return nil, err
+		}
+	case spec == apiAuto:
+		ep.listen = "127.0.0.1:0"
+	case externalSocket(spec):
+		// socket of an externally managed core, never injected into a config
+		ep.listen = spec
+	default:
+		if _, _, err := net.SplitHostPort(spec); err != nil {
+			return nil, fmt.Errorf("invalid api address %q: %w", spec, err)
+		}
+		ep.listen = spec
+	}
+
+	if err := ep.dial(); err != nil {
+		return nil, err
+	}
+
+	return ep, nil
+}
+
+// SetOwner - hands the runtime directory over to the core user, it creates the socket there.
+func (ep *apiEndpoint) SetOwner(uid, gid int) error {
+	if ep.dir == "" {
+		return nil
+	}
+	return os.Chown(ep.dir, uid, gid)
+}
+
+func (ep *apiEndpoint) socketPath() string {
+	return filepath.Join(ep.dir, apiSocketName)
+}
+
+// target - returns gRPC dial target of the current endpoint. Must be called with ep.mu held.
+func (ep *apiEndpoint) target() string {
+	if ep.spec == apiUnix {
+		return "unix://" + ep.socketPath()
+	}
+	return ep.listen
+}
+
+// dial - replaces API client with a new one connected to the current endpoint. Must be called with ep.mu held.
+func (ep *apiEndpoint) dial() error {
+	api, err := xrayapi.New(ep.target())
+	if err != nil {
+		return err
+	}
+
+	if ep.api != nil {
+		ep.api.Close()
+	}
+	ep.api = api
+
+	return nil
+}
+
+// allocate - prepares endpoint for the next core start: picks a free port or removes the stale socket,
+// then re-dials the client.
+func (ep *apiEndpoint) allocate() error {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	switch ep.spec {
+	case apiAuto:
+		addr, err := freeLoopbackAddr()
+		if err != nil {
+			return fmt.Errorf("allocate api port: %w", err)
+		}
+		ep.listen = addr
+	case apiUnix:
+		for _, p := range []string{ep.socketPath(), ep.socketPath() + ".lock"} {
+			if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("remove stale api socket: %w", err)
+			}
+		}
+	}
+
+	return ep.dial()
+}
+
+// API - returns client of the current endpoint.
+func (ep *apiEndpoint) API() *xrayapi.XrayAPI {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+
+	return ep.api
+}
+
+// Addr - returns current endpoint address for logs.
+func (ep *apiEndpoint) Addr() string {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+
+	return ep.target()
+}
+
+// Close - closes the client, calls through the closed client fail with "not initialized" errors.
+func (ep *apiEndpoint) Close() error {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	return ep.api.Close()
+}
+
+// inject - adds api section and, for unix socket, the api inbound with its routing rule to the config.
+func (ep *apiEndpoint) inject(full domain.CoreConfiguration) error {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+
+	if ep.spec != apiUnix {
+		full["api"] = structToRawJSON(initApiObject(ep.listen))
+		return nil
+	}
+
+	// api without listen is served through a tagged inbound routed to the api tag
+	full["api"] = structToRawJSON(initApiObject(""))
+
+	inbound := map[string]any{
+		"tag":      apiInboundTag,
+		"listen":   ep.socketPath(),
+		"protocol": "dokodemo-door",
+		// dokodemo can't take the port from a unix socket address, the api outbound ignores it anyway
+		"settings": map[string]any{"address": "127.0.0.1", "port": 1, "network": "unix"},
+	}
+
+	rule := map[string]any{
+		"type":        "field",
+		"inboundTag":  []string{apiInboundTag},
+		"outboundTag": initApiObject("").Tag,
+	}
+
+	var inbounds []json.RawMessage
+	if raw, ok := full["inbounds"]; ok && !isJSONNull(raw) {
+		if err := json.Unmarshal(raw, &inbounds); err != nil {
+			return fmt.Errorf("decode inbounds: %w", err)
+		}
+	}
+	full["inbounds"] = structToRawJSON(append(inbounds, structToRawJSON(inbound)))
+
+	routing := map[string]json.RawMessage{}
+	if raw, ok := full["routing"]; ok && !isJSONNull(raw) {
+		if err := json.Unmarshal(raw, &routing); err != nil {
+			return fmt.Errorf("decode routing: %w", err)
+		}
+	}
+
+	var rules []json.RawMessage
+	if raw, ok := routing["rules"]; ok && !isJSONNull(raw) {
+		if err := json.Unmarshal(raw, &rules); err != nil {
+			return fmt.Errorf("decode routing rules: %w", err)
+		}
+	}
+	routing["rules"] = structToRawJSON(append([]json.RawMessage{structToRawJSON(rule)}, rules...))
+	full["routing"] = structToRawJSON(routing)
+
+	return nil
+}
+
+// ensureRuntimeDir - creates the private instance runtime directory.
+func ensureRuntimeDir(dir string) error {
+	// parent stays traversable for the core user, the instance dir is private
+	if err := os.MkdirAll(filepath.Dir(dir), 0o711); err != nil {
+		return fmt.Errorf("create runtime dir: %w", err)
+	}
+	if err := os.Mkdir(dir, 0o700); err != nil && !errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("create runtime dir: %w", err)
+	}
+	return nil
+}
+
+func externalSocket(spec string) bool {
+	return strings.HasPrefix(spec, "unix://")
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return len(raw) == 0 || string(raw) == "null"
+}
+
+func freeLoopbackAddr() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer l.Close()
+
+	return l.Addr().String(), nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package xraycommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/xtls/xray-core/infra/conf"
+)
+
+// handlerProvider - applies inbound and outbound JSON objects to the running core through HandlerService.
+type handlerProvider struct {
+	api *apiEndpoint
+}
+
+func NewHandlerProvider(api *apiEndpoint) *handlerProvider {
+	return &handlerProvider{api: api}
+}
+
+func (hp *handlerProvider) AddInbound(ctx context.Context, raw json.RawMessage) error {
+	var detour conf.InboundDetourConfig
+	if err := json.Unmarshal(raw, &detour); err != nil {
+		return fmt.Errorf("decode inbound: %w", err)
+	}
+
+	cfg, err := detour.Build()
+	if err != nil {
+		return fmt.Errorf("build inbound %q: %w", detour.Tag, err)
+	}
+
+	return hp.api.API().AddInbound(ctx, cfg)
+}
+
+func (hp *handlerProvider) RemoveInbound(ctx context.Context, tag string) error {
+	return hp.api.API().RemoveInbound(ctx, tag)
+}
+
+func (hp *handlerProvider) AddOutbound(ctx context.Context, raw json.RawMessage) error {
+	var detour conf.OutboundDetourConfig
+	if err := json.Unmarshal(raw, &detour); err != nil {
+		return fmt.Errorf("decode outbound: %w", err)
+	}
+
+	cfg, err := detour.Build()
+	if err != nil {
+		return fmt.Errorf("build outbound %q: %w", detour.Tag, err)
+	}
+
+	return hp.api.API().AddOutbound(ctx, cfg)
+}
+
+func (hp *handlerProvider) RemoveOutbound(ctx context.Context, tag string) error {
+	return hp.api.API().RemoveOutbound(ctx, tag)
+}
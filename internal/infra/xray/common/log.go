@@ -14,14 +14,15 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/eterline/xraymon/internal/domain"
 )
 
 var (
 	accessReg = regexp.MustCompile(
-		`from (?P<client>[^ ]+)\s+accepted\s+(?P<target>[^ ]+)\s+\` +
-			`[(?P<inbound>[^ ]+)\s*(?:>>|=>|->)\s*(?P<outbound>[^ ]+)\]` +
+		`from (?P<client>[^ ]+)\s+accepted\s+(?P<target>[^ ]+)` +
+			`(?:\s+\[(?P<inbound>[^ ]+)\s*(?:>>|=>|->)\s*(?P<outbound>[^ ]+)\])?` + // absent without routing detour
 			`(?:\s+email:\s*(?P<email>[A-Za-z0-9._\-]+))?`,
 	)
 
@@ -32,6 +33,8 @@ var (
 
 type basicLogger struct {
 	logger *slog.Logger
+	name   string
+	events domain.EventPublisher
 
 	fileMu sync.Mutex
 	path   string
@@ -45,9 +48,11 @@ func (bl *basicLogger) Close() error {
 	return nil
 }
 
-func newBasicLogger(path string) (*basicLogger, error) {
+func newBasicLogger(path, name string, pub domain.EventPublisher) (*basicLogger, error) {
 	bl := &basicLogger{
-		path: path,
+		path:   path,
+		name:   name,
+		events: pub,
 	}
 
 	if err := bl.newLog(false); err != nil {
@@ -90,7 +95,15 @@ func (bl *basicLogger) newLog(rotate bool) error {
 }
 
 func (bl *basicLogger) Rotate() error {
-	return bl.newLog(true)
+	if err := bl.newLog(true); err != nil {
+		return err
+	}
+
+	ev := domain.NewEvent(domain.EventJournalRotated, bl.name+" journal rotated")
+	ev.Journal = bl.name
+	bl.events.Publish(ev)
+
+	return nil
 }
 
 // ========================
@@ -101,8 +114,8 @@ type coreLogger struct {
 	lastLineMu sync.RWMutex
 }
 
-func NewCoreLogger(path string) (*coreLogger, error) {
-	base, err := newBasicLogger(path)
+func NewCoreLogger(path string, pub domain.EventPublisher) (*coreLogger, error) {
+	base, err := newBasicLogger(path, "core", pub)
 	if err != nil {
 		return nil, err
 	}
@@ -156,8 +169,8 @@ type accessLogger struct {
 	*basicLogger
 }
 
-func NewAccessLogger(path string) (*accessLogger, error) {
-	base, err := newBasicLogger(path)
+func NewAccessLogger(path string, pub domain.EventPublisher) (*accessLogger, error) {
+	base, err := newBasicLogger(path, "access", pub)
 	if err != nil {
 		return nil, err
 	}
@@ -188,56 +201,111 @@ func (al *accessLogger) Write(p []byte) (int, error) {
 	return len(p), nil
 }
 
-func (l *accessLogger) readAllConnections() ([]domain.ConnectionMetadata, error) {
-	l.fileMu.Lock()
-	defer l.fileMu.Unlock()
-
-	f, err := os.Open(l.path)
+func (al *accessLogger) LastConnections(ctx context.Context, n int) ([]domain.ConnectionMetadata, error) {
+	lines, err := al.lastRecords(ctx, n)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
-
-	var list []domain.ConnectionMetadata
 
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		raw := scanner.Bytes()
+	result := make([]domain.ConnectionMetadata, 0, len(lines))
+	for _, raw := range lines {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 
 		var obj map[string]string
-		if json.Unmarshal(raw, &obj) != nil {
+		if err := json.Unmarshal(raw, &obj); err != nil {
 			continue
 		}
 
-		m := domain.ConnectionMetadata{
+		result = append(result, domain.ConnectionMetadata{
 			Client:   obj["client"],
 			Server:   obj["target"],
 			Proto:    obj["proto"],
 			Inbound:  obj["inbound"],
 			Outbound: obj["outbound"],
 			User:     obj["user"],
-		}
+		})
+	}
+
+	return result, nil
+}
+
+// ========================
+
+type stderrLogger struct {
+	*basicLogger
+}
 
-		list = append(list, m)
+// NewStderrLogger - journal of raw core stderr lines: panics, fatal errors and other unstructured output.
+func NewStderrLogger(path string, pub domain.EventPublisher) (*stderrLogger, error) {
+	base, err := newBasicLogger(path, "stderr", pub)
+	if err != nil {
+		return nil, err
 	}
 
-	return list, nil
+	return &stderrLogger{basicLogger: base}, nil
 }
 
-func (al *accessLogger) LastConnections(ctx context.Context, n int) ([]domain.ConnectionMetadata, error) {
-	if n <= 0 {
-		return al.readAllConnections()
+func (sl *stderrLogger) Write(p []byte) (int, error) {
+	if len(bytes.TrimSpace(p)) == 0 {
+		return len(p), nil
 	}
 
-	al.fileMu.Lock()
-	defer al.fileMu.Unlock()
+	sl.logger.Info("core stderr", "line", string(p))
+
+	return len(p), nil
+}
 
-	f, err := os.Open(al.path)
+// LastLines - returns the last n stderr lines, all lines when n <= 0.
+func (sl *stderrLogger) LastLines(ctx context.Context, n int) ([]domain.JournalLine, error) {
+	lines, err := sl.lastRecords(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.JournalLine, 0, len(lines))
+	for _, raw := range lines {
+		var obj struct {
+			Time time.Time `json:"time"`
+			Line string    `json:"line"`
+		}
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			continue
+		}
+
+		result = append(result, domain.JournalLine{Time: obj.Time, Line: obj.Line})
+	}
+
+	return result, nil
+}
+
+// ========================
+
+// lastRecords - returns the last n journal records in file order, all records when n <= 0.
+func (bl *basicLogger) lastRecords(ctx context.Context, n int) ([][]byte, error) {
+	bl.fileMu.Lock()
+	defer bl.fileMu.Unlock()
+
+	f, err := os.Open(bl.path)
 	if err != nil {
 		return nil, err
 	}
 	defer f.Close()
 
+	if n <= 0 {
+		var lines [][]byte
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			if json.Valid(scanner.Bytes()) {
+				lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+			}
+		}
+
+		return lines, scanner.Err()
+	}
+
 	stat, err := f.Stat()
 	if err != nil {
 		return nil, err
@@ -304,28 +372,7 @@ func (al *accessLogger) LastConnections(ctx context.Context, n int) ([]domain.Co
 		lines[i], lines[j] = lines[j], lines[i]
 	}
 
-	result := make([]domain.ConnectionMetadata, 0, len(lines))
-	for _, raw := range lines {
-		if err := ctx.Err(); err != nil {
-			return nil, err
-		}
-
-		var obj map[string]string
-		if err := json.Unmarshal(raw, &obj); err != nil {
-			continue
-		}
-
-		result = append(result, domain.ConnectionMetadata{
-			Client:   obj["client"],
-			Server:   obj["target"],
-			Proto:    obj["proto"],
-			Inbound:  obj["inbound"],
-			Outbound: obj["outbound"],
-			User:     obj["user"],
-		})
-	}
-
-	return result, nil
+	return lines, nil
 }
 
 // ========================
@@ -347,6 +394,26 @@ func parseCoreLine(line []byte) (meta coreLineFields, ok bool) {
 	return meta, true
 }
 
+// lineKind - journal a core stdout line belongs to.
+type lineKind int
+
+const (
+	lineCore lineKind = iota
+	lineAccess
+)
+
+// classifyLine - core log lines carry a [Level] tag, access lines are matched by the access record format.
+// Anything else stays in the core journal.
+func classifyLine(line []byte) lineKind {
+	if _, ok := parseCoreLine(line); ok {
+		return lineCore
+	}
+	if _, ok := parseAccess(line); ok {
+		return lineAccess
+	}
+	return lineCore
+}
+
 // ======
 
 type accessFields struct {
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package xraycommon
+
+import "testing"
+
+func Test_ClassifyLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want lineKind
+	}{
+		{
+			"access",
+			"2025/03/14 10:30:00.123456 from 10.0.0.2:51234 accepted tcp:example.com:443 [vless-in >> direct] email: user@example",
+			lineAccess,
+		},
+		{
+			"access without email",
+			"2025/03/14 10:30:00.123456 from 10.0.0.2:51234 accepted udp:1.1.1.1:53 [dns-in -> dns-out]",
+			lineAccess,
+		},
+		{
+			"access without routing detour",
+			"2025/03/14 10:30:00.123456 from tcp:127.0.0.1:59612 accepted tcp:127.0.0.1:443",
+			lineAccess,
+		},
+		{
+			"core line mentioning accepted",
+			"2025/03/14 10:30:00.123456 [Info] [1234] proxy/vless/inbound: connection accepted from 10.0.0.2",
+			lineCore,
+		},
+		{
+			"core line with access record payload",
+			"2025/03/14 10:30:00.123456 [Warning] from 10.0.0.2:1 accepted tcp:a:1 [in >> out]",
+			lineCore,
+		},
+		{
+			"unstructured",
+			"Xray 25.12.2 (Xray, Penetrates Everything.) Custom (go1.25 linux/amd64)",
+			lineCore,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyLine([]byte(tt.line)); got != tt.want {
+				t.Errorf("classifyLine = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
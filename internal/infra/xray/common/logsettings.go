@@ -0,0 +1,75 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package xraycommon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/eterline/xraymon/internal/domain"
+)
+
+// logSettingsFile - core log settings persisted in a JSON file.
+type logSettingsFile struct {
+	path     string
+	defaults domain.LogSettings
+
+	mu sync.Mutex
+}
+
+// NewLogSettingsFile - creates store, defaults are returned until settings are saved.
+func NewLogSettingsFile(path string, defaults domain.LogSettings) *logSettingsFile {
+	return &logSettingsFile{
+		path:     path,
+		defaults: defaults,
+	}
+}
+
+func (lsf *logSettingsFile) LoadLogSettings() (domain.LogSettings, error) {
+	lsf.mu.Lock()
+	defer lsf.mu.Unlock()
+
+	data, err := os.ReadFile(lsf.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return lsf.defaults, nil
+	}
+	if err != nil {
+		return lsf.defaults, fmt.Errorf("read log settings: %w", err)
+	}
+
+	ls := lsf.defaults
+	if err := json.Unmarshal(data, &ls); err != nil {
+		return lsf.defaults, fmt.Errorf("decode log settings: %w", err)
+	}
+
+	if err := ls.Validate(); err != nil {
+		return lsf.defaults, fmt.Errorf("log settings %s: %w", lsf.path, err)
+	}
+
+	return ls, nil
+}
+
+func (lsf *logSettingsFile) SaveLogSettings(ls domain.LogSettings) error {
+	lsf.mu.Lock()
+	defer lsf.mu.Unlock()
+
+	data, err := json.MarshalIndent(ls, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := lsf.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("write temp file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, lsf.path); err != nil {
+		return fmt.Errorf("rename: %w", err)
+	}
+
+	return nil
+}
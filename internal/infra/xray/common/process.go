@@ -0,0 +1,181 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package xraycommon
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/eterline/xraymon/internal/infra/procfs"
+)
+
+const (
+	// DefaultStopGrace - time the core gets to exit after SIGTERM before the process group is killed.
+	DefaultStopGrace = 5 * time.Second
+
+	staleKillWait = time.Second
+	stalePoll     = 100 * time.Millisecond
+)
+
+// setProcessGroup - starts the core as a process group leader, so its children are signalled with it.
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// signalGroup - sends sig to the process group led by pid. A group that is already gone is not an error.
+func signalGroup(pid int, sig syscall.Signal) error {
+	err := syscall.Kill(-pid, sig)
+	if errors.Is(err, syscall.ESRCH) {
+		return nil
+	}
+	return err
+}
+
+// terminate - stops the core process group: SIGTERM first, SIGKILL when the core outlives the stop grace.
+// Output written while stopping still goes to the journals, the process is always reaped.
+func (xd *XrayDispatcher) terminate(cmd *exec.Cmd, lines <-chan outputLine) error {
+	pid := cmd.Process.Pid
+
+	if err := signalGroup(pid, syscall.SIGTERM); err != nil {
+		_ = cmd.Process.Kill()
+	}
+
+	kill := time.AfterFunc(xd.stopGrace, func() {
+		_ = signalGroup(pid, syscall.SIGKILL)
+	})
+	defer kill.Stop()
+
+	return xd.reap(cmd, lines)
+}
+
+// reap - journals the rest of the core output, waits for the process and kills what is left of its group.
+func (xd *XrayDispatcher) reap(cmd *exec.Cmd, lines <-chan outputLine) error {
+	for line := range lines {
+		_ = xd.streamLog(line)
+	}
+
+	err := cmd.Wait()
+	_ = signalGroup(cmd.Process.Pid, syscall.SIGKILL)
+	xd.removePIDFile()
+
+	return err
+}
+
+// ----------------- PID file -----------------
+
+// writePIDFile - records pid and start time of the running core, so a later xraymon run can find it.
+func (xd *XrayDispatcher) writePIDFile(pid int) error {
+	if xd.pidFile == "" {
+		return nil
+	}
+
+	start, err := procfs.StartTime(pid)
+	if err != nil {
+		return err
+	}
+
+	if err := ensureRuntimeDir(filepath.Dir(xd.pidFile)); err != nil {
+		return err
+	}
+
+	return os.WriteFile(xd.pidFile, []byte(fmt.Sprintf("%d %d\n", pid, start)), 0o600)
+}
+
+func (xd *XrayDispatcher) removePIDFile() {
+	if xd.pidFile != "" {
+		_ = os.Remove(xd.pidFile)
+	}
+}
+
+// readPIDFile - returns pid and start time of the recorded core.
+func readPIDFile(path string) (pid int, start uint64, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("malformed pid file %q", path)
+	}
+
+	if pid, err = strconv.Atoi(fields[0]); err != nil || pid <= 0 {
+		return 0, 0, fmt.Errorf("malformed pid file %q", path)
+	}
+	if start, err = strconv.ParseUint(fields[1], 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("malformed pid file %q", path)
+	}
+
+	return pid, start, nil
+}
+
+// TerminateStale - stops the core left running by a previous xraymon run, so it frees the API endpoint
+// and the cgroup before a new core starts. The core is found by the pid file and recognized by its start
+// time, a reused pid is left alone. Returns pid of the stopped core, 0 when there was none.
+func TerminateStale(pidFile string, grace time.Duration) (int, error) {
+	pid, start, err := readPIDFile(pidFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		_ = removeFile(pidFile)
+		return 0, err
+	}
+
+	alive := func() bool {
+		st, err := procfs.StartTime(pid)
+		return err == nil && st == start
+	}
+
+	if !alive() {
+		return 0, removeFile(pidFile)
+	}
+
+	if err := signalGroup(pid, syscall.SIGTERM); err != nil {
+		return pid, fmt.Errorf("terminate stale core %d: %w", pid, err)
+	}
+
+	if waitGone(alive, grace) {
+		return pid, removeFile(pidFile)
+	}
+
+	if err := signalGroup(pid, syscall.SIGKILL); err != nil {
+		return pid, fmt.Errorf("kill stale core %d: %w", pid, err)
+	}
+
+	if !waitGone(alive, staleKillWait) {
+		return pid, fmt.Errorf("stale core %d is still running", pid)
+	}
+
+	return pid, removeFile(pidFile)
+}
+
+func removeFile(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// waitGone - polls alive until it reports false or timeout passes.
+func waitGone(alive func() bool, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for alive() {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(stalePoll)
+	}
+	return true
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package xraycommon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eterline/xraymon/internal/domain"
+)
+
+// DefaultConfigRevisions - number of config revisions kept when retention is not set.
+const DefaultConfigRevisions = 50
+
+const revisionExt = ".json"
+
+// revisionStore - numbered config revisions, one file per revision in a directory next to the config.
+type revisionStore struct {
+	dir  string
+	keep int // kept revisions, 0 - unlimited
+}
+
+// revisionFile - on-disk form of a revision.
+type revisionFile struct {
+	Number  uint64                   `json:"number"`
+	Time    time.Time                `json:"time"`
+	Author  string                   `json:"author"`
+	Comment string                   `json:"comment,omitempty"`
+	Hash    string                   `json:"hash"`
+	Config  domain.CoreConfiguration `json:"config"`
+}
+
+func (rf revisionFile) revision() domain.ConfigRevision {
+	return domain.ConfigRevision{
+		Number:  rf.Number,
+		Time:    rf.Time,
+		Author:  rf.Author,
+		Comment: rf.Comment,
+		Hash:    rf.Hash,
+		Config:  rf.Config,
+	}
+}
+
+func (rs *revisionStore) path(n uint64) string {
+	return filepath.Join(rs.dir, fmt.Sprintf("%08d%s", n, revisionExt))
+}
+
+// numbers - returns kept revision numbers in ascending order.
+func (rs *revisionStore) numbers() ([]uint64, error) {
+	entries, err := os.ReadDir(rs.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read revisions: %w", err)
+	}
+
+	var list []uint64
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), revisionExt)
+		if !ok || e.IsDir() {
+			continue
+		}
+		if n, err := strconv.ParseUint(name, 10, 64); err == nil {
+			list = append(list, n)
+		}
+	}
+
+	slices.Sort(list)
+	return list, nil
+}
+
+func (rs *revisionStore) read(n uint64) (revisionFile, error) {
+	data, err := os.ReadFile(rs.path(n))
+	if errors.Is(err, os.ErrNotExist) {
+		return revisionFile{}, fmt.Errorf("%w: %d", domain.ErrRevisionNotFound, n)
+	}
+	if err != nil {
+		return revisionFile{}, fmt.Errorf("read revision %d: %w", n, err)
+	}
+
+	var rf revisionFile
+	if err := json.Unmarshal(data, &rf); err != nil {
+		return revisionFile{}, fmt.Errorf("decode revision %d: %w", n, err)
+	}
+
+	return rf, nil
+}
+
+// record - stores cfg as the next revision unless it equals the latest one, then applies retention.
+func (rs *revisionStore) record(cfg domain.CoreConfiguration, change domain.ConfigChange) (domain.ConfigRevision, error) {
+	list, err := rs.numbers()
+	if err != nil {
+		return domain.ConfigRevision{}, err
+	}
+
+	hash := cfg.Hash()
+
+	var next uint64 = 1
+	if len(list) > 0 {
+		last := list[len(list)-1]
+		latest, err := rs.read(last)
+		if err != nil {
+			return domain.ConfigRevision{}, err
+		}
+		if latest.Hash == hash {
+			return latest.revision(), nil
+		}
+		next = last + 1
+	}
+
+	rf := revisionFile{
+		Number:  next,
+		Time:    time.Now(),
+		Author:  change.Author,
+		Comment: change.Comment,
+		Hash:    hash,
+		Config:  cfg,
+	}
+
+	data, err := json.MarshalIndent(rf, "", "    ")
+	if err != nil {
+		return domain.ConfigRevision{}, fmt.Errorf("encode revision: %w", err)
+	}
+
+	if err := os.MkdirAll(rs.dir, 0o755); err != nil {
+		return domain.ConfigRevision{}, fmt.Errorf("create revisions dir: %w", err)
+	}
+
+	tmpPath := rs.path(next) + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return domain.ConfigRevision{}, fmt.Errorf("write revision: %w", err)
+	}
+	if err := os.Rename(tmpPath, rs.path(next)); err != nil {
+		return domain.ConfigRevision{}, fmt.Errorf("rename revision: %w", err)
+	}
+
+	rs.prune(append(list, next))
+
+	return rf.revision(), nil
+}
+
+// prune - removes the oldest revisions above the retention limit.
+func (rs *revisionStore) prune(list []uint64) {
+	if rs.keep <= 0 || len(list) <= rs.keep {
+		return
+	}
+
+	for _, n := range list[:len(list)-rs.keep] {
+		_ = os.Remove(rs.path(n))
+	}
+}
+
+// list - returns kept revisions newest first, without configs.
+func (rs *revisionStore) list() ([]domain.ConfigRevision, error) {
+	numbers, err := rs.numbers()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.ConfigRevision, 0, len(numbers))
+	for i := len(numbers) - 1; i >= 0; i-- {
+		rf, err := rs.read(numbers[i])
+		if errors.Is(err, domain.ErrRevisionNotFound) {
+			continue // pruned while listing
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rev := rf.revision()
+		rev.Config = nil
+		result = append(result, rev)
+	}
+
+	return result, nil
+}
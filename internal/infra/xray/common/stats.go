@@ -2,7 +2,6 @@ package xraycommon
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/cespare/xxhash"
@@ -11,20 +10,13 @@ import (
 )
 
 type statsProvider struct {
-	api *xrayapi.XrayAPI
+	api *apiEndpoint
 }
 
-func NewStatsProvider() (*statsProvider, error) {
-	api, err := xrayapi.New(apiListenAddr)
-	if err != nil {
-		return nil, fmt.Errorf("failed init stats provider: %w", err)
-	}
-
-	p := &statsProvider{
+func NewStatsProvider(api *apiEndpoint) *statsProvider {
+	return &statsProvider{
 		api: api,
 	}
-
-	return p, nil
 }
 
 func trafficKey(t xrayapi.Traffic) uint64 {
@@ -42,7 +34,7 @@ func trafficKey(t xrayapi.Traffic) uint64 {
 }
 
 func (sp *statsProvider) Stats(ctx context.Context) ([]domain.StatsSnapshot, error) {
-	tr0, cl0, err := sp.api.GetTraffic(ctx, false)
+	tr0, cl0, err := sp.api.API().GetTraffic(ctx, false)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +45,7 @@ func (sp *statsProvider) Stats(ctx context.Context) ([]domain.StatsSnapshot, err
 	case <-time.After(1 * time.Second):
 	}
 
-	tr1, cl1, err := sp.api.GetTraffic(ctx, false)
+	tr1, cl1, err := sp.api.API().GetTraffic(ctx, false)
 	if err != nil {
 		return nil, err
 	}
@@ -112,3 +104,9 @@ func (sp *statsProvider) Stats(ctx context.Context) ([]domain.StatsSnapshot, err
 
 	return snapshots, nil
 }
+
+// Probe - checks that the core API answers.
+func (sp *statsProvider) Probe(ctx context.Context) error {
+	_, err := sp.api.API().SysStats(ctx)
+	return err
+}
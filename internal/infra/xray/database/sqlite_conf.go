@@ -3,43 +3,22 @@ package database
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/eterline/xraymon/internal/domain"
-	"github.com/eterline/xraymon/internal/utils/usecase"
 )
 
-var allowedFields = usecase.NewWhitelist(
-	"log",
-	"api",
-	"dns",
-	"routing",
-	"policy",
-	"inbounds",
-	"outbounds",
-	"transport",
-	"stats",
-	"reverse",
-	"fakedns",
-	"metrics",
-	"observatory",
-	"burstObservatory",
-)
-
-func clearConfig(ccf *domain.CoreConfiguration) {
-	for key := range *ccf {
-		if !allowedFields.Allowed(key) {
-			delete(*ccf, key)
-		}
-	}
-}
-
 type sqlConfig struct {
-	db *sql.DB
+	db     *sql.DB
+	events domain.EventPublisher
+	keep   int // kept revisions, 0 - unlimited
 }
 
-func NewSQLiteConfig(db *sql.DB) (*sqlConfig, error) {
-	c := &sqlConfig{db: db}
+// NewSQLiteConfig - config stored by sections with a revision row for every saved config.
+func NewSQLiteConfig(db *sql.DB, pub domain.EventPublisher, keep int) (*sqlConfig, error) {
+	c := &sqlConfig{db: db, events: pub, keep: keep}
 	if err := c.init(); err != nil {
 		return nil, err
 	}
@@ -51,41 +30,188 @@ func (c *sqlConfig) init() error {
 	CREATE TABLE IF NOT EXISTS CoreConfig (
 		key   TEXT PRIMARY KEY,
 		value BLOB NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS CoreConfigRevision (
+		number  INTEGER PRIMARY KEY AUTOINCREMENT,
+		time    INTEGER NOT NULL,
+		author  TEXT NOT NULL,
+		comment TEXT NOT NULL,
+		hash    TEXT NOT NULL,
+		config  BLOB NOT NULL
 	);`
 	_, err := c.db.Exec(query)
 	return err
 }
 
 func (c *sqlConfig) SaveConfig(cfg domain.CoreConfiguration) error {
+	_, err := c.SaveRevision(cfg, domain.ConfigChange{Author: domain.SystemAuthor})
+	return err
+}
+
+// SaveRevision - replaces the stored config sections and records the config as a revision.
+func (c *sqlConfig) SaveRevision(cfg domain.CoreConfiguration, change domain.ConfigChange) (domain.ConfigRevision, error) {
 	tx, err := c.db.Begin()
 	if err != nil {
-		return err
+		return domain.ConfigRevision{}, err
 	}
 	defer tx.Rollback()
 
+	// sections missing in the new config must not survive, a revision is the whole config
+	if _, err := tx.Exec(`DELETE FROM CoreConfig`); err != nil {
+		return domain.ConfigRevision{}, err
+	}
+
 	stmt, err := tx.Prepare(`
 		INSERT INTO CoreConfig(key, value)
 		VALUES(?, ?)
 		ON CONFLICT(key) DO UPDATE SET value = excluded.value
 	`)
 	if err != nil {
-		return err
+		return domain.ConfigRevision{}, err
 	}
 	defer stmt.Close()
 
-	clearConfig(&cfg)
+	cfg.Clear()
 
 	for key, value := range cfg {
 		if !json.Valid(value) {
-			return fmt.Errorf("invalid JSON for key %q", key)
+			return domain.ConfigRevision{}, fmt.Errorf("invalid JSON for key %q", key)
 		}
 
 		if _, err := stmt.Exec(key, value); err != nil {
-			return err
+			return domain.ConfigRevision{}, err
+		}
+	}
+
+	rev, err := c.recordRevision(tx, cfg, change)
+	if err != nil {
+		return domain.ConfigRevision{}, fmt.Errorf("record revision: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.ConfigRevision{}, err
+	}
+
+	c.events.Publish(domain.NewEvent(
+		domain.EventConfigSaved,
+		fmt.Sprintf("config saved to database, revision %d", rev.Number),
+	))
+
+	return rev, nil
+}
+
+// recordRevision - inserts the revision unless the config equals the latest one, then applies retention.
+func (c *sqlConfig) recordRevision(tx *sql.Tx, cfg domain.CoreConfiguration, change domain.ConfigChange) (domain.ConfigRevision, error) {
+	rev := domain.ConfigRevision{
+		Time:    time.Now(),
+		Author:  change.Author,
+		Comment: change.Comment,
+		Hash:    cfg.Hash(),
+		Config:  cfg,
+	}
+
+	latest, err := scanRevision(tx.QueryRow(`
+		SELECT number, time, author, comment, hash, config FROM CoreConfigRevision
+		ORDER BY number DESC LIMIT 1
+	`))
+	switch {
+	case err == nil && latest.Hash == rev.Hash:
+		return latest, nil
+	case err != nil && !errors.Is(err, domain.ErrRevisionNotFound):
+		return domain.ConfigRevision{}, err
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return domain.ConfigRevision{}, err
+	}
+
+	res, err := tx.Exec(`
+		INSERT INTO CoreConfigRevision(time, author, comment, hash, config)
+		VALUES(?, ?, ?, ?, ?)
+	`, rev.Time.UnixNano(), rev.Author, rev.Comment, rev.Hash, data)
+	if err != nil {
+		return domain.ConfigRevision{}, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return domain.ConfigRevision{}, err
+	}
+	rev.Number = uint64(id)
+
+	if c.keep > 0 {
+		if _, err := tx.Exec(`DELETE FROM CoreConfigRevision WHERE number <= ?`, id-int64(c.keep)); err != nil {
+			return domain.ConfigRevision{}, err
+		}
+	}
+
+	return rev, nil
+}
+
+// Revisions - returns kept config revisions newest first, without configs.
+func (c *sqlConfig) Revisions() ([]domain.ConfigRevision, error) {
+	rows, err := c.db.Query(`
+		SELECT number, time, author, comment, hash FROM CoreConfigRevision
+		ORDER BY number DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.ConfigRevision
+
+	for rows.Next() {
+		var (
+			rev  domain.ConfigRevision
+			nano int64
+		)
+
+		if err := rows.Scan(&rev.Number, &nano, &rev.Author, &rev.Comment, &rev.Hash); err != nil {
+			return nil, err
 		}
+		rev.Time = time.Unix(0, nano)
+
+		list = append(list, rev)
+	}
+
+	return list, rows.Err()
+}
+
+// Revision - returns the config revision with its config.
+func (c *sqlConfig) Revision(number uint64) (domain.ConfigRevision, error) {
+	rev, err := scanRevision(c.db.QueryRow(`
+		SELECT number, time, author, comment, hash, config FROM CoreConfigRevision
+		WHERE number = ?
+	`, number))
+	if errors.Is(err, domain.ErrRevisionNotFound) {
+		return rev, fmt.Errorf("%w: %d", err, number)
+	}
+	return rev, err
+}
+
+func scanRevision(row *sql.Row) (domain.ConfigRevision, error) {
+	var (
+		rev  domain.ConfigRevision
+		nano int64
+		data []byte
+	)
+
+	err := row.Scan(&rev.Number, &nano, &rev.Author, &rev.Comment, &rev.Hash, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return rev, domain.ErrRevisionNotFound
+	}
+	if err != nil {
+		return rev, err
+	}
+	rev.Time = time.Unix(0, nano)
+
+	if err := json.Unmarshal(data, &rev.Config); err != nil {
+		return rev, fmt.Errorf("decode revision %d: %w", rev.Number, err)
 	}
 
-	return tx.Commit()
+	return rev, nil
 }
 
 func (c *sqlConfig) LoadConfig() (domain.CoreConfiguration, error) {
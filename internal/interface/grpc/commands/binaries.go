@@ -0,0 +1,152 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package commands
+
+import (
+	context "context"
+	"errors"
+	"log/slog"
+
+	"github.com/eterline/xraymon/internal/domain"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type BinarySwitcher interface {
+	SwitchBinary(name string) error
+}
+
+// binaryHandlers - gRPC handler for core binary inventory, upload and switching.
+type binaryHandlers struct {
+	store     domain.CoreBinaryStore
+	instances *Instances
+
+	log *slog.Logger
+
+	UnimplementedCoreBinaryProviderServer
+}
+
+func NewBinaryHandlers(store domain.CoreBinaryStore, instances *Instances, log *slog.Logger) *binaryHandlers {
+	return &binaryHandlers{
+		store:     store,
+		instances: instances,
+		log:       log,
+	}
+}
+
+// ListCoreBinaries - returns binaries of the cores directory and the active one of the instance.
+func (bh *binaryHandlers) ListCoreBinaries(ctx context.Context, r *ListCoreBinariesRequest) (*ListCoreBinariesResponse, error) {
+
+	inst, err := bh.instances.Get(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := bh.store.List(ctx)
+	if err != nil {
+		bh.log.Error("failed to list core binaries", "error", err)
+		return nil, err
+	}
+
+	resp := &ListCoreBinariesResponse{
+		Binaries: make([]*CoreBinaryInfo, 0, len(list)),
+		Active:   inst.CoreState.Status().Binary,
+	}
+
+	for _, bin := range list {
+		resp.Binaries = append(resp.Binaries, domain2dtoCoreBinary(bin))
+	}
+
+	return resp, nil
+}
+
+// UploadCoreBinary - receives a core binary in chunks and installs it after checksum verification.
+func (bh *binaryHandlers) UploadCoreBinary(stream grpc.ClientStreamingServer[CoreBinaryChunk, CoreBinaryInfo]) error {
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	log := bh.log.With("binary", first.Name)
+	log.Info("core binary upload requested")
+
+	rd := &chunkReader{stream: stream, buf: first.Data}
+
+	bin, err := bh.store.Install(stream.Context(), first.Name, rd, first.Sha256)
+	if err != nil {
+		if errors.Is(err, domain.ErrBinaryRejected) {
+			log.Warn("core binary rejected", "error", err)
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, domain.ErrBinaryExists) {
+			log.Warn("core binary already exists", "error", err)
+			return status.Error(codes.AlreadyExists, err.Error())
+		}
+
+		log.Error("failed to install core binary", "error", err)
+		return err
+	}
+
+	log.Info("core binary installed", "version", bin.Version, "sha256", bin.SHA256)
+	return stream.SendAndClose(domain2dtoCoreBinary(bin))
+}
+
+// SwitchCoreBinary - restarts the instance core with another binary, a failed start rolls back.
+func (bh *binaryHandlers) SwitchCoreBinary(ctx context.Context, r *SwitchCoreBinaryRequest) (*SwitchCoreBinaryResponse, error) {
+
+	inst, err := bh.instances.Get(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	log := bh.log.With("instance", inst.Name(), "binary", r.Name)
+
+	if !inst.coreRestartLim.InLimits() {
+		log.Warn("core binary switch rejected due to rate limit")
+		return nil, errors.New("too many requests")
+	}
+
+	log.Info("core binary switch requested")
+
+	if err := inst.Binaries.SwitchBinary(r.Name); err != nil {
+		log.Error("core binary switch failed", "error", err)
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return &SwitchCoreBinaryResponse{}, nil
+}
+
+// chunkReader - reads data of the upload stream chunks.
+type chunkReader struct {
+	stream grpc.ClientStreamingServer[CoreBinaryChunk, CoreBinaryInfo]
+	buf    []byte
+}
+
+func (cr *chunkReader) Read(p []byte) (int, error) {
+	for len(cr.buf) == 0 {
+		chunk, err := cr.stream.Recv()
+		if err != nil {
+			return 0, err // io.EOF on the end of upload
+		}
+		cr.buf = chunk.Data
+	}
+
+	n := copy(p, cr.buf)
+	cr.buf = cr.buf[n:]
+
+	return n, nil
+}
+
+func domain2dtoCoreBinary(bin domain.CoreBinary) *CoreBinaryInfo {
+	return &CoreBinaryInfo{
+		Name:     bin.Name,
+		Version:  bin.Version,
+		Sha256:   bin.SHA256,
+		Size:     uint64(bin.Size),
+		Modified: timestamppb.New(bin.ModTime),
+	}
+}
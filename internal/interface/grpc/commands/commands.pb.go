@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,6 +23,97 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type EventType int32
+
+const (
+	EventType_EVENT_UNKNOWN         EventType = 0
+	EventType_EVENT_CORE_STARTED    EventType = 1
+	EventType_EVENT_CORE_EXITED     EventType = 2
+	EventType_EVENT_CORE_BACKOFF    EventType = 3
+	EventType_EVENT_CORE_FAILED     EventType = 4
+	EventType_EVENT_CONFIG_SAVED    EventType = 5
+	EventType_EVENT_JOURNAL_ROTATED EventType = 6
+	EventType_EVENT_STATS_COLLECTED EventType = 7
+	EventType_EVENT_CONFIG_ROLLBACK EventType = 8
+	EventType_EVENT_CONFIG_APPLIED  EventType = 9
+	EventType_EVENT_BINARY_SWITCHED EventType = 10
+	EventType_EVENT_BINARY_ROLLBACK EventType = 11
+	EventType_EVENT_CORE_UNHEALTHY  EventType = 12
+	EventType_EVENT_SCHEDULED       EventType = 13
+	EventType_EVENT_DRAIN_STARTED   EventType = 14
+	EventType_EVENT_DRAIN_PROGRESS  EventType = 15
+	EventType_EVENT_DRAIN_FINISHED  EventType = 16
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0:  "EVENT_UNKNOWN",
+		1:  "EVENT_CORE_STARTED",
+		2:  "EVENT_CORE_EXITED",
+		3:  "EVENT_CORE_BACKOFF",
+		4:  "EVENT_CORE_FAILED",
+		5:  "EVENT_CONFIG_SAVED",
+		6:  "EVENT_JOURNAL_ROTATED",
+		7:  "EVENT_STATS_COLLECTED",
+		8:  "EVENT_CONFIG_ROLLBACK",
+		9:  "EVENT_CONFIG_APPLIED",
+		10: "EVENT_BINARY_SWITCHED",
+		11: "EVENT_BINARY_ROLLBACK",
+		12: "EVENT_CORE_UNHEALTHY",
+		13: "EVENT_SCHEDULED",
+		14: "EVENT_DRAIN_STARTED",
+		15: "EVENT_DRAIN_PROGRESS",
+		16: "EVENT_DRAIN_FINISHED",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_UNKNOWN":         0,
+		"EVENT_CORE_STARTED":    1,
+		"EVENT_CORE_EXITED":     2,
+		"EVENT_CORE_BACKOFF":    3,
+		"EVENT_CORE_FAILED":     4,
+		"EVENT_CONFIG_SAVED":    5,
+		"EVENT_JOURNAL_ROTATED": 6,
+		"EVENT_STATS_COLLECTED": 7,
+		"EVENT_CONFIG_ROLLBACK": 8,
+		"EVENT_CONFIG_APPLIED":  9,
+		"EVENT_BINARY_SWITCHED": 10,
+		"EVENT_BINARY_ROLLBACK": 11,
+		"EVENT_CORE_UNHEALTHY":  12,
+		"EVENT_SCHEDULED":       13,
+		"EVENT_DRAIN_STARTED":   14,
+		"EVENT_DRAIN_PROGRESS":  15,
+		"EVENT_DRAIN_FINISHED":  16,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_commands_proto_enumTypes[0].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_commands_proto_enumTypes[0]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{0}
+}
+
 type ConnectionType int32
 
 const (
@@ -55,11 +147,11 @@ func (x ConnectionType) String() string {
 }
 
 func (ConnectionType) Descriptor() protoreflect.EnumDescriptor {
-	return file_commands_proto_enumTypes[0].Descriptor()
+	return file_commands_proto_enumTypes[1].Descriptor()
 }
 
 func (ConnectionType) Type() protoreflect.EnumType {
-	return &file_commands_proto_enumTypes[0]
+	return &file_commands_proto_enumTypes[1]
 }
 
 func (x ConnectionType) Number() protoreflect.EnumNumber {
@@ -68,7 +160,7 @@ func (x ConnectionType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ConnectionType.Descriptor instead.
 func (ConnectionType) EnumDescriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{0}
+	return file_commands_proto_rawDescGZIP(), []int{1}
 }
 
 type NetType int32
@@ -104,11 +196,11 @@ func (x NetType) String() string {
 }
 
 func (NetType) Descriptor() protoreflect.EnumDescriptor {
-	return file_commands_proto_enumTypes[1].Descriptor()
+	return file_commands_proto_enumTypes[2].Descriptor()
 }
 
 func (NetType) Type() protoreflect.EnumType {
-	return &file_commands_proto_enumTypes[1]
+	return &file_commands_proto_enumTypes[2]
 }
 
 func (x NetType) Number() protoreflect.EnumNumber {
@@ -117,18 +209,286 @@ func (x NetType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use NetType.Descriptor instead.
 func (NetType) EnumDescriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{2}
+}
+
+type CoreState int32
+
+const (
+	CoreState_CORE_STOPPED CoreState = 0
+	CoreState_CORE_RUNNING CoreState = 1
+	CoreState_CORE_CRASHED CoreState = 2
+	CoreState_CORE_BACKOFF CoreState = 3
+	CoreState_CORE_FAILED  CoreState = 4
+)
+
+// Enum value maps for CoreState.
+var (
+	CoreState_name = map[int32]string{
+		0: "CORE_STOPPED",
+		1: "CORE_RUNNING",
+		2: "CORE_CRASHED",
+		3: "CORE_BACKOFF",
+		4: "CORE_FAILED",
+	}
+	CoreState_value = map[string]int32{
+		"CORE_STOPPED": 0,
+		"CORE_RUNNING": 1,
+		"CORE_CRASHED": 2,
+		"CORE_BACKOFF": 3,
+		"CORE_FAILED":  4,
+	}
+)
+
+func (x CoreState) Enum() *CoreState {
+	p := new(CoreState)
+	*p = x
+	return p
+}
+
+func (x CoreState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CoreState) Descriptor() protoreflect.EnumDescriptor {
+	return file_commands_proto_enumTypes[3].Descriptor()
+}
+
+func (CoreState) Type() protoreflect.EnumType {
+	return &file_commands_proto_enumTypes[3]
+}
+
+func (x CoreState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CoreState.Descriptor instead.
+func (CoreState) EnumDescriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{3}
+}
+
+type ConfigChangeOp int32
+
+const (
+	ConfigChangeOp_CONFIG_CHANGE_UNKNOWN ConfigChangeOp = 0
+	ConfigChangeOp_CONFIG_CHANGE_ADDED   ConfigChangeOp = 1
+	ConfigChangeOp_CONFIG_CHANGE_REMOVED ConfigChangeOp = 2
+	ConfigChangeOp_CONFIG_CHANGE_CHANGED ConfigChangeOp = 3
+)
+
+// Enum value maps for ConfigChangeOp.
+var (
+	ConfigChangeOp_name = map[int32]string{
+		0: "CONFIG_CHANGE_UNKNOWN",
+		1: "CONFIG_CHANGE_ADDED",
+		2: "CONFIG_CHANGE_REMOVED",
+		3: "CONFIG_CHANGE_CHANGED",
+	}
+	ConfigChangeOp_value = map[string]int32{
+		"CONFIG_CHANGE_UNKNOWN": 0,
+		"CONFIG_CHANGE_ADDED":   1,
+		"CONFIG_CHANGE_REMOVED": 2,
+		"CONFIG_CHANGE_CHANGED": 3,
+	}
+)
+
+func (x ConfigChangeOp) Enum() *ConfigChangeOp {
+	p := new(ConfigChangeOp)
+	*p = x
+	return p
+}
+
+func (x ConfigChangeOp) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConfigChangeOp) Descriptor() protoreflect.EnumDescriptor {
+	return file_commands_proto_enumTypes[4].Descriptor()
+}
+
+func (ConfigChangeOp) Type() protoreflect.EnumType {
+	return &file_commands_proto_enumTypes[4]
+}
+
+func (x ConfigChangeOp) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConfigChangeOp.Descriptor instead.
+func (ConfigChangeOp) EnumDescriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{4}
+}
+
+type WatchEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []EventType            `protobuf:"varint,1,rep,packed,name=types,proto3,enum=xraymon.commands.EventType" json:"types,omitempty"` // empty - all events
+	Instance      string                 `protobuf:"bytes,2,opt,name=instance,proto3" json:"instance,omitempty"`                                   // empty - all instances
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
+	mi := &file_commands_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsRequest) ProtoMessage() {}
+
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WatchEventsRequest) GetTypes() []EventType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *WatchEventsRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+type CoreEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=xraymon.commands.EventType" json:"type,omitempty"`
+	Instance      string                 `protobuf:"bytes,8,opt,name=instance,proto3" json:"instance,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	ExitCode      int32                  `protobuf:"varint,4,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Attempt       uint32                 `protobuf:"varint,5,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Delay         *durationpb.Duration   `protobuf:"bytes,6,opt,name=delay,proto3" json:"delay,omitempty"`
+	Journal       string                 `protobuf:"bytes,7,opt,name=journal,proto3" json:"journal,omitempty"`
+	Rate          uint64                 `protobuf:"varint,9,opt,name=rate,proto3" json:"rate,omitempty"` // drain events: inbound bytes per second
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoreEvent) Reset() {
+	*x = CoreEvent{}
+	mi := &file_commands_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoreEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoreEvent) ProtoMessage() {}
+
+func (x *CoreEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoreEvent.ProtoReflect.Descriptor instead.
+func (*CoreEvent) Descriptor() ([]byte, []int) {
 	return file_commands_proto_rawDescGZIP(), []int{1}
 }
 
+func (x *CoreEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_UNKNOWN
+}
+
+func (x *CoreEvent) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *CoreEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *CoreEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CoreEvent) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *CoreEvent) GetAttempt() uint32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *CoreEvent) GetDelay() *durationpb.Duration {
+	if x != nil {
+		return x.Delay
+	}
+	return nil
+}
+
+func (x *CoreEvent) GetJournal() string {
+	if x != nil {
+		return x.Journal
+	}
+	return ""
+}
+
+func (x *CoreEvent) GetRate() uint64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
 type RotateJournalRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RotateJournalRequest) Reset() {
 	*x = RotateJournalRequest{}
-	mi := &file_commands_proto_msgTypes[0]
+	mi := &file_commands_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -140,7 +500,7 @@ func (x *RotateJournalRequest) String() string {
 func (*RotateJournalRequest) ProtoMessage() {}
 
 func (x *RotateJournalRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_commands_proto_msgTypes[0]
+	mi := &file_commands_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -153,7 +513,14 @@ func (x *RotateJournalRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RotateJournalRequest.ProtoReflect.Descriptor instead.
 func (*RotateJournalRequest) Descriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{0}
+	return file_commands_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RotateJournalRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
 }
 
 type RotateJournalResponse struct {
@@ -164,7 +531,7 @@ type RotateJournalResponse struct {
 
 func (x *RotateJournalResponse) Reset() {
 	*x = RotateJournalResponse{}
-	mi := &file_commands_proto_msgTypes[1]
+	mi := &file_commands_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -176,7 +543,7 @@ func (x *RotateJournalResponse) String() string {
 func (*RotateJournalResponse) ProtoMessage() {}
 
 func (x *RotateJournalResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_commands_proto_msgTypes[1]
+	mi := &file_commands_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -189,7 +556,7 @@ func (x *RotateJournalResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RotateJournalResponse.ProtoReflect.Descriptor instead.
 func (*RotateJournalResponse) Descriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{1}
+	return file_commands_proto_rawDescGZIP(), []int{3}
 }
 
 type ConnectionIO struct {
@@ -204,7 +571,7 @@ type ConnectionIO struct {
 
 func (x *ConnectionIO) Reset() {
 	*x = ConnectionIO{}
-	mi := &file_commands_proto_msgTypes[2]
+	mi := &file_commands_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -216,7 +583,7 @@ func (x *ConnectionIO) String() string {
 func (*ConnectionIO) ProtoMessage() {}
 
 func (x *ConnectionIO) ProtoReflect() protoreflect.Message {
-	mi := &file_commands_proto_msgTypes[2]
+	mi := &file_commands_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -229,7 +596,7 @@ func (x *ConnectionIO) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectionIO.ProtoReflect.Descriptor instead.
 func (*ConnectionIO) Descriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{2}
+	return file_commands_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ConnectionIO) GetBytesRx() uint64 {
@@ -271,7 +638,7 @@ type StatsMeta struct {
 
 func (x *StatsMeta) Reset() {
 	*x = StatsMeta{}
-	mi := &file_commands_proto_msgTypes[3]
+	mi := &file_commands_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -283,7 +650,7 @@ func (x *StatsMeta) String() string {
 func (*StatsMeta) ProtoMessage() {}
 
 func (x *StatsMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_commands_proto_msgTypes[3]
+	mi := &file_commands_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -296,7 +663,7 @@ func (x *StatsMeta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatsMeta.ProtoReflect.Descriptor instead.
 func (*StatsMeta) Descriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{3}
+	return file_commands_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *StatsMeta) GetType() ConnectionType {
@@ -329,7 +696,7 @@ type NetworkStatsResponse struct {
 
 func (x *NetworkStatsResponse) Reset() {
 	*x = NetworkStatsResponse{}
-	mi := &file_commands_proto_msgTypes[4]
+	mi := &file_commands_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -341,7 +708,7 @@ func (x *NetworkStatsResponse) String() string {
 func (*NetworkStatsResponse) ProtoMessage() {}
 
 func (x *NetworkStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_commands_proto_msgTypes[4]
+	mi := &file_commands_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -354,7 +721,7 @@ func (x *NetworkStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkStatsResponse.ProtoReflect.Descriptor instead.
 func (*NetworkStatsResponse) Descriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{4}
+	return file_commands_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *NetworkStatsResponse) GetStats() []*StatsMeta {
@@ -366,13 +733,14 @@ func (x *NetworkStatsResponse) GetStats() []*StatsMeta {
 
 type NetworkStatsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *NetworkStatsRequest) Reset() {
 	*x = NetworkStatsRequest{}
-	mi := &file_commands_proto_msgTypes[5]
+	mi := &file_commands_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -384,7 +752,7 @@ func (x *NetworkStatsRequest) String() string {
 func (*NetworkStatsRequest) ProtoMessage() {}
 
 func (x *NetworkStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_commands_proto_msgTypes[5]
+	mi := &file_commands_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -397,19 +765,27 @@ func (x *NetworkStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkStatsRequest.ProtoReflect.Descriptor instead.
 func (*NetworkStatsRequest) Descriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{5}
+	return file_commands_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *NetworkStatsRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
 }
 
 type ConnectionJournalRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Last          uint64                 `protobuf:"varint,1,opt,name=last,proto3" json:"last,omitempty"`
+	Instance      string                 `protobuf:"bytes,2,opt,name=instance,proto3" json:"instance,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ConnectionJournalRequest) Reset() {
 	*x = ConnectionJournalRequest{}
-	mi := &file_commands_proto_msgTypes[6]
+	mi := &file_commands_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -421,7 +797,7 @@ func (x *ConnectionJournalRequest) String() string {
 func (*ConnectionJournalRequest) ProtoMessage() {}
 
 func (x *ConnectionJournalRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_commands_proto_msgTypes[6]
+	mi := &file_commands_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -434,7 +810,7 @@ func (x *ConnectionJournalRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectionJournalRequest.ProtoReflect.Descriptor instead.
 func (*ConnectionJournalRequest) Descriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{6}
+	return file_commands_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ConnectionJournalRequest) GetLast() uint64 {
@@ -444,11 +820,122 @@ func (x *ConnectionJournalRequest) GetLast() uint64 {
 	return 0
 }
 
-type ConnectionMeta struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Client        string                 `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
-	Server        string                 `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`
-	Proto         NetType                `protobuf:"varint,3,opt,name=proto,proto3,enum=xraymon.commands.NetType" json:"proto,omitempty"`
+func (x *ConnectionJournalRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+type StderrJournalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Last          uint64                 `protobuf:"varint,1,opt,name=last,proto3" json:"last,omitempty"` // 0 - whole journal
+	Instance      string                 `protobuf:"bytes,2,opt,name=instance,proto3" json:"instance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StderrJournalRequest) Reset() {
+	*x = StderrJournalRequest{}
+	mi := &file_commands_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StderrJournalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StderrJournalRequest) ProtoMessage() {}
+
+func (x *StderrJournalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StderrJournalRequest.ProtoReflect.Descriptor instead.
+func (*StderrJournalRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StderrJournalRequest) GetLast() uint64 {
+	if x != nil {
+		return x.Last
+	}
+	return 0
+}
+
+func (x *StderrJournalRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+type StderrLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Line          string                 `protobuf:"bytes,2,opt,name=line,proto3" json:"line,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StderrLine) Reset() {
+	*x = StderrLine{}
+	mi := &file_commands_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StderrLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StderrLine) ProtoMessage() {}
+
+func (x *StderrLine) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StderrLine.ProtoReflect.Descriptor instead.
+func (*StderrLine) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StderrLine) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *StderrLine) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+type ConnectionMeta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Client        string                 `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	Server        string                 `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`
+	Proto         NetType                `protobuf:"varint,3,opt,name=proto,proto3,enum=xraymon.commands.NetType" json:"proto,omitempty"`
 	Inbound       string                 `protobuf:"bytes,4,opt,name=inbound,proto3" json:"inbound,omitempty"`
 	Outbound      string                 `protobuf:"bytes,5,opt,name=outbound,proto3" json:"outbound,omitempty"`
 	User          string                 `protobuf:"bytes,6,opt,name=user,proto3" json:"user,omitempty"`
@@ -456,21 +943,4296 @@ type ConnectionMeta struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConnectionMeta) Reset() {
-	*x = ConnectionMeta{}
-	mi := &file_commands_proto_msgTypes[7]
+func (x *ConnectionMeta) Reset() {
+	*x = ConnectionMeta{}
+	mi := &file_commands_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectionMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionMeta) ProtoMessage() {}
+
+func (x *ConnectionMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionMeta.ProtoReflect.Descriptor instead.
+func (*ConnectionMeta) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ConnectionMeta) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *ConnectionMeta) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *ConnectionMeta) GetProto() NetType {
+	if x != nil {
+		return x.Proto
+	}
+	return NetType_HTTP
+}
+
+func (x *ConnectionMeta) GetInbound() string {
+	if x != nil {
+		return x.Inbound
+	}
+	return ""
+}
+
+func (x *ConnectionMeta) GetOutbound() string {
+	if x != nil {
+		return x.Outbound
+	}
+	return ""
+}
+
+func (x *ConnectionMeta) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+type ListInstancesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInstancesRequest) Reset() {
+	*x = ListInstancesRequest{}
+	mi := &file_commands_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInstancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstancesRequest) ProtoMessage() {}
+
+func (x *ListInstancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInstancesRequest.ProtoReflect.Descriptor instead.
+func (*ListInstancesRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{12}
+}
+
+type InstanceInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	State         CoreState              `protobuf:"varint,2,opt,name=state,proto3,enum=xraymon.commands.CoreState" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceInfo) Reset() {
+	*x = InstanceInfo{}
+	mi := &file_commands_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceInfo) ProtoMessage() {}
+
+func (x *InstanceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceInfo.ProtoReflect.Descriptor instead.
+func (*InstanceInfo) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *InstanceInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InstanceInfo) GetState() CoreState {
+	if x != nil {
+		return x.State
+	}
+	return CoreState_CORE_STOPPED
+}
+
+type ListInstancesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instances     []*InstanceInfo        `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInstancesResponse) Reset() {
+	*x = ListInstancesResponse{}
+	mi := &file_commands_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInstancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstancesResponse) ProtoMessage() {}
+
+func (x *ListInstancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInstancesResponse.ProtoReflect.Descriptor instead.
+func (*ListInstancesResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListInstancesResponse) GetInstances() []*InstanceInfo {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+type CoreStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoreStatusRequest) Reset() {
+	*x = CoreStatusRequest{}
+	mi := &file_commands_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoreStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoreStatusRequest) ProtoMessage() {}
+
+func (x *CoreStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoreStatusRequest.ProtoReflect.Descriptor instead.
+func (*CoreStatusRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CoreStatusRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+type CoreStatusResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Working        bool                   `protobuf:"varint,1,opt,name=working,proto3" json:"working,omitempty"`
+	LastLog        string                 `protobuf:"bytes,2,opt,name=last_log,json=lastLog,proto3" json:"last_log,omitempty"`
+	WorkingTime    *durationpb.Duration   `protobuf:"bytes,3,opt,name=working_time,json=workingTime,proto3" json:"working_time,omitempty"`
+	State          CoreState              `protobuf:"varint,4,opt,name=state,proto3,enum=xraymon.commands.CoreState" json:"state,omitempty"`
+	Crashes        uint32                 `protobuf:"varint,5,opt,name=crashes,proto3" json:"crashes,omitempty"`
+	LastRollback   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_rollback,json=lastRollback,proto3" json:"last_rollback,omitempty"`
+	RollbackReason string                 `protobuf:"bytes,7,opt,name=rollback_reason,json=rollbackReason,proto3" json:"rollback_reason,omitempty"`
+	Restarts       uint32                 `protobuf:"varint,8,opt,name=restarts,proto3" json:"restarts,omitempty"`
+	Process        *ProcessMetrics        `protobuf:"bytes,9,opt,name=process,proto3" json:"process,omitempty"`
+	Binary         string                 `protobuf:"bytes,10,opt,name=binary,proto3" json:"binary,omitempty"`
+	Healthy        bool                   `protobuf:"varint,11,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	ProbeLatency   *durationpb.Duration   `protobuf:"bytes,12,opt,name=probe_latency,json=probeLatency,proto3" json:"probe_latency,omitempty"`
+	Attached       bool                   `protobuf:"varint,13,opt,name=attached,proto3" json:"attached,omitempty"` // externally managed core, xraymon only monitors it
+	Draining       bool                   `protobuf:"varint,14,opt,name=draining,proto3" json:"draining,omitempty"`
+	DrainRate      uint64                 `protobuf:"varint,15,opt,name=drain_rate,json=drainRate,proto3" json:"drain_rate,omitempty"` // inbound bytes per second while draining
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CoreStatusResponse) Reset() {
+	*x = CoreStatusResponse{}
+	mi := &file_commands_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoreStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoreStatusResponse) ProtoMessage() {}
+
+func (x *CoreStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoreStatusResponse.ProtoReflect.Descriptor instead.
+func (*CoreStatusResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CoreStatusResponse) GetWorking() bool {
+	if x != nil {
+		return x.Working
+	}
+	return false
+}
+
+func (x *CoreStatusResponse) GetLastLog() string {
+	if x != nil {
+		return x.LastLog
+	}
+	return ""
+}
+
+func (x *CoreStatusResponse) GetWorkingTime() *durationpb.Duration {
+	if x != nil {
+		return x.WorkingTime
+	}
+	return nil
+}
+
+func (x *CoreStatusResponse) GetState() CoreState {
+	if x != nil {
+		return x.State
+	}
+	return CoreState_CORE_STOPPED
+}
+
+func (x *CoreStatusResponse) GetCrashes() uint32 {
+	if x != nil {
+		return x.Crashes
+	}
+	return 0
+}
+
+func (x *CoreStatusResponse) GetLastRollback() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRollback
+	}
+	return nil
+}
+
+func (x *CoreStatusResponse) GetRollbackReason() string {
+	if x != nil {
+		return x.RollbackReason
+	}
+	return ""
+}
+
+func (x *CoreStatusResponse) GetRestarts() uint32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+func (x *CoreStatusResponse) GetProcess() *ProcessMetrics {
+	if x != nil {
+		return x.Process
+	}
+	return nil
+}
+
+func (x *CoreStatusResponse) GetBinary() string {
+	if x != nil {
+		return x.Binary
+	}
+	return ""
+}
+
+func (x *CoreStatusResponse) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *CoreStatusResponse) GetProbeLatency() *durationpb.Duration {
+	if x != nil {
+		return x.ProbeLatency
+	}
+	return nil
+}
+
+func (x *CoreStatusResponse) GetAttached() bool {
+	if x != nil {
+		return x.Attached
+	}
+	return false
+}
+
+func (x *CoreStatusResponse) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+func (x *CoreStatusResponse) GetDrainRate() uint64 {
+	if x != nil {
+		return x.DrainRate
+	}
+	return 0
+}
+
+type ProcessMetrics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pid           uint32                 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	RssBytes      uint64                 `protobuf:"varint,2,opt,name=rss_bytes,json=rssBytes,proto3" json:"rss_bytes,omitempty"`
+	CpuTime       *durationpb.Duration   `protobuf:"bytes,3,opt,name=cpu_time,json=cpuTime,proto3" json:"cpu_time,omitempty"`
+	CpuPercent    float64                `protobuf:"fixed64,4,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	Threads       uint32                 `protobuf:"varint,5,opt,name=threads,proto3" json:"threads,omitempty"`
+	OpenFds       uint32                 `protobuf:"varint,6,opt,name=open_fds,json=openFds,proto3" json:"open_fds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessMetrics) Reset() {
+	*x = ProcessMetrics{}
+	mi := &file_commands_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessMetrics) ProtoMessage() {}
+
+func (x *ProcessMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessMetrics.ProtoReflect.Descriptor instead.
+func (*ProcessMetrics) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ProcessMetrics) GetPid() uint32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ProcessMetrics) GetRssBytes() uint64 {
+	if x != nil {
+		return x.RssBytes
+	}
+	return 0
+}
+
+func (x *ProcessMetrics) GetCpuTime() *durationpb.Duration {
+	if x != nil {
+		return x.CpuTime
+	}
+	return nil
+}
+
+func (x *ProcessMetrics) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *ProcessMetrics) GetThreads() uint32 {
+	if x != nil {
+		return x.Threads
+	}
+	return 0
+}
+
+func (x *ProcessMetrics) GetOpenFds() uint32 {
+	if x != nil {
+		return x.OpenFds
+	}
+	return 0
+}
+
+type WatchCoreMetricsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Interval      *durationpb.Duration   `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"` // default 5s, minimum 1s
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchCoreMetricsRequest) Reset() {
+	*x = WatchCoreMetricsRequest{}
+	mi := &file_commands_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCoreMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCoreMetricsRequest) ProtoMessage() {}
+
+func (x *WatchCoreMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCoreMetricsRequest.ProtoReflect.Descriptor instead.
+func (*WatchCoreMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *WatchCoreMetricsRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *WatchCoreMetricsRequest) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+type CoreMetrics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	State         CoreState              `protobuf:"varint,2,opt,name=state,proto3,enum=xraymon.commands.CoreState" json:"state,omitempty"`
+	Restarts      uint32                 `protobuf:"varint,3,opt,name=restarts,proto3" json:"restarts,omitempty"`
+	Process       *ProcessMetrics        `protobuf:"bytes,4,opt,name=process,proto3" json:"process,omitempty"` // empty when the core is not running
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoreMetrics) Reset() {
+	*x = CoreMetrics{}
+	mi := &file_commands_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoreMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoreMetrics) ProtoMessage() {}
+
+func (x *CoreMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoreMetrics.ProtoReflect.Descriptor instead.
+func (*CoreMetrics) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CoreMetrics) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *CoreMetrics) GetState() CoreState {
+	if x != nil {
+		return x.State
+	}
+	return CoreState_CORE_STOPPED
+}
+
+func (x *CoreMetrics) GetRestarts() uint32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+func (x *CoreMetrics) GetProcess() *ProcessMetrics {
+	if x != nil {
+		return x.Process
+	}
+	return nil
+}
+
+type CoreRestartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Drain         bool                   `protobuf:"varint,2,opt,name=drain,proto3" json:"drain,omitempty"` // drain connections before the restart
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoreRestartRequest) Reset() {
+	*x = CoreRestartRequest{}
+	mi := &file_commands_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoreRestartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoreRestartRequest) ProtoMessage() {}
+
+func (x *CoreRestartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoreRestartRequest.ProtoReflect.Descriptor instead.
+func (*CoreRestartRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CoreRestartRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *CoreRestartRequest) GetDrain() bool {
+	if x != nil {
+		return x.Drain
+	}
+	return false
+}
+
+type CoreRestartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoreRestartResponse) Reset() {
+	*x = CoreRestartResponse{}
+	mi := &file_commands_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoreRestartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoreRestartResponse) ProtoMessage() {}
+
+func (x *CoreRestartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoreRestartResponse.ProtoReflect.Descriptor instead.
+func (*CoreRestartResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{21}
+}
+
+type CoreStartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoreStartRequest) Reset() {
+	*x = CoreStartRequest{}
+	mi := &file_commands_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoreStartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoreStartRequest) ProtoMessage() {}
+
+func (x *CoreStartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoreStartRequest.ProtoReflect.Descriptor instead.
+func (*CoreStartRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CoreStartRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+type CoreStartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoreStartResponse) Reset() {
+	*x = CoreStartResponse{}
+	mi := &file_commands_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoreStartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoreStartResponse) ProtoMessage() {}
+
+func (x *CoreStartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoreStartResponse.ProtoReflect.Descriptor instead.
+func (*CoreStartResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{23}
+}
+
+type CoreStopRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoreStopRequest) Reset() {
+	*x = CoreStopRequest{}
+	mi := &file_commands_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoreStopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoreStopRequest) ProtoMessage() {}
+
+func (x *CoreStopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoreStopRequest.ProtoReflect.Descriptor instead.
+func (*CoreStopRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CoreStopRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+type CoreStopResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoreStopResponse) Reset() {
+	*x = CoreStopResponse{}
+	mi := &file_commands_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoreStopResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoreStopResponse) ProtoMessage() {}
+
+func (x *CoreStopResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoreStopResponse.ProtoReflect.Descriptor instead.
+func (*CoreStopResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{25}
+}
+
+type GetConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigRequest) Reset() {
+	*x = GetConfigRequest{}
+	mi := &file_commands_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRequest) ProtoMessage() {}
+
+func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetConfigRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+type GetConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          string                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigResponse) Reset() {
+	*x = GetConfigResponse{}
+	mi := &file_commands_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigResponse) ProtoMessage() {}
+
+func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetConfigResponse) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type UploadConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          string                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	RestartCore   bool                   `protobuf:"varint,2,opt,name=restart_core,json=restartCore,proto3" json:"restart_core,omitempty"`
+	Instance      string                 `protobuf:"bytes,3,opt,name=instance,proto3" json:"instance,omitempty"`
+	Drain         bool                   `protobuf:"varint,4,opt,name=drain,proto3" json:"drain,omitempty"`                 // drain connections when the apply restarts the core
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`              // stored with the config revision
+	DryRun        bool                   `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // test and diff against the current config without saving
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadConfigRequest) Reset() {
+	*x = UploadConfigRequest{}
+	mi := &file_commands_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadConfigRequest) ProtoMessage() {}
+
+func (x *UploadConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadConfigRequest.ProtoReflect.Descriptor instead.
+func (*UploadConfigRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UploadConfigRequest) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *UploadConfigRequest) GetRestartCore() bool {
+	if x != nil {
+		return x.RestartCore
+	}
+	return false
+}
+
+func (x *UploadConfigRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *UploadConfigRequest) GetDrain() bool {
+	if x != nil {
+		return x.Drain
+	}
+	return false
+}
+
+func (x *UploadConfigRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *UploadConfigRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type UploadConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      uint64                 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`                         // revision of the saved config, 0 for a dry run
+	Changes       []*ConfigChange        `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`                            // dry run: changes against the current config
+	DroppedKeys   []string               `protobuf:"bytes,3,rep,name=dropped_keys,json=droppedKeys,proto3" json:"dropped_keys,omitempty"` // dry run: top-level keys that are not stored
+	Rejected      string                 `protobuf:"bytes,4,opt,name=rejected,proto3" json:"rejected,omitempty"`                          // dry run: core test error, empty when the config passed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadConfigResponse) Reset() {
+	*x = UploadConfigResponse{}
+	mi := &file_commands_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadConfigResponse) ProtoMessage() {}
+
+func (x *UploadConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadConfigResponse.ProtoReflect.Descriptor instead.
+func (*UploadConfigResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UploadConfigResponse) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *UploadConfigResponse) GetChanges() []*ConfigChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *UploadConfigResponse) GetDroppedKeys() []string {
+	if x != nil {
+		return x.DroppedKeys
+	}
+	return nil
+}
+
+func (x *UploadConfigResponse) GetRejected() string {
+	if x != nil {
+		return x.Rejected
+	}
+	return ""
+}
+
+type ConfigRevision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        uint64                 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	Author        string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Comment       string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	Hash          string                 `protobuf:"bytes,5,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigRevision) Reset() {
+	*x = ConfigRevision{}
+	mi := &file_commands_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigRevision) ProtoMessage() {}
+
+func (x *ConfigRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigRevision.ProtoReflect.Descriptor instead.
+func (*ConfigRevision) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ConfigRevision) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *ConfigRevision) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ConfigRevision) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *ConfigRevision) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *ConfigRevision) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type ListConfigRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Limit         uint32                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // 0 - all kept revisions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConfigRevisionsRequest) Reset() {
+	*x = ListConfigRevisionsRequest{}
+	mi := &file_commands_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConfigRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConfigRevisionsRequest) ProtoMessage() {}
+
+func (x *ListConfigRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConfigRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListConfigRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListConfigRevisionsRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *ListConfigRevisionsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListConfigRevisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*ConfigRevision      `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"` // newest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConfigRevisionsResponse) Reset() {
+	*x = ListConfigRevisionsResponse{}
+	mi := &file_commands_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConfigRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConfigRevisionsResponse) ProtoMessage() {}
+
+func (x *ListConfigRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConfigRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListConfigRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListConfigRevisionsResponse) GetRevisions() []*ConfigRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+type GetConfigRevisionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Revision      uint64                 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigRevisionRequest) Reset() {
+	*x = GetConfigRevisionRequest{}
+	mi := &file_commands_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigRevisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRevisionRequest) ProtoMessage() {}
+
+func (x *GetConfigRevisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRevisionRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRevisionRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetConfigRevisionRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *GetConfigRevisionRequest) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type GetConfigRevisionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      *ConfigRevision        `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Data          string                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigRevisionResponse) Reset() {
+	*x = GetConfigRevisionResponse{}
+	mi := &file_commands_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigRevisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRevisionResponse) ProtoMessage() {}
+
+func (x *GetConfigRevisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRevisionResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigRevisionResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetConfigRevisionResponse) GetRevision() *ConfigRevision {
+	if x != nil {
+		return x.Revision
+	}
+	return nil
+}
+
+func (x *GetConfigRevisionResponse) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type DiffConfigRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	From          uint64                 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"` // 0 - the current config
+	To            uint64                 `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`     // 0 - the current config
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffConfigRevisionsRequest) Reset() {
+	*x = DiffConfigRevisionsRequest{}
+	mi := &file_commands_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffConfigRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffConfigRevisionsRequest) ProtoMessage() {}
+
+func (x *DiffConfigRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffConfigRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*DiffConfigRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DiffConfigRevisionsRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *DiffConfigRevisionsRequest) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *DiffConfigRevisionsRequest) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type ConfigChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            ConfigChangeOp         `protobuf:"varint,1,opt,name=op,proto3,enum=xraymon.commands.ConfigChangeOp" json:"op,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"` // JSON pointer
+	Old           string                 `protobuf:"bytes,3,opt,name=old,proto3" json:"old,omitempty"`   // JSON value, empty when added
+	New           string                 `protobuf:"bytes,4,opt,name=new,proto3" json:"new,omitempty"`   // JSON value, empty when removed
+	Tag           string                 `protobuf:"bytes,5,opt,name=tag,proto3" json:"tag,omitempty"`   // handler tag or ruleTag of a routing rule
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigChange) Reset() {
+	*x = ConfigChange{}
+	mi := &file_commands_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigChange) ProtoMessage() {}
+
+func (x *ConfigChange) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigChange.ProtoReflect.Descriptor instead.
+func (*ConfigChange) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ConfigChange) GetOp() ConfigChangeOp {
+	if x != nil {
+		return x.Op
+	}
+	return ConfigChangeOp_CONFIG_CHANGE_UNKNOWN
+}
+
+func (x *ConfigChange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetOld() string {
+	if x != nil {
+		return x.Old
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetNew() string {
+	if x != nil {
+		return x.New
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type DiffConfigRevisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*ConfigChange        `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffConfigRevisionsResponse) Reset() {
+	*x = DiffConfigRevisionsResponse{}
+	mi := &file_commands_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffConfigRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffConfigRevisionsResponse) ProtoMessage() {}
+
+func (x *DiffConfigRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffConfigRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*DiffConfigRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DiffConfigRevisionsResponse) GetChanges() []*ConfigChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type RollbackConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Revision      uint64                 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	RestartCore   bool                   `protobuf:"varint,3,opt,name=restart_core,json=restartCore,proto3" json:"restart_core,omitempty"`
+	Drain         bool                   `protobuf:"varint,4,opt,name=drain,proto3" json:"drain,omitempty"`    // drain connections when the apply restarts the core
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"` // "rollback to revision N" when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackConfigRequest) Reset() {
+	*x = RollbackConfigRequest{}
+	mi := &file_commands_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackConfigRequest) ProtoMessage() {}
+
+func (x *RollbackConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackConfigRequest.ProtoReflect.Descriptor instead.
+func (*RollbackConfigRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RollbackConfigRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *RollbackConfigRequest) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *RollbackConfigRequest) GetRestartCore() bool {
+	if x != nil {
+		return x.RestartCore
+	}
+	return false
+}
+
+func (x *RollbackConfigRequest) GetDrain() bool {
+	if x != nil {
+		return x.Drain
+	}
+	return false
+}
+
+func (x *RollbackConfigRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type RollbackConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      *ConfigRevision        `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"` // new revision with the restored config
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackConfigResponse) Reset() {
+	*x = RollbackConfigResponse{}
+	mi := &file_commands_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackConfigResponse) ProtoMessage() {}
+
+func (x *RollbackConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackConfigResponse.ProtoReflect.Descriptor instead.
+func (*RollbackConfigResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RollbackConfigResponse) GetRevision() *ConfigRevision {
+	if x != nil {
+		return x.Revision
+	}
+	return nil
+}
+
+type Inbound struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Protocol      string                 `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Listen        string                 `protobuf:"bytes,3,opt,name=listen,proto3" json:"listen,omitempty"`
+	Port          string                 `protobuf:"bytes,4,opt,name=port,proto3" json:"port,omitempty"` // number, range or list as written in the config
+	Data          string                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"` // the whole inbound object in JSON format
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Inbound) Reset() {
+	*x = Inbound{}
+	mi := &file_commands_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Inbound) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Inbound) ProtoMessage() {}
+
+func (x *Inbound) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Inbound.ProtoReflect.Descriptor instead.
+func (*Inbound) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Inbound) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Inbound) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *Inbound) GetListen() string {
+	if x != nil {
+		return x.Listen
+	}
+	return ""
+}
+
+func (x *Inbound) GetPort() string {
+	if x != nil {
+		return x.Port
+	}
+	return ""
+}
+
+func (x *Inbound) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type ListInboundsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInboundsRequest) Reset() {
+	*x = ListInboundsRequest{}
+	mi := &file_commands_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInboundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInboundsRequest) ProtoMessage() {}
+
+func (x *ListInboundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInboundsRequest.ProtoReflect.Descriptor instead.
+func (*ListInboundsRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListInboundsRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+type ListInboundsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inbounds      []*Inbound             `protobuf:"bytes,1,rep,name=inbounds,proto3" json:"inbounds,omitempty"` // config order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInboundsResponse) Reset() {
+	*x = ListInboundsResponse{}
+	mi := &file_commands_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInboundsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInboundsResponse) ProtoMessage() {}
+
+func (x *ListInboundsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInboundsResponse.ProtoReflect.Descriptor instead.
+func (*ListInboundsResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListInboundsResponse) GetInbounds() []*Inbound {
+	if x != nil {
+		return x.Inbounds
+	}
+	return nil
+}
+
+type GetInboundRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInboundRequest) Reset() {
+	*x = GetInboundRequest{}
+	mi := &file_commands_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInboundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInboundRequest) ProtoMessage() {}
+
+func (x *GetInboundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInboundRequest.ProtoReflect.Descriptor instead.
+func (*GetInboundRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetInboundRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *GetInboundRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type PutInboundRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`         // inbound to replace, empty or unknown - a new inbound; data without tag gets it
+	Data          string                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`       // inbound object in JSON format
+	Apply         bool                   `protobuf:"varint,4,opt,name=apply,proto3" json:"apply,omitempty"`    // apply the saved config to the running core
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"` // stored with the config revision
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutInboundRequest) Reset() {
+	*x = PutInboundRequest{}
+	mi := &file_commands_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutInboundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutInboundRequest) ProtoMessage() {}
+
+func (x *PutInboundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutInboundRequest.ProtoReflect.Descriptor instead.
+func (*PutInboundRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *PutInboundRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *PutInboundRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *PutInboundRequest) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *PutInboundRequest) GetApply() bool {
+	if x != nil {
+		return x.Apply
+	}
+	return false
+}
+
+func (x *PutInboundRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type PutInboundResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       bool                   `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Revision      uint64                 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutInboundResponse) Reset() {
+	*x = PutInboundResponse{}
+	mi := &file_commands_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutInboundResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutInboundResponse) ProtoMessage() {}
+
+func (x *PutInboundResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutInboundResponse.ProtoReflect.Descriptor instead.
+func (*PutInboundResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *PutInboundResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+func (x *PutInboundResponse) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type DeleteInboundRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	Apply         bool                   `protobuf:"varint,3,opt,name=apply,proto3" json:"apply,omitempty"`    // apply the saved config to the running core
+	Comment       string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"` // stored with the config revision
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteInboundRequest) Reset() {
+	*x = DeleteInboundRequest{}
+	mi := &file_commands_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteInboundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteInboundRequest) ProtoMessage() {}
+
+func (x *DeleteInboundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteInboundRequest.ProtoReflect.Descriptor instead.
+func (*DeleteInboundRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DeleteInboundRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *DeleteInboundRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *DeleteInboundRequest) GetApply() bool {
+	if x != nil {
+		return x.Apply
+	}
+	return false
+}
+
+func (x *DeleteInboundRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type DeleteInboundResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      uint64                 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteInboundResponse) Reset() {
+	*x = DeleteInboundResponse{}
+	mi := &file_commands_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteInboundResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteInboundResponse) ProtoMessage() {}
+
+func (x *DeleteInboundResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteInboundResponse.ProtoReflect.Descriptor instead.
+func (*DeleteInboundResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DeleteInboundResponse) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type Outbound struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Protocol      string                 `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Data          string                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"` // the whole outbound object in JSON format
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Outbound) Reset() {
+	*x = Outbound{}
+	mi := &file_commands_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Outbound) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Outbound) ProtoMessage() {}
+
+func (x *Outbound) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Outbound.ProtoReflect.Descriptor instead.
+func (*Outbound) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Outbound) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Outbound) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *Outbound) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type ListOutboundsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOutboundsRequest) Reset() {
+	*x = ListOutboundsRequest{}
+	mi := &file_commands_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOutboundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOutboundsRequest) ProtoMessage() {}
+
+func (x *ListOutboundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOutboundsRequest.ProtoReflect.Descriptor instead.
+func (*ListOutboundsRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListOutboundsRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+type ListOutboundsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Outbounds     []*Outbound            `protobuf:"bytes,1,rep,name=outbounds,proto3" json:"outbounds,omitempty"` // config order, the first one is the default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOutboundsResponse) Reset() {
+	*x = ListOutboundsResponse{}
+	mi := &file_commands_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOutboundsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOutboundsResponse) ProtoMessage() {}
+
+func (x *ListOutboundsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOutboundsResponse.ProtoReflect.Descriptor instead.
+func (*ListOutboundsResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListOutboundsResponse) GetOutbounds() []*Outbound {
+	if x != nil {
+		return x.Outbounds
+	}
+	return nil
+}
+
+type GetOutboundRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOutboundRequest) Reset() {
+	*x = GetOutboundRequest{}
+	mi := &file_commands_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOutboundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOutboundRequest) ProtoMessage() {}
+
+func (x *GetOutboundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOutboundRequest.ProtoReflect.Descriptor instead.
+func (*GetOutboundRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetOutboundRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *GetOutboundRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type PutOutboundRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`         // outbound to replace, empty or unknown - a new outbound; data without tag gets it
+	Data          string                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`       // outbound object in JSON format
+	Apply         bool                   `protobuf:"varint,4,opt,name=apply,proto3" json:"apply,omitempty"`    // apply the saved config to the running core
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"` // stored with the config revision
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutOutboundRequest) Reset() {
+	*x = PutOutboundRequest{}
+	mi := &file_commands_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutOutboundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutOutboundRequest) ProtoMessage() {}
+
+func (x *PutOutboundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutOutboundRequest.ProtoReflect.Descriptor instead.
+func (*PutOutboundRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *PutOutboundRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *PutOutboundRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *PutOutboundRequest) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *PutOutboundRequest) GetApply() bool {
+	if x != nil {
+		return x.Apply
+	}
+	return false
+}
+
+func (x *PutOutboundRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type PutOutboundResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       bool                   `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Revision      uint64                 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutOutboundResponse) Reset() {
+	*x = PutOutboundResponse{}
+	mi := &file_commands_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutOutboundResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutOutboundResponse) ProtoMessage() {}
+
+func (x *PutOutboundResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutOutboundResponse.ProtoReflect.Descriptor instead.
+func (*PutOutboundResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *PutOutboundResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+func (x *PutOutboundResponse) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+// Deleting a referenced outbound fails with FAILED_PRECONDITION, the references
+// are listed as PreconditionFailure violations with JSON pointers as subjects.
+type DeleteOutboundRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Instance string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Tag      string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	Cascade  bool                   `protobuf:"varint,3,opt,name=cascade,proto3" json:"cascade,omitempty"` // remove rules, balancer selectors and fallbacks referring to the outbound
+	Apply    bool                   `protobuf:"varint,4,opt,name=apply,proto3" json:"apply,omitempty"`     // apply the saved config to the running core
+	Comment  string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`  // stored with the config revision
+	// outbounds dialing through the outbound and balancers left without selectors, deleted with cascade;
+	// unnamed ones fail the delete
+	Dependents    []string `protobuf:"bytes,6,rep,name=dependents,proto3" json:"dependents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOutboundRequest) Reset() {
+	*x = DeleteOutboundRequest{}
+	mi := &file_commands_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOutboundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOutboundRequest) ProtoMessage() {}
+
+func (x *DeleteOutboundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOutboundRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOutboundRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DeleteOutboundRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *DeleteOutboundRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *DeleteOutboundRequest) GetCascade() bool {
+	if x != nil {
+		return x.Cascade
+	}
+	return false
+}
+
+func (x *DeleteOutboundRequest) GetApply() bool {
+	if x != nil {
+		return x.Apply
+	}
+	return false
+}
+
+func (x *DeleteOutboundRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *DeleteOutboundRequest) GetDependents() []string {
+	if x != nil {
+		return x.Dependents
+	}
+	return nil
+}
+
+type DeleteOutboundResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      uint64                 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Removed       []string               `protobuf:"bytes,2,rep,name=removed,proto3" json:"removed,omitempty"` // JSON pointers of the references removed by cascade
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOutboundResponse) Reset() {
+	*x = DeleteOutboundResponse{}
+	mi := &file_commands_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOutboundResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOutboundResponse) ProtoMessage() {}
+
+func (x *DeleteOutboundResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOutboundResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOutboundResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DeleteOutboundResponse) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *DeleteOutboundResponse) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+type Balancer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Selector      []string               `protobuf:"bytes,2,rep,name=selector,proto3" json:"selector,omitempty"` // outbound tag prefixes
+	FallbackTag   string                 `protobuf:"bytes,3,opt,name=fallback_tag,json=fallbackTag,proto3" json:"fallback_tag,omitempty"`
+	Strategy      string                 `protobuf:"bytes,4,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Data          string                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"` // the whole balancer object in JSON format
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Balancer) Reset() {
+	*x = Balancer{}
+	mi := &file_commands_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Balancer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Balancer) ProtoMessage() {}
+
+func (x *Balancer) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Balancer.ProtoReflect.Descriptor instead.
+func (*Balancer) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *Balancer) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Balancer) GetSelector() []string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *Balancer) GetFallbackTag() string {
+	if x != nil {
+		return x.FallbackTag
+	}
+	return ""
+}
+
+func (x *Balancer) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *Balancer) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type ListBalancersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBalancersRequest) Reset() {
+	*x = ListBalancersRequest{}
+	mi := &file_commands_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBalancersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBalancersRequest) ProtoMessage() {}
+
+func (x *ListBalancersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBalancersRequest.ProtoReflect.Descriptor instead.
+func (*ListBalancersRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListBalancersRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+type ListBalancersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balancers     []*Balancer            `protobuf:"bytes,1,rep,name=balancers,proto3" json:"balancers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBalancersResponse) Reset() {
+	*x = ListBalancersResponse{}
+	mi := &file_commands_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBalancersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBalancersResponse) ProtoMessage() {}
+
+func (x *ListBalancersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBalancersResponse.ProtoReflect.Descriptor instead.
+func (*ListBalancersResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListBalancersResponse) GetBalancers() []*Balancer {
+	if x != nil {
+		return x.Balancers
+	}
+	return nil
+}
+
+type GetBalancerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalancerRequest) Reset() {
+	*x = GetBalancerRequest{}
+	mi := &file_commands_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalancerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalancerRequest) ProtoMessage() {}
+
+func (x *GetBalancerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalancerRequest.ProtoReflect.Descriptor instead.
+func (*GetBalancerRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetBalancerRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *GetBalancerRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type PutBalancerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`         // balancer to replace, empty or unknown - a new balancer; data without tag gets it
+	Data          string                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`       // balancer object in JSON format
+	Apply         bool                   `protobuf:"varint,4,opt,name=apply,proto3" json:"apply,omitempty"`    // apply the saved config to the running core
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"` // stored with the config revision
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutBalancerRequest) Reset() {
+	*x = PutBalancerRequest{}
+	mi := &file_commands_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutBalancerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutBalancerRequest) ProtoMessage() {}
+
+func (x *PutBalancerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutBalancerRequest.ProtoReflect.Descriptor instead.
+func (*PutBalancerRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *PutBalancerRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *PutBalancerRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *PutBalancerRequest) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *PutBalancerRequest) GetApply() bool {
+	if x != nil {
+		return x.Apply
+	}
+	return false
+}
+
+func (x *PutBalancerRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type PutBalancerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       bool                   `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Revision      uint64                 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutBalancerResponse) Reset() {
+	*x = PutBalancerResponse{}
+	mi := &file_commands_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutBalancerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutBalancerResponse) ProtoMessage() {}
+
+func (x *PutBalancerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutBalancerResponse.ProtoReflect.Descriptor instead.
+func (*PutBalancerResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *PutBalancerResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+func (x *PutBalancerResponse) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type DeleteBalancerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	Cascade       bool                   `protobuf:"varint,3,opt,name=cascade,proto3" json:"cascade,omitempty"` // remove routing rules using the balancer
+	Apply         bool                   `protobuf:"varint,4,opt,name=apply,proto3" json:"apply,omitempty"`     // apply the saved config to the running core
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`  // stored with the config revision
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBalancerRequest) Reset() {
+	*x = DeleteBalancerRequest{}
+	mi := &file_commands_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBalancerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBalancerRequest) ProtoMessage() {}
+
+func (x *DeleteBalancerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBalancerRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBalancerRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *DeleteBalancerRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *DeleteBalancerRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *DeleteBalancerRequest) GetCascade() bool {
+	if x != nil {
+		return x.Cascade
+	}
+	return false
+}
+
+func (x *DeleteBalancerRequest) GetApply() bool {
+	if x != nil {
+		return x.Apply
+	}
+	return false
+}
+
+func (x *DeleteBalancerRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type DeleteBalancerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      uint64                 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Removed       []string               `protobuf:"bytes,2,rep,name=removed,proto3" json:"removed,omitempty"` // JSON pointers of the rules removed by cascade
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBalancerResponse) Reset() {
+	*x = DeleteBalancerResponse{}
+	mi := &file_commands_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBalancerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBalancerResponse) ProtoMessage() {}
+
+func (x *DeleteBalancerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBalancerResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBalancerResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *DeleteBalancerResponse) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *DeleteBalancerResponse) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+type RoutingRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`      // ruleTag, generated from the content for untagged rules and stored by the first rule edit
+	Index         int32                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"` // position in matching order
+	OutboundTag   string                 `protobuf:"bytes,3,opt,name=outbound_tag,json=outboundTag,proto3" json:"outbound_tag,omitempty"`
+	BalancerTag   string                 `protobuf:"bytes,4,opt,name=balancer_tag,json=balancerTag,proto3" json:"balancer_tag,omitempty"`
+	Data          string                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"` // the whole rule object in JSON format
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoutingRule) Reset() {
+	*x = RoutingRule{}
+	mi := &file_commands_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoutingRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoutingRule) ProtoMessage() {}
+
+func (x *RoutingRule) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoutingRule.ProtoReflect.Descriptor instead.
+func (*RoutingRule) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *RoutingRule) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *RoutingRule) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *RoutingRule) GetOutboundTag() string {
+	if x != nil {
+		return x.OutboundTag
+	}
+	return ""
+}
+
+func (x *RoutingRule) GetBalancerTag() string {
+	if x != nil {
+		return x.BalancerTag
+	}
+	return ""
+}
+
+func (x *RoutingRule) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type ListRoutingRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoutingRulesRequest) Reset() {
+	*x = ListRoutingRulesRequest{}
+	mi := &file_commands_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoutingRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoutingRulesRequest) ProtoMessage() {}
+
+func (x *ListRoutingRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoutingRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListRoutingRulesRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ListRoutingRulesRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+type ListRoutingRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*RoutingRule         `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"` // matching order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoutingRulesResponse) Reset() {
+	*x = ListRoutingRulesResponse{}
+	mi := &file_commands_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoutingRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoutingRulesResponse) ProtoMessage() {}
+
+func (x *ListRoutingRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoutingRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListRoutingRulesResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListRoutingRulesResponse) GetRules() []*RoutingRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type InsertRoutingRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Position      int32                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"` // 0 - first, negative or past the end - appended
+	Data          string                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`          // rule object in JSON format with a unique ruleTag
+	Apply         bool                   `protobuf:"varint,4,opt,name=apply,proto3" json:"apply,omitempty"`       // apply the saved config to the running core
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`    // stored with the config revision
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InsertRoutingRuleRequest) Reset() {
+	*x = InsertRoutingRuleRequest{}
+	mi := &file_commands_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InsertRoutingRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InsertRoutingRuleRequest) ProtoMessage() {}
+
+func (x *InsertRoutingRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InsertRoutingRuleRequest.ProtoReflect.Descriptor instead.
+func (*InsertRoutingRuleRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *InsertRoutingRuleRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *InsertRoutingRuleRequest) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *InsertRoutingRuleRequest) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *InsertRoutingRuleRequest) GetApply() bool {
+	if x != nil {
+		return x.Apply
+	}
+	return false
+}
+
+func (x *InsertRoutingRuleRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type InsertRoutingRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      uint64                 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InsertRoutingRuleResponse) Reset() {
+	*x = InsertRoutingRuleResponse{}
+	mi := &file_commands_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InsertRoutingRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InsertRoutingRuleResponse) ProtoMessage() {}
+
+func (x *InsertRoutingRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InsertRoutingRuleResponse.ProtoReflect.Descriptor instead.
+func (*InsertRoutingRuleResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *InsertRoutingRuleResponse) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type MoveRoutingRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	Position      int32                  `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"` // 0 - first, negative or past the end - last
+	Apply         bool                   `protobuf:"varint,4,opt,name=apply,proto3" json:"apply,omitempty"`       // apply the saved config to the running core
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`    // stored with the config revision
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveRoutingRuleRequest) Reset() {
+	*x = MoveRoutingRuleRequest{}
+	mi := &file_commands_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveRoutingRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveRoutingRuleRequest) ProtoMessage() {}
+
+func (x *MoveRoutingRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveRoutingRuleRequest.ProtoReflect.Descriptor instead.
+func (*MoveRoutingRuleRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *MoveRoutingRuleRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *MoveRoutingRuleRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *MoveRoutingRuleRequest) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *MoveRoutingRuleRequest) GetApply() bool {
+	if x != nil {
+		return x.Apply
+	}
+	return false
+}
+
+func (x *MoveRoutingRuleRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type MoveRoutingRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      uint64                 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveRoutingRuleResponse) Reset() {
+	*x = MoveRoutingRuleResponse{}
+	mi := &file_commands_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveRoutingRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveRoutingRuleResponse) ProtoMessage() {}
+
+func (x *MoveRoutingRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveRoutingRuleResponse.ProtoReflect.Descriptor instead.
+func (*MoveRoutingRuleResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *MoveRoutingRuleResponse) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type ReplaceRoutingRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	Data          string                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`       // rule object in JSON format, without ruleTag it keeps the tag
+	Apply         bool                   `protobuf:"varint,4,opt,name=apply,proto3" json:"apply,omitempty"`    // apply the saved config to the running core
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"` // stored with the config revision
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplaceRoutingRuleRequest) Reset() {
+	*x = ReplaceRoutingRuleRequest{}
+	mi := &file_commands_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceRoutingRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceRoutingRuleRequest) ProtoMessage() {}
+
+func (x *ReplaceRoutingRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceRoutingRuleRequest.ProtoReflect.Descriptor instead.
+func (*ReplaceRoutingRuleRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ReplaceRoutingRuleRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *ReplaceRoutingRuleRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ReplaceRoutingRuleRequest) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *ReplaceRoutingRuleRequest) GetApply() bool {
+	if x != nil {
+		return x.Apply
+	}
+	return false
+}
+
+func (x *ReplaceRoutingRuleRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type ReplaceRoutingRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      uint64                 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplaceRoutingRuleResponse) Reset() {
+	*x = ReplaceRoutingRuleResponse{}
+	mi := &file_commands_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceRoutingRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceRoutingRuleResponse) ProtoMessage() {}
+
+func (x *ReplaceRoutingRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceRoutingRuleResponse.ProtoReflect.Descriptor instead.
+func (*ReplaceRoutingRuleResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ReplaceRoutingRuleResponse) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type DeleteRoutingRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	Apply         bool                   `protobuf:"varint,3,opt,name=apply,proto3" json:"apply,omitempty"`    // apply the saved config to the running core
+	Comment       string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"` // stored with the config revision
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoutingRuleRequest) Reset() {
+	*x = DeleteRoutingRuleRequest{}
+	mi := &file_commands_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoutingRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoutingRuleRequest) ProtoMessage() {}
+
+func (x *DeleteRoutingRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoutingRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRoutingRuleRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *DeleteRoutingRuleRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *DeleteRoutingRuleRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *DeleteRoutingRuleRequest) GetApply() bool {
+	if x != nil {
+		return x.Apply
+	}
+	return false
+}
+
+func (x *DeleteRoutingRuleRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type DeleteRoutingRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      uint64                 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoutingRuleResponse) Reset() {
+	*x = DeleteRoutingRuleResponse{}
+	mi := &file_commands_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoutingRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoutingRuleResponse) ProtoMessage() {}
+
+func (x *DeleteRoutingRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoutingRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRoutingRuleResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *DeleteRoutingRuleResponse) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+// Simulates routing of a connection by the stored config, empty fields are unknown.
+type MatchRouteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	InboundTag    string                 `protobuf:"bytes,2,opt,name=inbound_tag,json=inboundTag,proto3" json:"inbound_tag,omitempty"`
+	Network       string                 `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"` // tcp or udp
+	Domain        string                 `protobuf:"bytes,4,opt,name=domain,proto3" json:"domain,omitempty"`
+	Ip            string                 `protobuf:"bytes,5,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port          uint32                 `protobuf:"varint,6,opt,name=port,proto3" json:"port,omitempty"`
+	SourceIp      string                 `protobuf:"bytes,7,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+	SourcePort    uint32                 `protobuf:"varint,8,opt,name=source_port,json=sourcePort,proto3" json:"source_port,omitempty"`
+	Protocol      string                 `protobuf:"bytes,9,opt,name=protocol,proto3" json:"protocol,omitempty"` // sniffed protocol: http, tls, quic, bittorrent
+	User          string                 `protobuf:"bytes,10,opt,name=user,proto3" json:"user,omitempty"`        // user email
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchRouteRequest) Reset() {
+	*x = MatchRouteRequest{}
+	mi := &file_commands_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchRouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchRouteRequest) ProtoMessage() {}
+
+func (x *MatchRouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchRouteRequest.ProtoReflect.Descriptor instead.
+func (*MatchRouteRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *MatchRouteRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *MatchRouteRequest) GetInboundTag() string {
+	if x != nil {
+		return x.InboundTag
+	}
+	return ""
+}
+
+func (x *MatchRouteRequest) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *MatchRouteRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *MatchRouteRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *MatchRouteRequest) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *MatchRouteRequest) GetSourceIp() string {
+	if x != nil {
+		return x.SourceIp
+	}
+	return ""
+}
+
+func (x *MatchRouteRequest) GetSourcePort() uint32 {
+	if x != nil {
+		return x.SourcePort
+	}
+	return 0
+}
+
+func (x *MatchRouteRequest) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *MatchRouteRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+type MatchRouteResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Matched     bool                   `protobuf:"varint,1,opt,name=matched,proto3" json:"matched,omitempty"`
+	Rule        *RoutingRule           `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`                                  // the first matching rule
+	OutboundTag string                 `protobuf:"bytes,3,opt,name=outbound_tag,json=outboundTag,proto3" json:"outbound_tag,omitempty"` // matched outbound or the default one
+	BalancerTag string                 `protobuf:"bytes,4,opt,name=balancer_tag,json=balancerTag,proto3" json:"balancer_tag,omitempty"`
+	// rules before the match depending on geo data or connection details unknown
+	// offline, the core may route by one of them instead
+	Undetermined  []*RoutingRule `protobuf:"bytes,5,rep,name=undetermined,proto3" json:"undetermined,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchRouteResponse) Reset() {
+	*x = MatchRouteResponse{}
+	mi := &file_commands_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchRouteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchRouteResponse) ProtoMessage() {}
+
+func (x *MatchRouteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchRouteResponse.ProtoReflect.Descriptor instead.
+func (*MatchRouteResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *MatchRouteResponse) GetMatched() bool {
+	if x != nil {
+		return x.Matched
+	}
+	return false
+}
+
+func (x *MatchRouteResponse) GetRule() *RoutingRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *MatchRouteResponse) GetOutboundTag() string {
+	if x != nil {
+		return x.OutboundTag
+	}
+	return ""
+}
+
+func (x *MatchRouteResponse) GetBalancerTag() string {
+	if x != nil {
+		return x.BalancerTag
+	}
+	return ""
+}
+
+func (x *MatchRouteResponse) GetUndetermined() []*RoutingRule {
+	if x != nil {
+		return x.Undetermined
+	}
+	return nil
+}
+
+type CrashHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Limit         uint32                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // 0 - all kept records
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrashHistoryRequest) Reset() {
+	*x = CrashHistoryRequest{}
+	mi := &file_commands_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrashHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrashHistoryRequest) ProtoMessage() {}
+
+func (x *CrashHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrashHistoryRequest.ProtoReflect.Descriptor instead.
+func (*CrashHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *CrashHistoryRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *CrashHistoryRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type CrashRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	ExitCode      int32                  `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Signal        string                 `protobuf:"bytes,3,opt,name=signal,proto3" json:"signal,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Uptime        *durationpb.Duration   `protobuf:"bytes,5,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	ConfigHash    string                 `protobuf:"bytes,6,opt,name=config_hash,json=configHash,proto3" json:"config_hash,omitempty"`
+	Binary        string                 `protobuf:"bytes,7,opt,name=binary,proto3" json:"binary,omitempty"`
+	Output        []string               `protobuf:"bytes,8,rep,name=output,proto3" json:"output,omitempty"`
+	ConfigRev     uint64                 `protobuf:"varint,9,opt,name=config_rev,json=configRev,proto3" json:"config_rev,omitempty"` // 0 - revision unknown
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrashRecord) Reset() {
+	*x = CrashRecord{}
+	mi := &file_commands_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrashRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrashRecord) ProtoMessage() {}
+
+func (x *CrashRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrashRecord.ProtoReflect.Descriptor instead.
+func (*CrashRecord) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *CrashRecord) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *CrashRecord) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *CrashRecord) GetSignal() string {
+	if x != nil {
+		return x.Signal
+	}
+	return ""
+}
+
+func (x *CrashRecord) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CrashRecord) GetUptime() *durationpb.Duration {
+	if x != nil {
+		return x.Uptime
+	}
+	return nil
+}
+
+func (x *CrashRecord) GetConfigHash() string {
+	if x != nil {
+		return x.ConfigHash
+	}
+	return ""
+}
+
+func (x *CrashRecord) GetBinary() string {
+	if x != nil {
+		return x.Binary
+	}
+	return ""
+}
+
+func (x *CrashRecord) GetOutput() []string {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+func (x *CrashRecord) GetConfigRev() uint64 {
+	if x != nil {
+		return x.ConfigRev
+	}
+	return 0
+}
+
+type CrashHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*CrashRecord         `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"` // newest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrashHistoryResponse) Reset() {
+	*x = CrashHistoryResponse{}
+	mi := &file_commands_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrashHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrashHistoryResponse) ProtoMessage() {}
+
+func (x *CrashHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrashHistoryResponse.ProtoReflect.Descriptor instead.
+func (*CrashHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *CrashHistoryResponse) GetRecords() []*CrashRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+type LogSettings struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"` // debug|info|warning|error|none
+	DnsLog        bool                   `protobuf:"varint,2,opt,name=dns_log,json=dnsLog,proto3" json:"dns_log,omitempty"`
+	MaskAddress   string                 `protobuf:"bytes,3,opt,name=mask_address,json=maskAddress,proto3" json:"mask_address,omitempty"` // empty|quarter|half|full
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogSettings) Reset() {
+	*x = LogSettings{}
+	mi := &file_commands_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogSettings) ProtoMessage() {}
+
+func (x *LogSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogSettings.ProtoReflect.Descriptor instead.
+func (*LogSettings) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *LogSettings) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *LogSettings) GetDnsLog() bool {
+	if x != nil {
+		return x.DnsLog
+	}
+	return false
+}
+
+func (x *LogSettings) GetMaskAddress() string {
+	if x != nil {
+		return x.MaskAddress
+	}
+	return ""
+}
+
+type GetLogSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogSettingsRequest) Reset() {
+	*x = GetLogSettingsRequest{}
+	mi := &file_commands_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogSettingsRequest) ProtoMessage() {}
+
+func (x *GetLogSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetLogSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *GetLogSettingsRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+// Xray can not change log settings of a running core, they are applied by restart.
+type SetLogSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Settings      *LogSettings           `protobuf:"bytes,2,opt,name=settings,proto3" json:"settings,omitempty"`
+	RestartCore   bool                   `protobuf:"varint,3,opt,name=restart_core,json=restartCore,proto3" json:"restart_core,omitempty"` // false - applied with the next core start
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogSettingsRequest) Reset() {
+	*x = SetLogSettingsRequest{}
+	mi := &file_commands_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogSettingsRequest) ProtoMessage() {}
+
+func (x *SetLogSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogSettingsRequest.ProtoReflect.Descriptor instead.
+func (*SetLogSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *SetLogSettingsRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *SetLogSettingsRequest) GetSettings() *LogSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *SetLogSettingsRequest) GetRestartCore() bool {
+	if x != nil {
+		return x.RestartCore
+	}
+	return false
+}
+
+type SetLogSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Restarted     bool                   `protobuf:"varint,1,opt,name=restarted,proto3" json:"restarted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogSettingsResponse) Reset() {
+	*x = SetLogSettingsResponse{}
+	mi := &file_commands_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConnectionMeta) String() string {
+func (x *SetLogSettingsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConnectionMeta) ProtoMessage() {}
+func (*SetLogSettingsResponse) ProtoMessage() {}
 
-func (x *ConnectionMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_commands_proto_msgTypes[7]
+func (x *SetLogSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -481,74 +5243,116 @@ func (x *ConnectionMeta) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConnectionMeta.ProtoReflect.Descriptor instead.
-func (*ConnectionMeta) Descriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use SetLogSettingsResponse.ProtoReflect.Descriptor instead.
+func (*SetLogSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *ConnectionMeta) GetClient() string {
+func (x *SetLogSettingsResponse) GetRestarted() bool {
 	if x != nil {
-		return x.Client
+		return x.Restarted
 	}
-	return ""
+	return false
 }
 
-func (x *ConnectionMeta) GetServer() string {
+type CoreBinaryInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Sha256        string                 `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Size          uint64                 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Modified      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=modified,proto3" json:"modified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoreBinaryInfo) Reset() {
+	*x = CoreBinaryInfo{}
+	mi := &file_commands_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoreBinaryInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoreBinaryInfo) ProtoMessage() {}
+
+func (x *CoreBinaryInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[84]
 	if x != nil {
-		return x.Server
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ConnectionMeta) GetProto() NetType {
+// Deprecated: Use CoreBinaryInfo.ProtoReflect.Descriptor instead.
+func (*CoreBinaryInfo) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *CoreBinaryInfo) GetName() string {
 	if x != nil {
-		return x.Proto
+		return x.Name
 	}
-	return NetType_HTTP
+	return ""
 }
 
-func (x *ConnectionMeta) GetInbound() string {
+func (x *CoreBinaryInfo) GetVersion() string {
 	if x != nil {
-		return x.Inbound
+		return x.Version
 	}
 	return ""
 }
 
-func (x *ConnectionMeta) GetOutbound() string {
+func (x *CoreBinaryInfo) GetSha256() string {
 	if x != nil {
-		return x.Outbound
+		return x.Sha256
 	}
 	return ""
 }
 
-func (x *ConnectionMeta) GetUser() string {
+func (x *CoreBinaryInfo) GetSize() uint64 {
 	if x != nil {
-		return x.User
+		return x.Size
 	}
-	return ""
+	return 0
 }
 
-type CoreStatusRequest struct {
+func (x *CoreBinaryInfo) GetModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Modified
+	}
+	return nil
+}
+
+type ListCoreBinariesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"` // instance whose active binary is reported
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CoreStatusRequest) Reset() {
-	*x = CoreStatusRequest{}
-	mi := &file_commands_proto_msgTypes[8]
+func (x *ListCoreBinariesRequest) Reset() {
+	*x = ListCoreBinariesRequest{}
+	mi := &file_commands_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CoreStatusRequest) String() string {
+func (x *ListCoreBinariesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CoreStatusRequest) ProtoMessage() {}
+func (*ListCoreBinariesRequest) ProtoMessage() {}
 
-func (x *CoreStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_commands_proto_msgTypes[8]
+func (x *ListCoreBinariesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -559,35 +5363,41 @@ func (x *CoreStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CoreStatusRequest.ProtoReflect.Descriptor instead.
-func (*CoreStatusRequest) Descriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ListCoreBinariesRequest.ProtoReflect.Descriptor instead.
+func (*ListCoreBinariesRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{85}
 }
 
-type CoreStatusResponse struct {
+func (x *ListCoreBinariesRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+type ListCoreBinariesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Working       bool                   `protobuf:"varint,1,opt,name=working,proto3" json:"working,omitempty"`
-	LastLog       string                 `protobuf:"bytes,2,opt,name=last_log,json=lastLog,proto3" json:"last_log,omitempty"`
-	WorkingTime   *durationpb.Duration   `protobuf:"bytes,3,opt,name=working_time,json=workingTime,proto3" json:"working_time,omitempty"`
+	Binaries      []*CoreBinaryInfo      `protobuf:"bytes,1,rep,name=binaries,proto3" json:"binaries,omitempty"`
+	Active        string                 `protobuf:"bytes,2,opt,name=active,proto3" json:"active,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CoreStatusResponse) Reset() {
-	*x = CoreStatusResponse{}
-	mi := &file_commands_proto_msgTypes[9]
+func (x *ListCoreBinariesResponse) Reset() {
+	*x = ListCoreBinariesResponse{}
+	mi := &file_commands_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CoreStatusResponse) String() string {
+func (x *ListCoreBinariesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CoreStatusResponse) ProtoMessage() {}
+func (*ListCoreBinariesResponse) ProtoMessage() {}
 
-func (x *CoreStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_commands_proto_msgTypes[9]
+func (x *ListCoreBinariesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -598,53 +5408,50 @@ func (x *CoreStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CoreStatusResponse.ProtoReflect.Descriptor instead.
-func (*CoreStatusResponse) Descriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ListCoreBinariesResponse.ProtoReflect.Descriptor instead.
+func (*ListCoreBinariesResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *CoreStatusResponse) GetWorking() bool {
+func (x *ListCoreBinariesResponse) GetBinaries() []*CoreBinaryInfo {
 	if x != nil {
-		return x.Working
+		return x.Binaries
 	}
-	return false
+	return nil
 }
 
-func (x *CoreStatusResponse) GetLastLog() string {
+func (x *ListCoreBinariesResponse) GetActive() string {
 	if x != nil {
-		return x.LastLog
+		return x.Active
 	}
 	return ""
 }
 
-func (x *CoreStatusResponse) GetWorkingTime() *durationpb.Duration {
-	if x != nil {
-		return x.WorkingTime
-	}
-	return nil
-}
-
-type CoreRestartRequest struct {
+// First chunk carries name and sha256, following chunks only data.
+type CoreBinaryChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // new name in the cores dir, installed binaries are never replaced
+	Sha256        string                 `protobuf:"bytes,2,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CoreRestartRequest) Reset() {
-	*x = CoreRestartRequest{}
-	mi := &file_commands_proto_msgTypes[10]
+func (x *CoreBinaryChunk) Reset() {
+	*x = CoreBinaryChunk{}
+	mi := &file_commands_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CoreRestartRequest) String() string {
+func (x *CoreBinaryChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CoreRestartRequest) ProtoMessage() {}
+func (*CoreBinaryChunk) ProtoMessage() {}
 
-func (x *CoreRestartRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_commands_proto_msgTypes[10]
+func (x *CoreBinaryChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -655,32 +5462,55 @@ func (x *CoreRestartRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CoreRestartRequest.ProtoReflect.Descriptor instead.
-func (*CoreRestartRequest) Descriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use CoreBinaryChunk.ProtoReflect.Descriptor instead.
+func (*CoreBinaryChunk) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{87}
 }
 
-type CoreRestartResponse struct {
+func (x *CoreBinaryChunk) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CoreBinaryChunk) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *CoreBinaryChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SwitchCoreBinaryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CoreRestartResponse) Reset() {
-	*x = CoreRestartResponse{}
-	mi := &file_commands_proto_msgTypes[11]
+func (x *SwitchCoreBinaryRequest) Reset() {
+	*x = SwitchCoreBinaryRequest{}
+	mi := &file_commands_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CoreRestartResponse) String() string {
+func (x *SwitchCoreBinaryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CoreRestartResponse) ProtoMessage() {}
+func (*SwitchCoreBinaryRequest) ProtoMessage() {}
 
-func (x *CoreRestartResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_commands_proto_msgTypes[11]
+func (x *SwitchCoreBinaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -691,32 +5521,46 @@ func (x *CoreRestartResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CoreRestartResponse.ProtoReflect.Descriptor instead.
-func (*CoreRestartResponse) Descriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use SwitchCoreBinaryRequest.ProtoReflect.Descriptor instead.
+func (*SwitchCoreBinaryRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{88}
 }
 
-type GetConfigRequest struct {
+func (x *SwitchCoreBinaryRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *SwitchCoreBinaryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SwitchCoreBinaryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetConfigRequest) Reset() {
-	*x = GetConfigRequest{}
-	mi := &file_commands_proto_msgTypes[12]
+func (x *SwitchCoreBinaryResponse) Reset() {
+	*x = SwitchCoreBinaryResponse{}
+	mi := &file_commands_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetConfigRequest) String() string {
+func (x *SwitchCoreBinaryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConfigRequest) ProtoMessage() {}
+func (*SwitchCoreBinaryResponse) ProtoMessage() {}
 
-func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_commands_proto_msgTypes[12]
+func (x *SwitchCoreBinaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -727,33 +5571,33 @@ func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
-func (*GetConfigRequest) Descriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use SwitchCoreBinaryResponse.ProtoReflect.Descriptor instead.
+func (*SwitchCoreBinaryResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{89}
 }
 
-type GetConfigResponse struct {
+type ListScheduleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          string                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"` // empty - jobs of all instances
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetConfigResponse) Reset() {
-	*x = GetConfigResponse{}
-	mi := &file_commands_proto_msgTypes[13]
+func (x *ListScheduleRequest) Reset() {
+	*x = ListScheduleRequest{}
+	mi := &file_commands_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetConfigResponse) String() string {
+func (x *ListScheduleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConfigResponse) ProtoMessage() {}
+func (*ListScheduleRequest) ProtoMessage() {}
 
-func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_commands_proto_msgTypes[13]
+func (x *ListScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -764,41 +5608,49 @@ func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConfigResponse.ProtoReflect.Descriptor instead.
-func (*GetConfigResponse) Descriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ListScheduleRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{90}
 }
 
-func (x *GetConfigResponse) GetData() string {
+func (x *ListScheduleRequest) GetInstance() string {
 	if x != nil {
-		return x.Data
+		return x.Instance
 	}
 	return ""
 }
 
-type UploadConfigRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          string                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	RestartCore   bool                   `protobuf:"varint,2,opt,name=restart_core,json=restartCore,proto3" json:"restart_core,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ScheduledJob struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Instance       string                 `protobuf:"bytes,2,opt,name=instance,proto3" json:"instance,omitempty"`
+	Action         string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Cron           string                 `protobuf:"bytes,4,opt,name=cron,proto3" json:"cron,omitempty"`
+	Window         string                 `protobuf:"bytes,5,opt,name=window,proto3" json:"window,omitempty"`
+	WindowCron     string                 `protobuf:"bytes,6,opt,name=window_cron,json=windowCron,proto3" json:"window_cron,omitempty"`
+	WindowDuration *durationpb.Duration   `protobuf:"bytes,7,opt,name=window_duration,json=windowDuration,proto3" json:"window_duration,omitempty"`
+	NextRun        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"` // empty when the job will never run again
+	LastRun        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_run,json=lastRun,proto3" json:"last_run,omitempty"`
+	LastError      string                 `protobuf:"bytes,10,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *UploadConfigRequest) Reset() {
-	*x = UploadConfigRequest{}
-	mi := &file_commands_proto_msgTypes[14]
+func (x *ScheduledJob) Reset() {
+	*x = ScheduledJob{}
+	mi := &file_commands_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadConfigRequest) String() string {
+func (x *ScheduledJob) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadConfigRequest) ProtoMessage() {}
+func (*ScheduledJob) ProtoMessage() {}
 
-func (x *UploadConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_commands_proto_msgTypes[14]
+func (x *ScheduledJob) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -809,46 +5661,103 @@ func (x *UploadConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadConfigRequest.ProtoReflect.Descriptor instead.
-func (*UploadConfigRequest) Descriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ScheduledJob.ProtoReflect.Descriptor instead.
+func (*ScheduledJob) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{91}
 }
 
-func (x *UploadConfigRequest) GetData() string {
+func (x *ScheduledJob) GetName() string {
 	if x != nil {
-		return x.Data
+		return x.Name
 	}
 	return ""
 }
 
-func (x *UploadConfigRequest) GetRestartCore() bool {
+func (x *ScheduledJob) GetInstance() string {
 	if x != nil {
-		return x.RestartCore
+		return x.Instance
 	}
-	return false
+	return ""
 }
 
-type UploadConfigResponse struct {
+func (x *ScheduledJob) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ScheduledJob) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *ScheduledJob) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *ScheduledJob) GetWindowCron() string {
+	if x != nil {
+		return x.WindowCron
+	}
+	return ""
+}
+
+func (x *ScheduledJob) GetWindowDuration() *durationpb.Duration {
+	if x != nil {
+		return x.WindowDuration
+	}
+	return nil
+}
+
+func (x *ScheduledJob) GetNextRun() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRun
+	}
+	return nil
+}
+
+func (x *ScheduledJob) GetLastRun() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRun
+	}
+	return nil
+}
+
+func (x *ScheduledJob) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+type ListScheduleResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*ScheduledJob        `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadConfigResponse) Reset() {
-	*x = UploadConfigResponse{}
-	mi := &file_commands_proto_msgTypes[15]
+func (x *ListScheduleResponse) Reset() {
+	*x = ListScheduleResponse{}
+	mi := &file_commands_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadConfigResponse) String() string {
+func (x *ListScheduleResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadConfigResponse) ProtoMessage() {}
+func (*ListScheduleResponse) ProtoMessage() {}
 
-func (x *UploadConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_commands_proto_msgTypes[15]
+func (x *ListScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commands_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -859,17 +5768,38 @@ func (x *UploadConfigResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadConfigResponse.ProtoReflect.Descriptor instead.
-func (*UploadConfigResponse) Descriptor() ([]byte, []int) {
-	return file_commands_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ListScheduleResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_commands_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *ListScheduleResponse) GetJobs() []*ScheduledJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
 }
 
 var File_commands_proto protoreflect.FileDescriptor
 
 const file_commands_proto_rawDesc = "" +
 	"\n" +
-	"\x0ecommands.proto\x12\x10xraymon.commands\x1a\x1egoogle/protobuf/duration.proto\"\x16\n" +
-	"\x14RotateJournalRequest\"\x17\n" +
+	"\x0ecommands.proto\x12\x10xraymon.commands\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"c\n" +
+	"\x12WatchEventsRequest\x121\n" +
+	"\x05types\x18\x01 \x03(\x0e2\x1b.xraymon.commands.EventTypeR\x05types\x12\x1a\n" +
+	"\binstance\x18\x02 \x01(\tR\binstance\"\xb8\x02\n" +
+	"\tCoreEvent\x12/\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1b.xraymon.commands.EventTypeR\x04type\x12\x1a\n" +
+	"\binstance\x18\b \x01(\tR\binstance\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1b\n" +
+	"\texit_code\x18\x04 \x01(\x05R\bexitCode\x12\x18\n" +
+	"\aattempt\x18\x05 \x01(\rR\aattempt\x12/\n" +
+	"\x05delay\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x05delay\x12\x18\n" +
+	"\ajournal\x18\a \x01(\tR\ajournal\x12\x12\n" +
+	"\x04rate\x18\t \x01(\x04R\x04rate\"2\n" +
+	"\x14RotateJournalRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\"\x17\n" +
 	"\x15RotateJournalResponse\"\x96\x01\n" +
 	"\fConnectionIO\x12\x19\n" +
 	"\bbytes_rx\x18\x01 \x01(\x04R\abytesRx\x12\x19\n" +
@@ -881,31 +5811,369 @@ const file_commands_proto_rawDesc = "" +
 	"\x05alias\x18\x02 \x01(\tR\x05alias\x12.\n" +
 	"\x02io\x18\x03 \x01(\v2\x1e.xraymon.commands.ConnectionIOR\x02io\"I\n" +
 	"\x14NetworkStatsResponse\x121\n" +
-	"\x05stats\x18\x01 \x03(\v2\x1b.xraymon.commands.StatsMetaR\x05stats\"\x15\n" +
-	"\x13NetworkStatsRequest\".\n" +
+	"\x05stats\x18\x01 \x03(\v2\x1b.xraymon.commands.StatsMetaR\x05stats\"1\n" +
+	"\x13NetworkStatsRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\"J\n" +
 	"\x18ConnectionJournalRequest\x12\x12\n" +
-	"\x04last\x18\x01 \x01(\x04R\x04last\"\xbb\x01\n" +
+	"\x04last\x18\x01 \x01(\x04R\x04last\x12\x1a\n" +
+	"\binstance\x18\x02 \x01(\tR\binstance\"F\n" +
+	"\x14StderrJournalRequest\x12\x12\n" +
+	"\x04last\x18\x01 \x01(\x04R\x04last\x12\x1a\n" +
+	"\binstance\x18\x02 \x01(\tR\binstance\"P\n" +
+	"\n" +
+	"StderrLine\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\tR\x04line\"\xbb\x01\n" +
 	"\x0eConnectionMeta\x12\x16\n" +
 	"\x06client\x18\x01 \x01(\tR\x06client\x12\x16\n" +
 	"\x06server\x18\x02 \x01(\tR\x06server\x12/\n" +
 	"\x05proto\x18\x03 \x01(\x0e2\x19.xraymon.commands.NetTypeR\x05proto\x12\x18\n" +
 	"\ainbound\x18\x04 \x01(\tR\ainbound\x12\x1a\n" +
 	"\boutbound\x18\x05 \x01(\tR\boutbound\x12\x12\n" +
-	"\x04user\x18\x06 \x01(\tR\x04user\"\x13\n" +
-	"\x11CoreStatusRequest\"\x87\x01\n" +
+	"\x04user\x18\x06 \x01(\tR\x04user\"\x16\n" +
+	"\x14ListInstancesRequest\"U\n" +
+	"\fInstanceInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x121\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x1b.xraymon.commands.CoreStateR\x05state\"U\n" +
+	"\x15ListInstancesResponse\x12<\n" +
+	"\tinstances\x18\x01 \x03(\v2\x1e.xraymon.commands.InstanceInfoR\tinstances\"/\n" +
+	"\x11CoreStatusRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\"\xdf\x04\n" +
 	"\x12CoreStatusResponse\x12\x18\n" +
 	"\aworking\x18\x01 \x01(\bR\aworking\x12\x19\n" +
 	"\blast_log\x18\x02 \x01(\tR\alastLog\x12<\n" +
-	"\fworking_time\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\vworkingTime\"\x14\n" +
-	"\x12CoreRestartRequest\"\x15\n" +
-	"\x13CoreRestartResponse\"\x12\n" +
-	"\x10GetConfigRequest\"'\n" +
+	"\fworking_time\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\vworkingTime\x121\n" +
+	"\x05state\x18\x04 \x01(\x0e2\x1b.xraymon.commands.CoreStateR\x05state\x12\x18\n" +
+	"\acrashes\x18\x05 \x01(\rR\acrashes\x12?\n" +
+	"\rlast_rollback\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\flastRollback\x12'\n" +
+	"\x0frollback_reason\x18\a \x01(\tR\x0erollbackReason\x12\x1a\n" +
+	"\brestarts\x18\b \x01(\rR\brestarts\x12:\n" +
+	"\aprocess\x18\t \x01(\v2 .xraymon.commands.ProcessMetricsR\aprocess\x12\x16\n" +
+	"\x06binary\x18\n" +
+	" \x01(\tR\x06binary\x12\x18\n" +
+	"\ahealthy\x18\v \x01(\bR\ahealthy\x12>\n" +
+	"\rprobe_latency\x18\f \x01(\v2\x19.google.protobuf.DurationR\fprobeLatency\x12\x1a\n" +
+	"\battached\x18\r \x01(\bR\battached\x12\x1a\n" +
+	"\bdraining\x18\x0e \x01(\bR\bdraining\x12\x1d\n" +
+	"\n" +
+	"drain_rate\x18\x0f \x01(\x04R\tdrainRate\"\xcb\x01\n" +
+	"\x0eProcessMetrics\x12\x10\n" +
+	"\x03pid\x18\x01 \x01(\rR\x03pid\x12\x1b\n" +
+	"\trss_bytes\x18\x02 \x01(\x04R\brssBytes\x124\n" +
+	"\bcpu_time\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\acpuTime\x12\x1f\n" +
+	"\vcpu_percent\x18\x04 \x01(\x01R\n" +
+	"cpuPercent\x12\x18\n" +
+	"\athreads\x18\x05 \x01(\rR\athreads\x12\x19\n" +
+	"\bopen_fds\x18\x06 \x01(\rR\aopenFds\"l\n" +
+	"\x17WatchCoreMetricsRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x125\n" +
+	"\binterval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\binterval\"\xc8\x01\n" +
+	"\vCoreMetrics\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x121\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x1b.xraymon.commands.CoreStateR\x05state\x12\x1a\n" +
+	"\brestarts\x18\x03 \x01(\rR\brestarts\x12:\n" +
+	"\aprocess\x18\x04 \x01(\v2 .xraymon.commands.ProcessMetricsR\aprocess\"F\n" +
+	"\x12CoreRestartRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x14\n" +
+	"\x05drain\x18\x02 \x01(\bR\x05drain\"\x15\n" +
+	"\x13CoreRestartResponse\".\n" +
+	"\x10CoreStartRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\"\x13\n" +
+	"\x11CoreStartResponse\"-\n" +
+	"\x0fCoreStopRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\"\x12\n" +
+	"\x10CoreStopResponse\".\n" +
+	"\x10GetConfigRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\"'\n" +
 	"\x11GetConfigResponse\x12\x12\n" +
-	"\x04data\x18\x01 \x01(\tR\x04data\"L\n" +
+	"\x04data\x18\x01 \x01(\tR\x04data\"\xb1\x01\n" +
 	"\x13UploadConfigRequest\x12\x12\n" +
 	"\x04data\x18\x01 \x01(\tR\x04data\x12!\n" +
-	"\frestart_core\x18\x02 \x01(\bR\vrestartCore\"\x16\n" +
-	"\x14UploadConfigResponse*5\n" +
+	"\frestart_core\x18\x02 \x01(\bR\vrestartCore\x12\x1a\n" +
+	"\binstance\x18\x03 \x01(\tR\binstance\x12\x14\n" +
+	"\x05drain\x18\x04 \x01(\bR\x05drain\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\x12\x17\n" +
+	"\adry_run\x18\x06 \x01(\bR\x06dryRun\"\xab\x01\n" +
+	"\x14UploadConfigResponse\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x04R\brevision\x128\n" +
+	"\achanges\x18\x02 \x03(\v2\x1e.xraymon.commands.ConfigChangeR\achanges\x12!\n" +
+	"\fdropped_keys\x18\x03 \x03(\tR\vdroppedKeys\x12\x1a\n" +
+	"\brejected\x18\x04 \x01(\tR\brejected\"\x9e\x01\n" +
+	"\x0eConfigRevision\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x04R\x06number\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\x12\x12\n" +
+	"\x04hash\x18\x05 \x01(\tR\x04hash\"N\n" +
+	"\x1aListConfigRevisionsRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\rR\x05limit\"]\n" +
+	"\x1bListConfigRevisionsResponse\x12>\n" +
+	"\trevisions\x18\x01 \x03(\v2 .xraymon.commands.ConfigRevisionR\trevisions\"R\n" +
+	"\x18GetConfigRevisionRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x04R\brevision\"m\n" +
+	"\x19GetConfigRevisionResponse\x12<\n" +
+	"\brevision\x18\x01 \x01(\v2 .xraymon.commands.ConfigRevisionR\brevision\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\tR\x04data\"\\\n" +
+	"\x1aDiffConfigRevisionsRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x04R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x04R\x02to\"\x8a\x01\n" +
+	"\fConfigChange\x120\n" +
+	"\x02op\x18\x01 \x01(\x0e2 .xraymon.commands.ConfigChangeOpR\x02op\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x10\n" +
+	"\x03old\x18\x03 \x01(\tR\x03old\x12\x10\n" +
+	"\x03new\x18\x04 \x01(\tR\x03new\x12\x10\n" +
+	"\x03tag\x18\x05 \x01(\tR\x03tag\"W\n" +
+	"\x1bDiffConfigRevisionsResponse\x128\n" +
+	"\achanges\x18\x01 \x03(\v2\x1e.xraymon.commands.ConfigChangeR\achanges\"\xa2\x01\n" +
+	"\x15RollbackConfigRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x04R\brevision\x12!\n" +
+	"\frestart_core\x18\x03 \x01(\bR\vrestartCore\x12\x14\n" +
+	"\x05drain\x18\x04 \x01(\bR\x05drain\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\"V\n" +
+	"\x16RollbackConfigResponse\x12<\n" +
+	"\brevision\x18\x01 \x01(\v2 .xraymon.commands.ConfigRevisionR\brevision\"w\n" +
+	"\aInbound\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x1a\n" +
+	"\bprotocol\x18\x02 \x01(\tR\bprotocol\x12\x16\n" +
+	"\x06listen\x18\x03 \x01(\tR\x06listen\x12\x12\n" +
+	"\x04port\x18\x04 \x01(\tR\x04port\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\tR\x04data\"1\n" +
+	"\x13ListInboundsRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\"M\n" +
+	"\x14ListInboundsResponse\x125\n" +
+	"\binbounds\x18\x01 \x03(\v2\x19.xraymon.commands.InboundR\binbounds\"A\n" +
+	"\x11GetInboundRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\"\x85\x01\n" +
+	"\x11PutInboundRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\tR\x04data\x12\x14\n" +
+	"\x05apply\x18\x04 \x01(\bR\x05apply\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\"J\n" +
+	"\x12PutInboundResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\bR\acreated\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x04R\brevision\"t\n" +
+	"\x14DeleteInboundRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12\x14\n" +
+	"\x05apply\x18\x03 \x01(\bR\x05apply\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\"3\n" +
+	"\x15DeleteInboundResponse\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x04R\brevision\"L\n" +
+	"\bOutbound\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x1a\n" +
+	"\bprotocol\x18\x02 \x01(\tR\bprotocol\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\tR\x04data\"2\n" +
+	"\x14ListOutboundsRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\"Q\n" +
+	"\x15ListOutboundsResponse\x128\n" +
+	"\toutbounds\x18\x01 \x03(\v2\x1a.xraymon.commands.OutboundR\toutbounds\"B\n" +
+	"\x12GetOutboundRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\"\x86\x01\n" +
+	"\x12PutOutboundRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\tR\x04data\x12\x14\n" +
+	"\x05apply\x18\x04 \x01(\bR\x05apply\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\"K\n" +
+	"\x13PutOutboundResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\bR\acreated\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x04R\brevision\"\xaf\x01\n" +
+	"\x15DeleteOutboundRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12\x18\n" +
+	"\acascade\x18\x03 \x01(\bR\acascade\x12\x14\n" +
+	"\x05apply\x18\x04 \x01(\bR\x05apply\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\x12\x1e\n" +
+	"\n" +
+	"dependents\x18\x06 \x03(\tR\n" +
+	"dependents\"N\n" +
+	"\x16DeleteOutboundResponse\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x04R\brevision\x12\x18\n" +
+	"\aremoved\x18\x02 \x03(\tR\aremoved\"\x8b\x01\n" +
+	"\bBalancer\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x1a\n" +
+	"\bselector\x18\x02 \x03(\tR\bselector\x12!\n" +
+	"\ffallback_tag\x18\x03 \x01(\tR\vfallbackTag\x12\x1a\n" +
+	"\bstrategy\x18\x04 \x01(\tR\bstrategy\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\tR\x04data\"2\n" +
+	"\x14ListBalancersRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\"Q\n" +
+	"\x15ListBalancersResponse\x128\n" +
+	"\tbalancers\x18\x01 \x03(\v2\x1a.xraymon.commands.BalancerR\tbalancers\"B\n" +
+	"\x12GetBalancerRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\"\x86\x01\n" +
+	"\x12PutBalancerRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\tR\x04data\x12\x14\n" +
+	"\x05apply\x18\x04 \x01(\bR\x05apply\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\"K\n" +
+	"\x13PutBalancerResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\bR\acreated\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x04R\brevision\"\x8f\x01\n" +
+	"\x15DeleteBalancerRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12\x18\n" +
+	"\acascade\x18\x03 \x01(\bR\acascade\x12\x14\n" +
+	"\x05apply\x18\x04 \x01(\bR\x05apply\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\"N\n" +
+	"\x16DeleteBalancerResponse\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x04R\brevision\x12\x18\n" +
+	"\aremoved\x18\x02 \x03(\tR\aremoved\"\x8f\x01\n" +
+	"\vRoutingRule\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\x12!\n" +
+	"\foutbound_tag\x18\x03 \x01(\tR\voutboundTag\x12!\n" +
+	"\fbalancer_tag\x18\x04 \x01(\tR\vbalancerTag\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\tR\x04data\"5\n" +
+	"\x17ListRoutingRulesRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\"O\n" +
+	"\x18ListRoutingRulesResponse\x123\n" +
+	"\x05rules\x18\x01 \x03(\v2\x1d.xraymon.commands.RoutingRuleR\x05rules\"\x96\x01\n" +
+	"\x18InsertRoutingRuleRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\tR\x04data\x12\x14\n" +
+	"\x05apply\x18\x04 \x01(\bR\x05apply\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\"7\n" +
+	"\x19InsertRoutingRuleResponse\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x04R\brevision\"\x92\x01\n" +
+	"\x16MoveRoutingRuleRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12\x1a\n" +
+	"\bposition\x18\x03 \x01(\x05R\bposition\x12\x14\n" +
+	"\x05apply\x18\x04 \x01(\bR\x05apply\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\"5\n" +
+	"\x17MoveRoutingRuleResponse\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x04R\brevision\"\x8d\x01\n" +
+	"\x19ReplaceRoutingRuleRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\tR\x04data\x12\x14\n" +
+	"\x05apply\x18\x04 \x01(\bR\x05apply\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\"8\n" +
+	"\x1aReplaceRoutingRuleResponse\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x04R\brevision\"x\n" +
+	"\x18DeleteRoutingRuleRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12\x14\n" +
+	"\x05apply\x18\x03 \x01(\bR\x05apply\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\"7\n" +
+	"\x19DeleteRoutingRuleResponse\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x04R\brevision\"\x94\x02\n" +
+	"\x11MatchRouteRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x1f\n" +
+	"\vinbound_tag\x18\x02 \x01(\tR\n" +
+	"inboundTag\x12\x18\n" +
+	"\anetwork\x18\x03 \x01(\tR\anetwork\x12\x16\n" +
+	"\x06domain\x18\x04 \x01(\tR\x06domain\x12\x0e\n" +
+	"\x02ip\x18\x05 \x01(\tR\x02ip\x12\x12\n" +
+	"\x04port\x18\x06 \x01(\rR\x04port\x12\x1b\n" +
+	"\tsource_ip\x18\a \x01(\tR\bsourceIp\x12\x1f\n" +
+	"\vsource_port\x18\b \x01(\rR\n" +
+	"sourcePort\x12\x1a\n" +
+	"\bprotocol\x18\t \x01(\tR\bprotocol\x12\x12\n" +
+	"\x04user\x18\n" +
+	" \x01(\tR\x04user\"\xea\x01\n" +
+	"\x12MatchRouteResponse\x12\x18\n" +
+	"\amatched\x18\x01 \x01(\bR\amatched\x121\n" +
+	"\x04rule\x18\x02 \x01(\v2\x1d.xraymon.commands.RoutingRuleR\x04rule\x12!\n" +
+	"\foutbound_tag\x18\x03 \x01(\tR\voutboundTag\x12!\n" +
+	"\fbalancer_tag\x18\x04 \x01(\tR\vbalancerTag\x12A\n" +
+	"\fundetermined\x18\x05 \x03(\v2\x1d.xraymon.commands.RoutingRuleR\fundetermined\"G\n" +
+	"\x13CrashHistoryRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\rR\x05limit\"\xad\x02\n" +
+	"\vCrashRecord\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1b\n" +
+	"\texit_code\x18\x02 \x01(\x05R\bexitCode\x12\x16\n" +
+	"\x06signal\x18\x03 \x01(\tR\x06signal\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x121\n" +
+	"\x06uptime\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x06uptime\x12\x1f\n" +
+	"\vconfig_hash\x18\x06 \x01(\tR\n" +
+	"configHash\x12\x16\n" +
+	"\x06binary\x18\a \x01(\tR\x06binary\x12\x16\n" +
+	"\x06output\x18\b \x03(\tR\x06output\x12\x1d\n" +
+	"\n" +
+	"config_rev\x18\t \x01(\x04R\tconfigRev\"O\n" +
+	"\x14CrashHistoryResponse\x127\n" +
+	"\arecords\x18\x01 \x03(\v2\x1d.xraymon.commands.CrashRecordR\arecords\"_\n" +
+	"\vLogSettings\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12\x17\n" +
+	"\adns_log\x18\x02 \x01(\bR\x06dnsLog\x12!\n" +
+	"\fmask_address\x18\x03 \x01(\tR\vmaskAddress\"3\n" +
+	"\x15GetLogSettingsRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\"\x91\x01\n" +
+	"\x15SetLogSettingsRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x129\n" +
+	"\bsettings\x18\x02 \x01(\v2\x1d.xraymon.commands.LogSettingsR\bsettings\x12!\n" +
+	"\frestart_core\x18\x03 \x01(\bR\vrestartCore\"6\n" +
+	"\x16SetLogSettingsResponse\x12\x1c\n" +
+	"\trestarted\x18\x01 \x01(\bR\trestarted\"\xa2\x01\n" +
+	"\x0eCoreBinaryInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x04R\x04size\x126\n" +
+	"\bmodified\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bmodified\"5\n" +
+	"\x17ListCoreBinariesRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\"p\n" +
+	"\x18ListCoreBinariesResponse\x12<\n" +
+	"\bbinaries\x18\x01 \x03(\v2 .xraymon.commands.CoreBinaryInfoR\bbinaries\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\tR\x06active\"Q\n" +
+	"\x0fCoreBinaryChunk\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06sha256\x18\x02 \x01(\tR\x06sha256\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"I\n" +
+	"\x17SwitchCoreBinaryRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x1a\n" +
+	"\x18SwitchCoreBinaryResponse\"1\n" +
+	"\x13ListScheduleRequest\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\"\xf4\x02\n" +
+	"\fScheduledJob\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\binstance\x18\x02 \x01(\tR\binstance\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x12\n" +
+	"\x04cron\x18\x04 \x01(\tR\x04cron\x12\x16\n" +
+	"\x06window\x18\x05 \x01(\tR\x06window\x12\x1f\n" +
+	"\vwindow_cron\x18\x06 \x01(\tR\n" +
+	"windowCron\x12B\n" +
+	"\x0fwindow_duration\x18\a \x01(\v2\x19.google.protobuf.DurationR\x0ewindowDuration\x125\n" +
+	"\bnext_run\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\anextRun\x125\n" +
+	"\blast_run\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\alastRun\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\n" +
+	" \x01(\tR\tlastError\"J\n" +
+	"\x14ListScheduleResponse\x122\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x1e.xraymon.commands.ScheduledJobR\x04jobs*\xb1\x03\n" +
+	"\tEventType\x12\x11\n" +
+	"\rEVENT_UNKNOWN\x10\x00\x12\x16\n" +
+	"\x12EVENT_CORE_STARTED\x10\x01\x12\x15\n" +
+	"\x11EVENT_CORE_EXITED\x10\x02\x12\x16\n" +
+	"\x12EVENT_CORE_BACKOFF\x10\x03\x12\x15\n" +
+	"\x11EVENT_CORE_FAILED\x10\x04\x12\x16\n" +
+	"\x12EVENT_CONFIG_SAVED\x10\x05\x12\x19\n" +
+	"\x15EVENT_JOURNAL_ROTATED\x10\x06\x12\x19\n" +
+	"\x15EVENT_STATS_COLLECTED\x10\a\x12\x19\n" +
+	"\x15EVENT_CONFIG_ROLLBACK\x10\b\x12\x18\n" +
+	"\x14EVENT_CONFIG_APPLIED\x10\t\x12\x19\n" +
+	"\x15EVENT_BINARY_SWITCHED\x10\n" +
+	"\x12\x19\n" +
+	"\x15EVENT_BINARY_ROLLBACK\x10\v\x12\x18\n" +
+	"\x14EVENT_CORE_UNHEALTHY\x10\f\x12\x13\n" +
+	"\x0fEVENT_SCHEDULED\x10\r\x12\x17\n" +
+	"\x13EVENT_DRAIN_STARTED\x10\x0e\x12\x18\n" +
+	"\x14EVENT_DRAIN_PROGRESS\x10\x0f\x12\x18\n" +
+	"\x14EVENT_DRAIN_FINISHED\x10\x10*5\n" +
 	"\x0eConnectionType\x12\v\n" +
 	"\aINBOUND\x10\x00\x12\f\n" +
 	"\bOUTBOUND\x10\x01\x12\b\n" +
@@ -913,17 +6181,69 @@ const file_commands_proto_rawDesc = "" +
 	"\aNetType\x12\b\n" +
 	"\x04HTTP\x10\x00\x12\a\n" +
 	"\x03TCP\x10\x01\x12\a\n" +
-	"\x03UDP\x10\x022\x80\x03\n" +
-	"\x14CoreManagmentService\x12W\n" +
+	"\x03UDP\x10\x02*d\n" +
+	"\tCoreState\x12\x10\n" +
+	"\fCORE_STOPPED\x10\x00\x12\x10\n" +
+	"\fCORE_RUNNING\x10\x01\x12\x10\n" +
+	"\fCORE_CRASHED\x10\x02\x12\x10\n" +
+	"\fCORE_BACKOFF\x10\x03\x12\x0f\n" +
+	"\vCORE_FAILED\x10\x04*z\n" +
+	"\x0eConfigChangeOp\x12\x19\n" +
+	"\x15CONFIG_CHANGE_UNKNOWN\x10\x00\x12\x17\n" +
+	"\x13CONFIG_CHANGE_ADDED\x10\x01\x12\x19\n" +
+	"\x15CONFIG_CHANGE_REMOVED\x10\x02\x12\x19\n" +
+	"\x15CONFIG_CHANGE_CHANGED\x10\x032\x8d\x19\n" +
+	"\x14CoreManagmentService\x12`\n" +
+	"\rListInstances\x12&.xraymon.commands.ListInstancesRequest\x1a'.xraymon.commands.ListInstancesResponse\x12W\n" +
 	"\n" +
 	"CoreStatus\x12#.xraymon.commands.CoreStatusRequest\x1a$.xraymon.commands.CoreStatusResponse\x12Z\n" +
 	"\vCoreRestart\x12$.xraymon.commands.CoreRestartRequest\x1a%.xraymon.commands.CoreRestartResponse\x12T\n" +
+	"\tCoreStart\x12\".xraymon.commands.CoreStartRequest\x1a#.xraymon.commands.CoreStartResponse\x12Q\n" +
+	"\bCoreStop\x12!.xraymon.commands.CoreStopRequest\x1a\".xraymon.commands.CoreStopResponse\x12T\n" +
 	"\tGetConfig\x12\".xraymon.commands.GetConfigRequest\x1a#.xraymon.commands.GetConfigResponse\x12]\n" +
-	"\fUploadConfig\x12%.xraymon.commands.UploadConfigRequest\x1a&.xraymon.commands.UploadConfigResponse2\xb7\x02\n" +
+	"\fUploadConfig\x12%.xraymon.commands.UploadConfigRequest\x1a&.xraymon.commands.UploadConfigResponse\x12^\n" +
+	"\x10WatchCoreMetrics\x12).xraymon.commands.WatchCoreMetricsRequest\x1a\x1d.xraymon.commands.CoreMetrics0\x01\x12]\n" +
+	"\fCrashHistory\x12%.xraymon.commands.CrashHistoryRequest\x1a&.xraymon.commands.CrashHistoryResponse\x12X\n" +
+	"\x0eGetLogSettings\x12'.xraymon.commands.GetLogSettingsRequest\x1a\x1d.xraymon.commands.LogSettings\x12c\n" +
+	"\x0eSetLogSettings\x12'.xraymon.commands.SetLogSettingsRequest\x1a(.xraymon.commands.SetLogSettingsResponse\x12r\n" +
+	"\x13ListConfigRevisions\x12,.xraymon.commands.ListConfigRevisionsRequest\x1a-.xraymon.commands.ListConfigRevisionsResponse\x12l\n" +
+	"\x11GetConfigRevision\x12*.xraymon.commands.GetConfigRevisionRequest\x1a+.xraymon.commands.GetConfigRevisionResponse\x12r\n" +
+	"\x13DiffConfigRevisions\x12,.xraymon.commands.DiffConfigRevisionsRequest\x1a-.xraymon.commands.DiffConfigRevisionsResponse\x12c\n" +
+	"\x0eRollbackConfig\x12'.xraymon.commands.RollbackConfigRequest\x1a(.xraymon.commands.RollbackConfigResponse\x12]\n" +
+	"\fListInbounds\x12%.xraymon.commands.ListInboundsRequest\x1a&.xraymon.commands.ListInboundsResponse\x12L\n" +
+	"\n" +
+	"GetInbound\x12#.xraymon.commands.GetInboundRequest\x1a\x19.xraymon.commands.Inbound\x12W\n" +
+	"\n" +
+	"PutInbound\x12#.xraymon.commands.PutInboundRequest\x1a$.xraymon.commands.PutInboundResponse\x12`\n" +
+	"\rDeleteInbound\x12&.xraymon.commands.DeleteInboundRequest\x1a'.xraymon.commands.DeleteInboundResponse\x12`\n" +
+	"\rListOutbounds\x12&.xraymon.commands.ListOutboundsRequest\x1a'.xraymon.commands.ListOutboundsResponse\x12O\n" +
+	"\vGetOutbound\x12$.xraymon.commands.GetOutboundRequest\x1a\x1a.xraymon.commands.Outbound\x12Z\n" +
+	"\vPutOutbound\x12$.xraymon.commands.PutOutboundRequest\x1a%.xraymon.commands.PutOutboundResponse\x12c\n" +
+	"\x0eDeleteOutbound\x12'.xraymon.commands.DeleteOutboundRequest\x1a(.xraymon.commands.DeleteOutboundResponse\x12`\n" +
+	"\rListBalancers\x12&.xraymon.commands.ListBalancersRequest\x1a'.xraymon.commands.ListBalancersResponse\x12O\n" +
+	"\vGetBalancer\x12$.xraymon.commands.GetBalancerRequest\x1a\x1a.xraymon.commands.Balancer\x12Z\n" +
+	"\vPutBalancer\x12$.xraymon.commands.PutBalancerRequest\x1a%.xraymon.commands.PutBalancerResponse\x12c\n" +
+	"\x0eDeleteBalancer\x12'.xraymon.commands.DeleteBalancerRequest\x1a(.xraymon.commands.DeleteBalancerResponse\x12i\n" +
+	"\x10ListRoutingRules\x12).xraymon.commands.ListRoutingRulesRequest\x1a*.xraymon.commands.ListRoutingRulesResponse\x12l\n" +
+	"\x11InsertRoutingRule\x12*.xraymon.commands.InsertRoutingRuleRequest\x1a+.xraymon.commands.InsertRoutingRuleResponse\x12f\n" +
+	"\x0fMoveRoutingRule\x12(.xraymon.commands.MoveRoutingRuleRequest\x1a).xraymon.commands.MoveRoutingRuleResponse\x12o\n" +
+	"\x12ReplaceRoutingRule\x12+.xraymon.commands.ReplaceRoutingRuleRequest\x1a,.xraymon.commands.ReplaceRoutingRuleResponse\x12l\n" +
+	"\x11DeleteRoutingRule\x12*.xraymon.commands.DeleteRoutingRuleRequest\x1a+.xraymon.commands.DeleteRoutingRuleResponse\x12W\n" +
+	"\n" +
+	"MatchRoute\x12#.xraymon.commands.MatchRouteRequest\x1a$.xraymon.commands.MatchRouteResponse2\x90\x03\n" +
 	"\x0fJournalProvider\x12c\n" +
-	"\x11ConnectionJournal\x12*.xraymon.commands.ConnectionJournalRequest\x1a .xraymon.commands.ConnectionMeta0\x01\x12]\n" +
+	"\x11ConnectionJournal\x12*.xraymon.commands.ConnectionJournalRequest\x1a .xraymon.commands.ConnectionMeta0\x01\x12W\n" +
+	"\rStderrJournal\x12&.xraymon.commands.StderrJournalRequest\x1a\x1c.xraymon.commands.StderrLine0\x01\x12]\n" +
 	"\fNetworkStats\x12%.xraymon.commands.NetworkStatsRequest\x1a&.xraymon.commands.NetworkStatsResponse\x12`\n" +
-	"\rRotateJournal\x12&.xraymon.commands.RotateJournalRequest\x1a'.xraymon.commands.RotateJournalResponseB>Z<github.com/eterline/xraymon/internal/interface/grpc/commandsb\x06proto3"
+	"\rRotateJournal\x12&.xraymon.commands.RotateJournalRequest\x1a'.xraymon.commands.RotateJournalResponse2\xc5\x02\n" +
+	"\x12CoreBinaryProvider\x12i\n" +
+	"\x10ListCoreBinaries\x12).xraymon.commands.ListCoreBinariesRequest\x1a*.xraymon.commands.ListCoreBinariesResponse\x12Y\n" +
+	"\x10UploadCoreBinary\x12!.xraymon.commands.CoreBinaryChunk\x1a .xraymon.commands.CoreBinaryInfo(\x01\x12i\n" +
+	"\x10SwitchCoreBinary\x12).xraymon.commands.SwitchCoreBinaryRequest\x1a*.xraymon.commands.SwitchCoreBinaryResponse2q\n" +
+	"\x10ScheduleProvider\x12]\n" +
+	"\fListSchedule\x12%.xraymon.commands.ListScheduleRequest\x1a&.xraymon.commands.ListScheduleResponse2c\n" +
+	"\rEventProvider\x12R\n" +
+	"\vWatchEvents\x12$.xraymon.commands.WatchEventsRequest\x1a\x1b.xraymon.commands.CoreEvent0\x01B>Z<github.com/eterline/xraymon/internal/interface/grpc/commandsb\x06proto3"
 
 var (
 	file_commands_proto_rawDescOnce sync.Once
@@ -937,54 +6257,244 @@ func file_commands_proto_rawDescGZIP() []byte {
 	return file_commands_proto_rawDescData
 }
 
-var file_commands_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_commands_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_commands_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_commands_proto_msgTypes = make([]protoimpl.MessageInfo, 93)
 var file_commands_proto_goTypes = []any{
-	(ConnectionType)(0),              // 0: xraymon.commands.ConnectionType
-	(NetType)(0),                     // 1: xraymon.commands.NetType
-	(*RotateJournalRequest)(nil),     // 2: xraymon.commands.RotateJournalRequest
-	(*RotateJournalResponse)(nil),    // 3: xraymon.commands.RotateJournalResponse
-	(*ConnectionIO)(nil),             // 4: xraymon.commands.ConnectionIO
-	(*StatsMeta)(nil),                // 5: xraymon.commands.StatsMeta
-	(*NetworkStatsResponse)(nil),     // 6: xraymon.commands.NetworkStatsResponse
-	(*NetworkStatsRequest)(nil),      // 7: xraymon.commands.NetworkStatsRequest
-	(*ConnectionJournalRequest)(nil), // 8: xraymon.commands.ConnectionJournalRequest
-	(*ConnectionMeta)(nil),           // 9: xraymon.commands.ConnectionMeta
-	(*CoreStatusRequest)(nil),        // 10: xraymon.commands.CoreStatusRequest
-	(*CoreStatusResponse)(nil),       // 11: xraymon.commands.CoreStatusResponse
-	(*CoreRestartRequest)(nil),       // 12: xraymon.commands.CoreRestartRequest
-	(*CoreRestartResponse)(nil),      // 13: xraymon.commands.CoreRestartResponse
-	(*GetConfigRequest)(nil),         // 14: xraymon.commands.GetConfigRequest
-	(*GetConfigResponse)(nil),        // 15: xraymon.commands.GetConfigResponse
-	(*UploadConfigRequest)(nil),      // 16: xraymon.commands.UploadConfigRequest
-	(*UploadConfigResponse)(nil),     // 17: xraymon.commands.UploadConfigResponse
-	(*durationpb.Duration)(nil),      // 18: google.protobuf.Duration
+	(EventType)(0),                      // 0: xraymon.commands.EventType
+	(ConnectionType)(0),                 // 1: xraymon.commands.ConnectionType
+	(NetType)(0),                        // 2: xraymon.commands.NetType
+	(CoreState)(0),                      // 3: xraymon.commands.CoreState
+	(ConfigChangeOp)(0),                 // 4: xraymon.commands.ConfigChangeOp
+	(*WatchEventsRequest)(nil),          // 5: xraymon.commands.WatchEventsRequest
+	(*CoreEvent)(nil),                   // 6: xraymon.commands.CoreEvent
+	(*RotateJournalRequest)(nil),        // 7: xraymon.commands.RotateJournalRequest
+	(*RotateJournalResponse)(nil),       // 8: xraymon.commands.RotateJournalResponse
+	(*ConnectionIO)(nil),                // 9: xraymon.commands.ConnectionIO
+	(*StatsMeta)(nil),                   // 10: xraymon.commands.StatsMeta
+	(*NetworkStatsResponse)(nil),        // 11: xraymon.commands.NetworkStatsResponse
+	(*NetworkStatsRequest)(nil),         // 12: xraymon.commands.NetworkStatsRequest
+	(*ConnectionJournalRequest)(nil),    // 13: xraymon.commands.ConnectionJournalRequest
+	(*StderrJournalRequest)(nil),        // 14: xraymon.commands.StderrJournalRequest
+	(*StderrLine)(nil),                  // 15: xraymon.commands.StderrLine
+	(*ConnectionMeta)(nil),              // 16: xraymon.commands.ConnectionMeta
+	(*ListInstancesRequest)(nil),        // 17: xraymon.commands.ListInstancesRequest
+	(*InstanceInfo)(nil),                // 18: xraymon.commands.InstanceInfo
+	(*ListInstancesResponse)(nil),       // 19: xraymon.commands.ListInstancesResponse
+	(*CoreStatusRequest)(nil),           // 20: xraymon.commands.CoreStatusRequest
+	(*CoreStatusResponse)(nil),          // 21: xraymon.commands.CoreStatusResponse
+	(*ProcessMetrics)(nil),              // 22: xraymon.commands.ProcessMetrics
+	(*WatchCoreMetricsRequest)(nil),     // 23: xraymon.commands.WatchCoreMetricsRequest
+	(*CoreMetrics)(nil),                 // 24: xraymon.commands.CoreMetrics
+	(*CoreRestartRequest)(nil),          // 25: xraymon.commands.CoreRestartRequest
+	(*CoreRestartResponse)(nil),         // 26: xraymon.commands.CoreRestartResponse
+	(*CoreStartRequest)(nil),            // 27: xraymon.commands.CoreStartRequest
+	(*CoreStartResponse)(nil),           // 28: xraymon.commands.CoreStartResponse
+	(*CoreStopRequest)(nil),             // 29: xraymon.commands.CoreStopRequest
+	(*CoreStopResponse)(nil),            // 30: xraymon.commands.CoreStopResponse
+	(*GetConfigRequest)(nil),            // 31: xraymon.commands.GetConfigRequest
+	(*GetConfigResponse)(nil),           // 32: xraymon.commands.GetConfigResponse
+	(*UploadConfigRequest)(nil),         // 33: xraymon.commands.UploadConfigRequest
+	(*UploadConfigResponse)(nil),        // 34: xraymon.commands.UploadConfigResponse
+	(*ConfigRevision)(nil),              // 35: xraymon.commands.ConfigRevision
+	(*ListConfigRevisionsRequest)(nil),  // 36: xraymon.commands.ListConfigRevisionsRequest
+	(*ListConfigRevisionsResponse)(nil), // 37: xraymon.commands.ListConfigRevisionsResponse
+	(*GetConfigRevisionRequest)(nil),    // 38: xraymon.commands.GetConfigRevisionRequest
+	(*GetConfigRevisionResponse)(nil),   // 39: xraymon.commands.GetConfigRevisionResponse
+	(*DiffConfigRevisionsRequest)(nil),  // 40: xraymon.commands.DiffConfigRevisionsRequest
+	(*ConfigChange)(nil),                // 41: xraymon.commands.ConfigChange
+	(*DiffConfigRevisionsResponse)(nil), // 42: xraymon.commands.DiffConfigRevisionsResponse
+	(*RollbackConfigRequest)(nil),       // 43: xraymon.commands.RollbackConfigRequest
+	(*RollbackConfigResponse)(nil),      // 44: xraymon.commands.RollbackConfigResponse
+	(*Inbound)(nil),                     // 45: xraymon.commands.Inbound
+	(*ListInboundsRequest)(nil),         // 46: xraymon.commands.ListInboundsRequest
+	(*ListInboundsResponse)(nil),        // 47: xraymon.commands.ListInboundsResponse
+	(*GetInboundRequest)(nil),           // 48: xraymon.commands.GetInboundRequest
+	(*PutInboundRequest)(nil),           // 49: xraymon.commands.PutInboundRequest
+	(*PutInboundResponse)(nil),          // 50: xraymon.commands.PutInboundResponse
+	(*DeleteInboundRequest)(nil),        // 51: xraymon.commands.DeleteInboundRequest
+	(*DeleteInboundResponse)(nil),       // 52: xraymon.commands.DeleteInboundResponse
+	(*Outbound)(nil),                    // 53: xraymon.commands.Outbound
+	(*ListOutboundsRequest)(nil),        // 54: xraymon.commands.ListOutboundsRequest
+	(*ListOutboundsResponse)(nil),       // 55: xraymon.commands.ListOutboundsResponse
+	(*GetOutboundRequest)(nil),          // 56: xraymon.commands.GetOutboundRequest
+	(*PutOutboundRequest)(nil),          // 57: xraymon.commands.PutOutboundRequest
+	(*PutOutboundResponse)(nil),         // 58: xraymon.commands.PutOutboundResponse
+	(*DeleteOutboundRequest)(nil),       // 59: xraymon.commands.DeleteOutboundRequest
+	(*DeleteOutboundResponse)(nil),      // 60: xraymon.commands.DeleteOutboundResponse
+	(*Balancer)(nil),                    // 61: xraymon.commands.Balancer
+	(*ListBalancersRequest)(nil),        // 62: xraymon.commands.ListBalancersRequest
+	(*ListBalancersResponse)(nil),       // 63: xraymon.commands.ListBalancersResponse
+	(*GetBalancerRequest)(nil),          // 64: xraymon.commands.GetBalancerRequest
+	(*PutBalancerRequest)(nil),          // 65: xraymon.commands.PutBalancerRequest
+	(*PutBalancerResponse)(nil),         // 66: xraymon.commands.PutBalancerResponse
+	(*DeleteBalancerRequest)(nil),       // 67: xraymon.commands.DeleteBalancerRequest
+	(*DeleteBalancerResponse)(nil),      // 68: xraymon.commands.DeleteBalancerResponse
+	(*RoutingRule)(nil),                 // 69: xraymon.commands.RoutingRule
+	(*ListRoutingRulesRequest)(nil),     // 70: xraymon.commands.ListRoutingRulesRequest
+	(*ListRoutingRulesResponse)(nil),    // 71: xraymon.commands.ListRoutingRulesResponse
+	(*InsertRoutingRuleRequest)(nil),    // 72: xraymon.commands.InsertRoutingRuleRequest
+	(*InsertRoutingRuleResponse)(nil),   // 73: xraymon.commands.InsertRoutingRuleResponse
+	(*MoveRoutingRuleRequest)(nil),      // 74: xraymon.commands.MoveRoutingRuleRequest
+	(*MoveRoutingRuleResponse)(nil),     // 75: xraymon.commands.MoveRoutingRuleResponse
+	(*ReplaceRoutingRuleRequest)(nil),   // 76: xraymon.commands.ReplaceRoutingRuleRequest
+	(*ReplaceRoutingRuleResponse)(nil),  // 77: xraymon.commands.ReplaceRoutingRuleResponse
+	(*DeleteRoutingRuleRequest)(nil),    // 78: xraymon.commands.DeleteRoutingRuleRequest
+	(*DeleteRoutingRuleResponse)(nil),   // 79: xraymon.commands.DeleteRoutingRuleResponse
+	(*MatchRouteRequest)(nil),           // 80: xraymon.commands.MatchRouteRequest
+	(*MatchRouteResponse)(nil),          // 81: xraymon.commands.MatchRouteResponse
+	(*CrashHistoryRequest)(nil),         // 82: xraymon.commands.CrashHistoryRequest
+	(*CrashRecord)(nil),                 // 83: xraymon.commands.CrashRecord
+	(*CrashHistoryResponse)(nil),        // 84: xraymon.commands.CrashHistoryResponse
+	(*LogSettings)(nil),                 // 85: xraymon.commands.LogSettings
+	(*GetLogSettingsRequest)(nil),       // 86: xraymon.commands.GetLogSettingsRequest
+	(*SetLogSettingsRequest)(nil),       // 87: xraymon.commands.SetLogSettingsRequest
+	(*SetLogSettingsResponse)(nil),      // 88: xraymon.commands.SetLogSettingsResponse
+	(*CoreBinaryInfo)(nil),              // 89: xraymon.commands.CoreBinaryInfo
+	(*ListCoreBinariesRequest)(nil),     // 90: xraymon.commands.ListCoreBinariesRequest
+	(*ListCoreBinariesResponse)(nil),    // 91: xraymon.commands.ListCoreBinariesResponse
+	(*CoreBinaryChunk)(nil),             // 92: xraymon.commands.CoreBinaryChunk
+	(*SwitchCoreBinaryRequest)(nil),     // 93: xraymon.commands.SwitchCoreBinaryRequest
+	(*SwitchCoreBinaryResponse)(nil),    // 94: xraymon.commands.SwitchCoreBinaryResponse
+	(*ListScheduleRequest)(nil),         // 95: xraymon.commands.ListScheduleRequest
+	(*ScheduledJob)(nil),                // 96: xraymon.commands.ScheduledJob
+	(*ListScheduleResponse)(nil),        // 97: xraymon.commands.ListScheduleResponse
+	(*timestamppb.Timestamp)(nil),       // 98: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),         // 99: google.protobuf.Duration
 }
 var file_commands_proto_depIdxs = []int32{
-	0,  // 0: xraymon.commands.StatsMeta.type:type_name -> xraymon.commands.ConnectionType
-	4,  // 1: xraymon.commands.StatsMeta.io:type_name -> xraymon.commands.ConnectionIO
-	5,  // 2: xraymon.commands.NetworkStatsResponse.stats:type_name -> xraymon.commands.StatsMeta
-	1,  // 3: xraymon.commands.ConnectionMeta.proto:type_name -> xraymon.commands.NetType
-	18, // 4: xraymon.commands.CoreStatusResponse.working_time:type_name -> google.protobuf.Duration
-	10, // 5: xraymon.commands.CoreManagmentService.CoreStatus:input_type -> xraymon.commands.CoreStatusRequest
-	12, // 6: xraymon.commands.CoreManagmentService.CoreRestart:input_type -> xraymon.commands.CoreRestartRequest
-	14, // 7: xraymon.commands.CoreManagmentService.GetConfig:input_type -> xraymon.commands.GetConfigRequest
-	16, // 8: xraymon.commands.CoreManagmentService.UploadConfig:input_type -> xraymon.commands.UploadConfigRequest
-	8,  // 9: xraymon.commands.JournalProvider.ConnectionJournal:input_type -> xraymon.commands.ConnectionJournalRequest
-	7,  // 10: xraymon.commands.JournalProvider.NetworkStats:input_type -> xraymon.commands.NetworkStatsRequest
-	2,  // 11: xraymon.commands.JournalProvider.RotateJournal:input_type -> xraymon.commands.RotateJournalRequest
-	11, // 12: xraymon.commands.CoreManagmentService.CoreStatus:output_type -> xraymon.commands.CoreStatusResponse
-	13, // 13: xraymon.commands.CoreManagmentService.CoreRestart:output_type -> xraymon.commands.CoreRestartResponse
-	15, // 14: xraymon.commands.CoreManagmentService.GetConfig:output_type -> xraymon.commands.GetConfigResponse
-	17, // 15: xraymon.commands.CoreManagmentService.UploadConfig:output_type -> xraymon.commands.UploadConfigResponse
-	9,  // 16: xraymon.commands.JournalProvider.ConnectionJournal:output_type -> xraymon.commands.ConnectionMeta
-	6,  // 17: xraymon.commands.JournalProvider.NetworkStats:output_type -> xraymon.commands.NetworkStatsResponse
-	3,  // 18: xraymon.commands.JournalProvider.RotateJournal:output_type -> xraymon.commands.RotateJournalResponse
-	12, // [12:19] is the sub-list for method output_type
-	5,  // [5:12] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	0,  // 0: xraymon.commands.WatchEventsRequest.types:type_name -> xraymon.commands.EventType
+	0,  // 1: xraymon.commands.CoreEvent.type:type_name -> xraymon.commands.EventType
+	98, // 2: xraymon.commands.CoreEvent.time:type_name -> google.protobuf.Timestamp
+	99, // 3: xraymon.commands.CoreEvent.delay:type_name -> google.protobuf.Duration
+	1,  // 4: xraymon.commands.StatsMeta.type:type_name -> xraymon.commands.ConnectionType
+	9,  // 5: xraymon.commands.StatsMeta.io:type_name -> xraymon.commands.ConnectionIO
+	10, // 6: xraymon.commands.NetworkStatsResponse.stats:type_name -> xraymon.commands.StatsMeta
+	98, // 7: xraymon.commands.StderrLine.time:type_name -> google.protobuf.Timestamp
+	2,  // 8: xraymon.commands.ConnectionMeta.proto:type_name -> xraymon.commands.NetType
+	3,  // 9: xraymon.commands.InstanceInfo.state:type_name -> xraymon.commands.CoreState
+	18, // 10: xraymon.commands.ListInstancesResponse.instances:type_name -> xraymon.commands.InstanceInfo
+	99, // 11: xraymon.commands.CoreStatusResponse.working_time:type_name -> google.protobuf.Duration
+	3,  // 12: xraymon.commands.CoreStatusResponse.state:type_name -> xraymon.commands.CoreState
+	98, // 13: xraymon.commands.CoreStatusResponse.last_rollback:type_name -> google.protobuf.Timestamp
+	22, // 14: xraymon.commands.CoreStatusResponse.process:type_name -> xraymon.commands.ProcessMetrics
+	99, // 15: xraymon.commands.CoreStatusResponse.probe_latency:type_name -> google.protobuf.Duration
+	99, // 16: xraymon.commands.ProcessMetrics.cpu_time:type_name -> google.protobuf.Duration
+	99, // 17: xraymon.commands.WatchCoreMetricsRequest.interval:type_name -> google.protobuf.Duration
+	98, // 18: xraymon.commands.CoreMetrics.time:type_name -> google.protobuf.Timestamp
+	3,  // 19: xraymon.commands.CoreMetrics.state:type_name -> xraymon.commands.CoreState
+	22, // 20: xraymon.commands.CoreMetrics.process:type_name -> xraymon.commands.ProcessMetrics
+	41, // 21: xraymon.commands.UploadConfigResponse.changes:type_name -> xraymon.commands.ConfigChange
+	98, // 22: xraymon.commands.ConfigRevision.time:type_name -> google.protobuf.Timestamp
+	35, // 23: xraymon.commands.ListConfigRevisionsResponse.revisions:type_name -> xraymon.commands.ConfigRevision
+	35, // 24: xraymon.commands.GetConfigRevisionResponse.revision:type_name -> xraymon.commands.ConfigRevision
+	4,  // 25: xraymon.commands.ConfigChange.op:type_name -> xraymon.commands.ConfigChangeOp
+	41, // 26: xraymon.commands.DiffConfigRevisionsResponse.changes:type_name -> xraymon.commands.ConfigChange
+	35, // 27: xraymon.commands.RollbackConfigResponse.revision:type_name -> xraymon.commands.ConfigRevision
+	45, // 28: xraymon.commands.ListInboundsResponse.inbounds:type_name -> xraymon.commands.Inbound
+	53, // 29: xraymon.commands.ListOutboundsResponse.outbounds:type_name -> xraymon.commands.Outbound
+	61, // 30: xraymon.commands.ListBalancersResponse.balancers:type_name -> xraymon.commands.Balancer
+	69, // 31: xraymon.commands.ListRoutingRulesResponse.rules:type_name -> xraymon.commands.RoutingRule
+	69, // 32: xraymon.commands.MatchRouteResponse.rule:type_name -> xraymon.commands.RoutingRule
+	69, // 33: xraymon.commands.MatchRouteResponse.undetermined:type_name -> xraymon.commands.RoutingRule
+	98, // 34: xraymon.commands.CrashRecord.time:type_name -> google.protobuf.Timestamp
+	99, // 35: xraymon.commands.CrashRecord.uptime:type_name -> google.protobuf.Duration
+	83, // 36: xraymon.commands.CrashHistoryResponse.records:type_name -> xraymon.commands.CrashRecord
+	85, // 37: xraymon.commands.SetLogSettingsRequest.settings:type_name -> xraymon.commands.LogSettings
+	98, // 38: xraymon.commands.CoreBinaryInfo.modified:type_name -> google.protobuf.Timestamp
+	89, // 39: xraymon.commands.ListCoreBinariesResponse.binaries:type_name -> xraymon.commands.CoreBinaryInfo
+	99, // 40: xraymon.commands.ScheduledJob.window_duration:type_name -> google.protobuf.Duration
+	98, // 41: xraymon.commands.ScheduledJob.next_run:type_name -> google.protobuf.Timestamp
+	98, // 42: xraymon.commands.ScheduledJob.last_run:type_name -> google.protobuf.Timestamp
+	96, // 43: xraymon.commands.ListScheduleResponse.jobs:type_name -> xraymon.commands.ScheduledJob
+	17, // 44: xraymon.commands.CoreManagmentService.ListInstances:input_type -> xraymon.commands.ListInstancesRequest
+	20, // 45: xraymon.commands.CoreManagmentService.CoreStatus:input_type -> xraymon.commands.CoreStatusRequest
+	25, // 46: xraymon.commands.CoreManagmentService.CoreRestart:input_type -> xraymon.commands.CoreRestartRequest
+	27, // 47: xraymon.commands.CoreManagmentService.CoreStart:input_type -> xraymon.commands.CoreStartRequest
+	29, // 48: xraymon.commands.CoreManagmentService.CoreStop:input_type -> xraymon.commands.CoreStopRequest
+	31, // 49: xraymon.commands.CoreManagmentService.GetConfig:input_type -> xraymon.commands.GetConfigRequest
+	33, // 50: xraymon.commands.CoreManagmentService.UploadConfig:input_type -> xraymon.commands.UploadConfigRequest
+	23, // 51: xraymon.commands.CoreManagmentService.WatchCoreMetrics:input_type -> xraymon.commands.WatchCoreMetricsRequest
+	82, // 52: xraymon.commands.CoreManagmentService.CrashHistory:input_type -> xraymon.commands.CrashHistoryRequest
+	86, // 53: xraymon.commands.CoreManagmentService.GetLogSettings:input_type -> xraymon.commands.GetLogSettingsRequest
+	87, // 54: xraymon.commands.CoreManagmentService.SetLogSettings:input_type -> xraymon.commands.SetLogSettingsRequest
+	36, // 55: xraymon.commands.CoreManagmentService.ListConfigRevisions:input_type -> xraymon.commands.ListConfigRevisionsRequest
+	38, // 56: xraymon.commands.CoreManagmentService.GetConfigRevision:input_type -> xraymon.commands.GetConfigRevisionRequest
+	40, // 57: xraymon.commands.CoreManagmentService.DiffConfigRevisions:input_type -> xraymon.commands.DiffConfigRevisionsRequest
+	43, // 58: xraymon.commands.CoreManagmentService.RollbackConfig:input_type -> xraymon.commands.RollbackConfigRequest
+	46, // 59: xraymon.commands.CoreManagmentService.ListInbounds:input_type -> xraymon.commands.ListInboundsRequest
+	48, // 60: xraymon.commands.CoreManagmentService.GetInbound:input_type -> xraymon.commands.GetInboundRequest
+	49, // 61: xraymon.commands.CoreManagmentService.PutInbound:input_type -> xraymon.commands.PutInboundRequest
+	51, // 62: xraymon.commands.CoreManagmentService.DeleteInbound:input_type -> xraymon.commands.DeleteInboundRequest
+	54, // 63: xraymon.commands.CoreManagmentService.ListOutbounds:input_type -> xraymon.commands.ListOutboundsRequest
+	56, // 64: xraymon.commands.CoreManagmentService.GetOutbound:input_type -> xraymon.commands.GetOutboundRequest
+	57, // 65: xraymon.commands.CoreManagmentService.PutOutbound:input_type -> xraymon.commands.PutOutboundRequest
+	59, // 66: xraymon.commands.CoreManagmentService.DeleteOutbound:input_type -> xraymon.commands.DeleteOutboundRequest
+	62, // 67: xraymon.commands.CoreManagmentService.ListBalancers:input_type -> xraymon.commands.ListBalancersRequest
+	64, // 68: xraymon.commands.CoreManagmentService.GetBalancer:input_type -> xraymon.commands.GetBalancerRequest
+	65, // 69: xraymon.commands.CoreManagmentService.PutBalancer:input_type -> xraymon.commands.PutBalancerRequest
+	67, // 70: xraymon.commands.CoreManagmentService.DeleteBalancer:input_type -> xraymon.commands.DeleteBalancerRequest
+	70, // 71: xraymon.commands.CoreManagmentService.ListRoutingRules:input_type -> xraymon.commands.ListRoutingRulesRequest
+	72, // 72: xraymon.commands.CoreManagmentService.InsertRoutingRule:input_type -> xraymon.commands.InsertRoutingRuleRequest
+	74, // 73: xraymon.commands.CoreManagmentService.MoveRoutingRule:input_type -> xraymon.commands.MoveRoutingRuleRequest
+	76, // 74: xraymon.commands.CoreManagmentService.ReplaceRoutingRule:input_type -> xraymon.commands.ReplaceRoutingRuleRequest
+	78, // 75: xraymon.commands.CoreManagmentService.DeleteRoutingRule:input_type -> xraymon.commands.DeleteRoutingRuleRequest
+	80, // 76: xraymon.commands.CoreManagmentService.MatchRoute:input_type -> xraymon.commands.MatchRouteRequest
+	13, // 77: xraymon.commands.JournalProvider.ConnectionJournal:input_type -> xraymon.commands.ConnectionJournalRequest
+	14, // 78: xraymon.commands.JournalProvider.StderrJournal:input_type -> xraymon.commands.StderrJournalRequest
+	12, // 79: xraymon.commands.JournalProvider.NetworkStats:input_type -> xraymon.commands.NetworkStatsRequest
+	7,  // 80: xraymon.commands.JournalProvider.RotateJournal:input_type -> xraymon.commands.RotateJournalRequest
+	90, // 81: xraymon.commands.CoreBinaryProvider.ListCoreBinaries:input_type -> xraymon.commands.ListCoreBinariesRequest
+	92, // 82: xraymon.commands.CoreBinaryProvider.UploadCoreBinary:input_type -> xraymon.commands.CoreBinaryChunk
+	93, // 83: xraymon.commands.CoreBinaryProvider.SwitchCoreBinary:input_type -> xraymon.commands.SwitchCoreBinaryRequest
+	95, // 84: xraymon.commands.ScheduleProvider.ListSchedule:input_type -> xraymon.commands.ListScheduleRequest
+	5,  // 85: xraymon.commands.EventProvider.WatchEvents:input_type -> xraymon.commands.WatchEventsRequest
+	19, // 86: xraymon.commands.CoreManagmentService.ListInstances:output_type -> xraymon.commands.ListInstancesResponse
+	21, // 87: xraymon.commands.CoreManagmentService.CoreStatus:output_type -> xraymon.commands.CoreStatusResponse
+	26, // 88: xraymon.commands.CoreManagmentService.CoreRestart:output_type -> xraymon.commands.CoreRestartResponse
+	28, // 89: xraymon.commands.CoreManagmentService.CoreStart:output_type -> xraymon.commands.CoreStartResponse
+	30, // 90: xraymon.commands.CoreManagmentService.CoreStop:output_type -> xraymon.commands.CoreStopResponse
+	32, // 91: xraymon.commands.CoreManagmentService.GetConfig:output_type -> xraymon.commands.GetConfigResponse
+	34, // 92: xraymon.commands.CoreManagmentService.UploadConfig:output_type -> xraymon.commands.UploadConfigResponse
+	24, // 93: xraymon.commands.CoreManagmentService.WatchCoreMetrics:output_type -> xraymon.commands.CoreMetrics
+	84, // 94: xraymon.commands.CoreManagmentService.CrashHistory:output_type -> xraymon.commands.CrashHistoryResponse
+	85, // 95: xraymon.commands.CoreManagmentService.GetLogSettings:output_type -> xraymon.commands.LogSettings
+	88, // 96: xraymon.commands.CoreManagmentService.SetLogSettings:output_type -> xraymon.commands.SetLogSettingsResponse
+	37, // 97: xraymon.commands.CoreManagmentService.ListConfigRevisions:output_type -> xraymon.commands.ListConfigRevisionsResponse
+	39, // 98: xraymon.commands.CoreManagmentService.GetConfigRevision:output_type -> xraymon.commands.GetConfigRevisionResponse
+	42, // 99: xraymon.commands.CoreManagmentService.DiffConfigRevisions:output_type -> xraymon.commands.DiffConfigRevisionsResponse
+	44, // 100: xraymon.commands.CoreManagmentService.RollbackConfig:output_type -> xraymon.commands.RollbackConfigResponse
+	47, // 101: xraymon.commands.CoreManagmentService.ListInbounds:output_type -> xraymon.commands.ListInboundsResponse
+	45, // 102: xraymon.commands.CoreManagmentService.GetInbound:output_type -> xraymon.commands.Inbound
+	50, // 103: xraymon.commands.CoreManagmentService.PutInbound:output_type -> xraymon.commands.PutInboundResponse
+	52, // 104: xraymon.commands.CoreManagmentService.DeleteInbound:output_type -> xraymon.commands.DeleteInboundResponse
+	55, // 105: xraymon.commands.CoreManagmentService.ListOutbounds:output_type -> xraymon.commands.ListOutboundsResponse
+	53, // 106: xraymon.commands.CoreManagmentService.GetOutbound:output_type -> xraymon.commands.Outbound
+	58, // 107: xraymon.commands.CoreManagmentService.PutOutbound:output_type -> xraymon.commands.PutOutboundResponse
+	60, // 108: xraymon.commands.CoreManagmentService.DeleteOutbound:output_type -> xraymon.commands.DeleteOutboundResponse
+	63, // 109: xraymon.commands.CoreManagmentService.ListBalancers:output_type -> xraymon.commands.ListBalancersResponse
+	61, // 110: xraymon.commands.CoreManagmentService.GetBalancer:output_type -> xraymon.commands.Balancer
+	66, // 111: xraymon.commands.CoreManagmentService.PutBalancer:output_type -> xraymon.commands.PutBalancerResponse
+	68, // 112: xraymon.commands.CoreManagmentService.DeleteBalancer:output_type -> xraymon.commands.DeleteBalancerResponse
+	71, // 113: xraymon.commands.CoreManagmentService.ListRoutingRules:output_type -> xraymon.commands.ListRoutingRulesResponse
+	73, // 114: xraymon.commands.CoreManagmentService.InsertRoutingRule:output_type -> xraymon.commands.InsertRoutingRuleResponse
+	75, // 115: xraymon.commands.CoreManagmentService.MoveRoutingRule:output_type -> xraymon.commands.MoveRoutingRuleResponse
+	77, // 116: xraymon.commands.CoreManagmentService.ReplaceRoutingRule:output_type -> xraymon.commands.ReplaceRoutingRuleResponse
+	79, // 117: xraymon.commands.CoreManagmentService.DeleteRoutingRule:output_type -> xraymon.commands.DeleteRoutingRuleResponse
+	81, // 118: xraymon.commands.CoreManagmentService.MatchRoute:output_type -> xraymon.commands.MatchRouteResponse
+	16, // 119: xraymon.commands.JournalProvider.ConnectionJournal:output_type -> xraymon.commands.ConnectionMeta
+	15, // 120: xraymon.commands.JournalProvider.StderrJournal:output_type -> xraymon.commands.StderrLine
+	11, // 121: xraymon.commands.JournalProvider.NetworkStats:output_type -> xraymon.commands.NetworkStatsResponse
+	8,  // 122: xraymon.commands.JournalProvider.RotateJournal:output_type -> xraymon.commands.RotateJournalResponse
+	91, // 123: xraymon.commands.CoreBinaryProvider.ListCoreBinaries:output_type -> xraymon.commands.ListCoreBinariesResponse
+	89, // 124: xraymon.commands.CoreBinaryProvider.UploadCoreBinary:output_type -> xraymon.commands.CoreBinaryInfo
+	94, // 125: xraymon.commands.CoreBinaryProvider.SwitchCoreBinary:output_type -> xraymon.commands.SwitchCoreBinaryResponse
+	97, // 126: xraymon.commands.ScheduleProvider.ListSchedule:output_type -> xraymon.commands.ListScheduleResponse
+	6,  // 127: xraymon.commands.EventProvider.WatchEvents:output_type -> xraymon.commands.CoreEvent
+	86, // [86:128] is the sub-list for method output_type
+	44, // [44:86] is the sub-list for method input_type
+	44, // [44:44] is the sub-list for extension type_name
+	44, // [44:44] is the sub-list for extension extendee
+	0,  // [0:44] is the sub-list for field type_name
 }
 
 func init() { file_commands_proto_init() }
@@ -997,10 +6507,10 @@ func file_commands_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_commands_proto_rawDesc), len(file_commands_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   16,
+			NumEnums:      5,
+			NumMessages:   93,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   5,
 		},
 		GoTypes:           file_commands_proto_goTypes,
 		DependencyIndexes: file_commands_proto_depIdxs,
@@ -19,20 +19,78 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CoreManagmentService_CoreStatus_FullMethodName   = "/xraymon.commands.CoreManagmentService/CoreStatus"
-	CoreManagmentService_CoreRestart_FullMethodName  = "/xraymon.commands.CoreManagmentService/CoreRestart"
-	CoreManagmentService_GetConfig_FullMethodName    = "/xraymon.commands.CoreManagmentService/GetConfig"
-	CoreManagmentService_UploadConfig_FullMethodName = "/xraymon.commands.CoreManagmentService/UploadConfig"
+	CoreManagmentService_ListInstances_FullMethodName       = "/xraymon.commands.CoreManagmentService/ListInstances"
+	CoreManagmentService_CoreStatus_FullMethodName          = "/xraymon.commands.CoreManagmentService/CoreStatus"
+	CoreManagmentService_CoreRestart_FullMethodName         = "/xraymon.commands.CoreManagmentService/CoreRestart"
+	CoreManagmentService_CoreStart_FullMethodName           = "/xraymon.commands.CoreManagmentService/CoreStart"
+	CoreManagmentService_CoreStop_FullMethodName            = "/xraymon.commands.CoreManagmentService/CoreStop"
+	CoreManagmentService_GetConfig_FullMethodName           = "/xraymon.commands.CoreManagmentService/GetConfig"
+	CoreManagmentService_UploadConfig_FullMethodName        = "/xraymon.commands.CoreManagmentService/UploadConfig"
+	CoreManagmentService_WatchCoreMetrics_FullMethodName    = "/xraymon.commands.CoreManagmentService/WatchCoreMetrics"
+	CoreManagmentService_CrashHistory_FullMethodName        = "/xraymon.commands.CoreManagmentService/CrashHistory"
+	CoreManagmentService_GetLogSettings_FullMethodName      = "/xraymon.commands.CoreManagmentService/GetLogSettings"
+	CoreManagmentService_SetLogSettings_FullMethodName      = "/xraymon.commands.CoreManagmentService/SetLogSettings"
+	CoreManagmentService_ListConfigRevisions_FullMethodName = "/xraymon.commands.CoreManagmentService/ListConfigRevisions"
+	CoreManagmentService_GetConfigRevision_FullMethodName   = "/xraymon.commands.CoreManagmentService/GetConfigRevision"
+	CoreManagmentService_DiffConfigRevisions_FullMethodName = "/xraymon.commands.CoreManagmentService/DiffConfigRevisions"
+	CoreManagmentService_RollbackConfig_FullMethodName      = "/xraymon.commands.CoreManagmentService/RollbackConfig"
+	CoreManagmentService_ListInbounds_FullMethodName        = "/xraymon.commands.CoreManagmentService/ListInbounds"
+	CoreManagmentService_GetInbound_FullMethodName          = "/xraymon.commands.CoreManagmentService/GetInbound"
+	CoreManagmentService_PutInbound_FullMethodName          = "/xraymon.commands.CoreManagmentService/PutInbound"
+	CoreManagmentService_DeleteInbound_FullMethodName       = "/xraymon.commands.CoreManagmentService/DeleteInbound"
+	CoreManagmentService_ListOutbounds_FullMethodName       = "/xraymon.commands.CoreManagmentService/ListOutbounds"
+	CoreManagmentService_GetOutbound_FullMethodName         = "/xraymon.commands.CoreManagmentService/GetOutbound"
+	CoreManagmentService_PutOutbound_FullMethodName         = "/xraymon.commands.CoreManagmentService/PutOutbound"
+	CoreManagmentService_DeleteOutbound_FullMethodName      = "/xraymon.commands.CoreManagmentService/DeleteOutbound"
+	CoreManagmentService_ListBalancers_FullMethodName       = "/xraymon.commands.CoreManagmentService/ListBalancers"
+	CoreManagmentService_GetBalancer_FullMethodName         = "/xraymon.commands.CoreManagmentService/GetBalancer"
+	CoreManagmentService_PutBalancer_FullMethodName         = "/xraymon.commands.CoreManagmentService/PutBalancer"
+	CoreManagmentService_DeleteBalancer_FullMethodName      = "/xraymon.commands.CoreManagmentService/DeleteBalancer"
+	CoreManagmentService_ListRoutingRules_FullMethodName    = "/xraymon.commands.CoreManagmentService/ListRoutingRules"
+	CoreManagmentService_InsertRoutingRule_FullMethodName   = "/xraymon.commands.CoreManagmentService/InsertRoutingRule"
+	CoreManagmentService_MoveRoutingRule_FullMethodName     = "/xraymon.commands.CoreManagmentService/MoveRoutingRule"
+	CoreManagmentService_ReplaceRoutingRule_FullMethodName  = "/xraymon.commands.CoreManagmentService/ReplaceRoutingRule"
+	CoreManagmentService_DeleteRoutingRule_FullMethodName   = "/xraymon.commands.CoreManagmentService/DeleteRoutingRule"
+	CoreManagmentService_MatchRoute_FullMethodName          = "/xraymon.commands.CoreManagmentService/MatchRoute"
 )
 
 // CoreManagmentServiceClient is the client API for CoreManagmentService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CoreManagmentServiceClient interface {
+	ListInstances(ctx context.Context, in *ListInstancesRequest, opts ...grpc.CallOption) (*ListInstancesResponse, error)
 	CoreStatus(ctx context.Context, in *CoreStatusRequest, opts ...grpc.CallOption) (*CoreStatusResponse, error)
 	CoreRestart(ctx context.Context, in *CoreRestartRequest, opts ...grpc.CallOption) (*CoreRestartResponse, error)
+	CoreStart(ctx context.Context, in *CoreStartRequest, opts ...grpc.CallOption) (*CoreStartResponse, error)
+	CoreStop(ctx context.Context, in *CoreStopRequest, opts ...grpc.CallOption) (*CoreStopResponse, error)
 	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error)
 	UploadConfig(ctx context.Context, in *UploadConfigRequest, opts ...grpc.CallOption) (*UploadConfigResponse, error)
+	WatchCoreMetrics(ctx context.Context, in *WatchCoreMetricsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CoreMetrics], error)
+	CrashHistory(ctx context.Context, in *CrashHistoryRequest, opts ...grpc.CallOption) (*CrashHistoryResponse, error)
+	GetLogSettings(ctx context.Context, in *GetLogSettingsRequest, opts ...grpc.CallOption) (*LogSettings, error)
+	SetLogSettings(ctx context.Context, in *SetLogSettingsRequest, opts ...grpc.CallOption) (*SetLogSettingsResponse, error)
+	ListConfigRevisions(ctx context.Context, in *ListConfigRevisionsRequest, opts ...grpc.CallOption) (*ListConfigRevisionsResponse, error)
+	GetConfigRevision(ctx context.Context, in *GetConfigRevisionRequest, opts ...grpc.CallOption) (*GetConfigRevisionResponse, error)
+	DiffConfigRevisions(ctx context.Context, in *DiffConfigRevisionsRequest, opts ...grpc.CallOption) (*DiffConfigRevisionsResponse, error)
+	RollbackConfig(ctx context.Context, in *RollbackConfigRequest, opts ...grpc.CallOption) (*RollbackConfigResponse, error)
+	ListInbounds(ctx context.Context, in *ListInboundsRequest, opts ...grpc.CallOption) (*ListInboundsResponse, error)
+	GetInbound(ctx context.Context, in *GetInboundRequest, opts ...grpc.CallOption) (*Inbound, error)
+	PutInbound(ctx context.Context, in *PutInboundRequest, opts ...grpc.CallOption) (*PutInboundResponse, error)
+	DeleteInbound(ctx context.Context, in *DeleteInboundRequest, opts ...grpc.CallOption) (*DeleteInboundResponse, error)
+	ListOutbounds(ctx context.Context, in *ListOutboundsRequest, opts ...grpc.CallOption) (*ListOutboundsResponse, error)
+	GetOutbound(ctx context.Context, in *GetOutboundRequest, opts ...grpc.CallOption) (*Outbound, error)
+	PutOutbound(ctx context.Context, in *PutOutboundRequest, opts ...grpc.CallOption) (*PutOutboundResponse, error)
+	DeleteOutbound(ctx context.Context, in *DeleteOutboundRequest, opts ...grpc.CallOption) (*DeleteOutboundResponse, error)
+	ListBalancers(ctx context.Context, in *ListBalancersRequest, opts ...grpc.CallOption) (*ListBalancersResponse, error)
+	GetBalancer(ctx context.Context, in *GetBalancerRequest, opts ...grpc.CallOption) (*Balancer, error)
+	PutBalancer(ctx context.Context, in *PutBalancerRequest, opts ...grpc.CallOption) (*PutBalancerResponse, error)
+	DeleteBalancer(ctx context.Context, in *DeleteBalancerRequest, opts ...grpc.CallOption) (*DeleteBalancerResponse, error)
+	ListRoutingRules(ctx context.Context, in *ListRoutingRulesRequest, opts ...grpc.CallOption) (*ListRoutingRulesResponse, error)
+	InsertRoutingRule(ctx context.Context, in *InsertRoutingRuleRequest, opts ...grpc.CallOption) (*InsertRoutingRuleResponse, error)
+	MoveRoutingRule(ctx context.Context, in *MoveRoutingRuleRequest, opts ...grpc.CallOption) (*MoveRoutingRuleResponse, error)
+	ReplaceRoutingRule(ctx context.Context, in *ReplaceRoutingRuleRequest, opts ...grpc.CallOption) (*ReplaceRoutingRuleResponse, error)
+	DeleteRoutingRule(ctx context.Context, in *DeleteRoutingRuleRequest, opts ...grpc.CallOption) (*DeleteRoutingRuleResponse, error)
+	MatchRoute(ctx context.Context, in *MatchRouteRequest, opts ...grpc.CallOption) (*MatchRouteResponse, error)
 }
 
 type coreManagmentServiceClient struct {
@@ -43,6 +101,16 @@ func NewCoreManagmentServiceClient(cc grpc.ClientConnInterface) CoreManagmentSer
 	return &coreManagmentServiceClient{cc}
 }
 
+func (c *coreManagmentServiceClient) ListInstances(ctx context.Context, in *ListInstancesRequest, opts ...grpc.CallOption) (*ListInstancesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInstancesResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_ListInstances_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *coreManagmentServiceClient) CoreStatus(ctx context.Context, in *CoreStatusRequest, opts ...grpc.CallOption) (*CoreStatusResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CoreStatusResponse)
@@ -63,171 +131,1706 @@ func (c *coreManagmentServiceClient) CoreRestart(ctx context.Context, in *CoreRe
 	return out, nil
 }
 
-func (c *coreManagmentServiceClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error) {
+func (c *coreManagmentServiceClient) CoreStart(ctx context.Context, in *CoreStartRequest, opts ...grpc.CallOption) (*CoreStartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CoreStartResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_CoreStart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) CoreStop(ctx context.Context, in *CoreStopRequest, opts ...grpc.CallOption) (*CoreStopResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CoreStopResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_CoreStop_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConfigResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_GetConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) UploadConfig(ctx context.Context, in *UploadConfigRequest, opts ...grpc.CallOption) (*UploadConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadConfigResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_UploadConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) WatchCoreMetrics(ctx context.Context, in *WatchCoreMetricsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CoreMetrics], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CoreManagmentService_ServiceDesc.Streams[0], CoreManagmentService_WatchCoreMetrics_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchCoreMetricsRequest, CoreMetrics]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CoreManagmentService_WatchCoreMetricsClient = grpc.ServerStreamingClient[CoreMetrics]
+
+func (c *coreManagmentServiceClient) CrashHistory(ctx context.Context, in *CrashHistoryRequest, opts ...grpc.CallOption) (*CrashHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CrashHistoryResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_CrashHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) GetLogSettings(ctx context.Context, in *GetLogSettingsRequest, opts ...grpc.CallOption) (*LogSettings, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogSettings)
+	err := c.cc.Invoke(ctx, CoreManagmentService_GetLogSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) SetLogSettings(ctx context.Context, in *SetLogSettingsRequest, opts ...grpc.CallOption) (*SetLogSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetLogSettingsResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_SetLogSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) ListConfigRevisions(ctx context.Context, in *ListConfigRevisionsRequest, opts ...grpc.CallOption) (*ListConfigRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListConfigRevisionsResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_ListConfigRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) GetConfigRevision(ctx context.Context, in *GetConfigRevisionRequest, opts ...grpc.CallOption) (*GetConfigRevisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConfigRevisionResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_GetConfigRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) DiffConfigRevisions(ctx context.Context, in *DiffConfigRevisionsRequest, opts ...grpc.CallOption) (*DiffConfigRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffConfigRevisionsResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_DiffConfigRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) RollbackConfig(ctx context.Context, in *RollbackConfigRequest, opts ...grpc.CallOption) (*RollbackConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollbackConfigResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_RollbackConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) ListInbounds(ctx context.Context, in *ListInboundsRequest, opts ...grpc.CallOption) (*ListInboundsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInboundsResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_ListInbounds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) GetInbound(ctx context.Context, in *GetInboundRequest, opts ...grpc.CallOption) (*Inbound, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Inbound)
+	err := c.cc.Invoke(ctx, CoreManagmentService_GetInbound_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) PutInbound(ctx context.Context, in *PutInboundRequest, opts ...grpc.CallOption) (*PutInboundResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PutInboundResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_PutInbound_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) DeleteInbound(ctx context.Context, in *DeleteInboundRequest, opts ...grpc.CallOption) (*DeleteInboundResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteInboundResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_DeleteInbound_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) ListOutbounds(ctx context.Context, in *ListOutboundsRequest, opts ...grpc.CallOption) (*ListOutboundsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOutboundsResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_ListOutbounds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) GetOutbound(ctx context.Context, in *GetOutboundRequest, opts ...grpc.CallOption) (*Outbound, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Outbound)
+	err := c.cc.Invoke(ctx, CoreManagmentService_GetOutbound_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) PutOutbound(ctx context.Context, in *PutOutboundRequest, opts ...grpc.CallOption) (*PutOutboundResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PutOutboundResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_PutOutbound_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) DeleteOutbound(ctx context.Context, in *DeleteOutboundRequest, opts ...grpc.CallOption) (*DeleteOutboundResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteOutboundResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_DeleteOutbound_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) ListBalancers(ctx context.Context, in *ListBalancersRequest, opts ...grpc.CallOption) (*ListBalancersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBalancersResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_ListBalancers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) GetBalancer(ctx context.Context, in *GetBalancerRequest, opts ...grpc.CallOption) (*Balancer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Balancer)
+	err := c.cc.Invoke(ctx, CoreManagmentService_GetBalancer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) PutBalancer(ctx context.Context, in *PutBalancerRequest, opts ...grpc.CallOption) (*PutBalancerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PutBalancerResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_PutBalancer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) DeleteBalancer(ctx context.Context, in *DeleteBalancerRequest, opts ...grpc.CallOption) (*DeleteBalancerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBalancerResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_DeleteBalancer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) ListRoutingRules(ctx context.Context, in *ListRoutingRulesRequest, opts ...grpc.CallOption) (*ListRoutingRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRoutingRulesResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_ListRoutingRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) InsertRoutingRule(ctx context.Context, in *InsertRoutingRuleRequest, opts ...grpc.CallOption) (*InsertRoutingRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InsertRoutingRuleResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_InsertRoutingRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) MoveRoutingRule(ctx context.Context, in *MoveRoutingRuleRequest, opts ...grpc.CallOption) (*MoveRoutingRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveRoutingRuleResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_MoveRoutingRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) ReplaceRoutingRule(ctx context.Context, in *ReplaceRoutingRuleRequest, opts ...grpc.CallOption) (*ReplaceRoutingRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplaceRoutingRuleResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_ReplaceRoutingRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) DeleteRoutingRule(ctx context.Context, in *DeleteRoutingRuleRequest, opts ...grpc.CallOption) (*DeleteRoutingRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRoutingRuleResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_DeleteRoutingRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreManagmentServiceClient) MatchRoute(ctx context.Context, in *MatchRouteRequest, opts ...grpc.CallOption) (*MatchRouteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchRouteResponse)
+	err := c.cc.Invoke(ctx, CoreManagmentService_MatchRoute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CoreManagmentServiceServer is the server API for CoreManagmentService service.
+// All implementations must embed UnimplementedCoreManagmentServiceServer
+// for forward compatibility.
+type CoreManagmentServiceServer interface {
+	ListInstances(context.Context, *ListInstancesRequest) (*ListInstancesResponse, error)
+	CoreStatus(context.Context, *CoreStatusRequest) (*CoreStatusResponse, error)
+	CoreRestart(context.Context, *CoreRestartRequest) (*CoreRestartResponse, error)
+	CoreStart(context.Context, *CoreStartRequest) (*CoreStartResponse, error)
+	CoreStop(context.Context, *CoreStopRequest) (*CoreStopResponse, error)
+	GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error)
+	UploadConfig(context.Context, *UploadConfigRequest) (*UploadConfigResponse, error)
+	WatchCoreMetrics(*WatchCoreMetricsRequest, grpc.ServerStreamingServer[CoreMetrics]) error
+	CrashHistory(context.Context, *CrashHistoryRequest) (*CrashHistoryResponse, error)
+	GetLogSettings(context.Context, *GetLogSettingsRequest) (*LogSettings, error)
+	SetLogSettings(context.Context, *SetLogSettingsRequest) (*SetLogSettingsResponse, error)
+	ListConfigRevisions(context.Context, *ListConfigRevisionsRequest) (*ListConfigRevisionsResponse, error)
+	GetConfigRevision(context.Context, *GetConfigRevisionRequest) (*GetConfigRevisionResponse, error)
+	DiffConfigRevisions(context.Context, *DiffConfigRevisionsRequest) (*DiffConfigRevisionsResponse, error)
+	RollbackConfig(context.Context, *RollbackConfigRequest) (*RollbackConfigResponse, error)
+	ListInbounds(context.Context, *ListInboundsRequest) (*ListInboundsResponse, error)
+	GetInbound(context.Context, *GetInboundRequest) (*Inbound, error)
+	PutInbound(context.Context, *PutInboundRequest) (*PutInboundResponse, error)
+	DeleteInbound(context.Context, *DeleteInboundRequest) (*DeleteInboundResponse, error)
+	ListOutbounds(context.Context, *ListOutboundsRequest) (*ListOutboundsResponse, error)
+	GetOutbound(context.Context, *GetOutboundRequest) (*Outbound, error)
+	PutOutbound(context.Context, *PutOutboundRequest) (*PutOutboundResponse, error)
+	DeleteOutbound(context.Context, *DeleteOutboundRequest) (*DeleteOutboundResponse, error)
+	ListBalancers(context.Context, *ListBalancersRequest) (*ListBalancersResponse, error)
+	GetBalancer(context.Context, *GetBalancerRequest) (*Balancer, error)
+	PutBalancer(context.Context, *PutBalancerRequest) (*PutBalancerResponse, error)
+	DeleteBalancer(context.Context, *DeleteBalancerRequest) (*DeleteBalancerResponse, error)
+	ListRoutingRules(context.Context, *ListRoutingRulesRequest) (*ListRoutingRulesResponse, error)
+	InsertRoutingRule(context.Context, *InsertRoutingRuleRequest) (*InsertRoutingRuleResponse, error)
+	MoveRoutingRule(context.Context, *MoveRoutingRuleRequest) (*MoveRoutingRuleResponse, error)
+	ReplaceRoutingRule(context.Context, *ReplaceRoutingRuleRequest) (*ReplaceRoutingRuleResponse, error)
+	DeleteRoutingRule(context.Context, *DeleteRoutingRuleRequest) (*DeleteRoutingRuleResponse, error)
+	MatchRoute(context.Context, *MatchRouteRequest) (*MatchRouteResponse, error)
+	mustEmbedUnimplementedCoreManagmentServiceServer()
+}
+
+// UnimplementedCoreManagmentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCoreManagmentServiceServer struct{}
+
+func (UnimplementedCoreManagmentServiceServer) ListInstances(context.Context, *ListInstancesRequest) (*ListInstancesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListInstances not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) CoreStatus(context.Context, *CoreStatusRequest) (*CoreStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CoreStatus not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) CoreRestart(context.Context, *CoreRestartRequest) (*CoreRestartResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CoreRestart not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) CoreStart(context.Context, *CoreStartRequest) (*CoreStartResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CoreStart not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) CoreStop(context.Context, *CoreStopRequest) (*CoreStopResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CoreStop not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) UploadConfig(context.Context, *UploadConfigRequest) (*UploadConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UploadConfig not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) WatchCoreMetrics(*WatchCoreMetricsRequest, grpc.ServerStreamingServer[CoreMetrics]) error {
+	return status.Error(codes.Unimplemented, "method WatchCoreMetrics not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) CrashHistory(context.Context, *CrashHistoryRequest) (*CrashHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CrashHistory not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) GetLogSettings(context.Context, *GetLogSettingsRequest) (*LogSettings, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLogSettings not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) SetLogSettings(context.Context, *SetLogSettingsRequest) (*SetLogSettingsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetLogSettings not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) ListConfigRevisions(context.Context, *ListConfigRevisionsRequest) (*ListConfigRevisionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListConfigRevisions not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) GetConfigRevision(context.Context, *GetConfigRevisionRequest) (*GetConfigRevisionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetConfigRevision not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) DiffConfigRevisions(context.Context, *DiffConfigRevisionsRequest) (*DiffConfigRevisionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DiffConfigRevisions not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) RollbackConfig(context.Context, *RollbackConfigRequest) (*RollbackConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RollbackConfig not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) ListInbounds(context.Context, *ListInboundsRequest) (*ListInboundsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListInbounds not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) GetInbound(context.Context, *GetInboundRequest) (*Inbound, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetInbound not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) PutInbound(context.Context, *PutInboundRequest) (*PutInboundResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PutInbound not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) DeleteInbound(context.Context, *DeleteInboundRequest) (*DeleteInboundResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteInbound not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) ListOutbounds(context.Context, *ListOutboundsRequest) (*ListOutboundsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListOutbounds not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) GetOutbound(context.Context, *GetOutboundRequest) (*Outbound, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOutbound not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) PutOutbound(context.Context, *PutOutboundRequest) (*PutOutboundResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PutOutbound not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) DeleteOutbound(context.Context, *DeleteOutboundRequest) (*DeleteOutboundResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteOutbound not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) ListBalancers(context.Context, *ListBalancersRequest) (*ListBalancersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBalancers not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) GetBalancer(context.Context, *GetBalancerRequest) (*Balancer, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBalancer not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) PutBalancer(context.Context, *PutBalancerRequest) (*PutBalancerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PutBalancer not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) DeleteBalancer(context.Context, *DeleteBalancerRequest) (*DeleteBalancerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteBalancer not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) ListRoutingRules(context.Context, *ListRoutingRulesRequest) (*ListRoutingRulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRoutingRules not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) InsertRoutingRule(context.Context, *InsertRoutingRuleRequest) (*InsertRoutingRuleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InsertRoutingRule not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) MoveRoutingRule(context.Context, *MoveRoutingRuleRequest) (*MoveRoutingRuleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MoveRoutingRule not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) ReplaceRoutingRule(context.Context, *ReplaceRoutingRuleRequest) (*ReplaceRoutingRuleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReplaceRoutingRule not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) DeleteRoutingRule(context.Context, *DeleteRoutingRuleRequest) (*DeleteRoutingRuleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteRoutingRule not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) MatchRoute(context.Context, *MatchRouteRequest) (*MatchRouteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MatchRoute not implemented")
+}
+func (UnimplementedCoreManagmentServiceServer) mustEmbedUnimplementedCoreManagmentServiceServer() {}
+func (UnimplementedCoreManagmentServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeCoreManagmentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CoreManagmentServiceServer will
+// result in compilation errors.
+type UnsafeCoreManagmentServiceServer interface {
+	mustEmbedUnimplementedCoreManagmentServiceServer()
+}
+
+func RegisterCoreManagmentServiceServer(s grpc.ServiceRegistrar, srv CoreManagmentServiceServer) {
+	// If the following call panics, it indicates UnimplementedCoreManagmentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CoreManagmentService_ServiceDesc, srv)
+}
+
+func _CoreManagmentService_ListInstances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInstancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).ListInstances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_ListInstances_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).ListInstances(ctx, req.(*ListInstancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_CoreStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CoreStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).CoreStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_CoreStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).CoreStatus(ctx, req.(*CoreStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_CoreRestart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CoreRestartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).CoreRestart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_CoreRestart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).CoreRestart(ctx, req.(*CoreRestartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_CoreStart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CoreStartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).CoreStart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_CoreStart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).CoreStart(ctx, req.(*CoreStartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_CoreStop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CoreStopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).CoreStop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_CoreStop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).CoreStop(ctx, req.(*CoreStopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_GetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).GetConfig(ctx, req.(*GetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_UploadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).UploadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_UploadConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).UploadConfig(ctx, req.(*UploadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_WatchCoreMetrics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCoreMetricsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CoreManagmentServiceServer).WatchCoreMetrics(m, &grpc.GenericServerStream[WatchCoreMetricsRequest, CoreMetrics]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CoreManagmentService_WatchCoreMetricsServer = grpc.ServerStreamingServer[CoreMetrics]
+
+func _CoreManagmentService_CrashHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CrashHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).CrashHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_CrashHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).CrashHistory(ctx, req.(*CrashHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_GetLogSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).GetLogSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_GetLogSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).GetLogSettings(ctx, req.(*GetLogSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_SetLogSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).SetLogSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_SetLogSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).SetLogSettings(ctx, req.(*SetLogSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_ListConfigRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConfigRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).ListConfigRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_ListConfigRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).ListConfigRevisions(ctx, req.(*ListConfigRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_GetConfigRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).GetConfigRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_GetConfigRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).GetConfigRevision(ctx, req.(*GetConfigRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_DiffConfigRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffConfigRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).DiffConfigRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_DiffConfigRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).DiffConfigRevisions(ctx, req.(*DiffConfigRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_RollbackConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).RollbackConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_RollbackConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).RollbackConfig(ctx, req.(*RollbackConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_ListInbounds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInboundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).ListInbounds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_ListInbounds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).ListInbounds(ctx, req.(*ListInboundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_GetInbound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInboundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).GetInbound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_GetInbound_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).GetInbound(ctx, req.(*GetInboundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_PutInbound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutInboundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).PutInbound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_PutInbound_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).PutInbound(ctx, req.(*PutInboundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_DeleteInbound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteInboundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).DeleteInbound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_DeleteInbound_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).DeleteInbound(ctx, req.(*DeleteInboundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_ListOutbounds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOutboundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).ListOutbounds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_ListOutbounds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).ListOutbounds(ctx, req.(*ListOutboundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_GetOutbound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOutboundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).GetOutbound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_GetOutbound_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).GetOutbound(ctx, req.(*GetOutboundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_PutOutbound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutOutboundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).PutOutbound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_PutOutbound_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).PutOutbound(ctx, req.(*PutOutboundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_DeleteOutbound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteOutboundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).DeleteOutbound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_DeleteOutbound_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).DeleteOutbound(ctx, req.(*DeleteOutboundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_ListBalancers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBalancersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).ListBalancers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_ListBalancers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).ListBalancers(ctx, req.(*ListBalancersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_GetBalancer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalancerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).GetBalancer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_GetBalancer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).GetBalancer(ctx, req.(*GetBalancerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_PutBalancer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutBalancerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).PutBalancer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_PutBalancer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).PutBalancer(ctx, req.(*PutBalancerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_DeleteBalancer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBalancerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).DeleteBalancer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_DeleteBalancer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).DeleteBalancer(ctx, req.(*DeleteBalancerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_ListRoutingRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoutingRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).ListRoutingRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_ListRoutingRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).ListRoutingRules(ctx, req.(*ListRoutingRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_InsertRoutingRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InsertRoutingRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).InsertRoutingRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_InsertRoutingRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).InsertRoutingRule(ctx, req.(*InsertRoutingRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_MoveRoutingRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveRoutingRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).MoveRoutingRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_MoveRoutingRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).MoveRoutingRule(ctx, req.(*MoveRoutingRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_ReplaceRoutingRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceRoutingRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).ReplaceRoutingRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_ReplaceRoutingRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).ReplaceRoutingRule(ctx, req.(*ReplaceRoutingRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_DeleteRoutingRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRoutingRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).DeleteRoutingRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_DeleteRoutingRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).DeleteRoutingRule(ctx, req.(*DeleteRoutingRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreManagmentService_MatchRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreManagmentServiceServer).MatchRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreManagmentService_MatchRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreManagmentServiceServer).MatchRoute(ctx, req.(*MatchRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CoreManagmentService_ServiceDesc is the grpc.ServiceDesc for CoreManagmentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CoreManagmentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "xraymon.commands.CoreManagmentService",
+	HandlerType: (*CoreManagmentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListInstances",
+			Handler:    _CoreManagmentService_ListInstances_Handler,
+		},
+		{
+			MethodName: "CoreStatus",
+			Handler:    _CoreManagmentService_CoreStatus_Handler,
+		},
+		{
+			MethodName: "CoreRestart",
+			Handler:    _CoreManagmentService_CoreRestart_Handler,
+		},
+		{
+			MethodName: "CoreStart",
+			Handler:    _CoreManagmentService_CoreStart_Handler,
+		},
+		{
+			MethodName: "CoreStop",
+			Handler:    _CoreManagmentService_CoreStop_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _CoreManagmentService_GetConfig_Handler,
+		},
+		{
+			MethodName: "UploadConfig",
+			Handler:    _CoreManagmentService_UploadConfig_Handler,
+		},
+		{
+			MethodName: "CrashHistory",
+			Handler:    _CoreManagmentService_CrashHistory_Handler,
+		},
+		{
+			MethodName: "GetLogSettings",
+			Handler:    _CoreManagmentService_GetLogSettings_Handler,
+		},
+		{
+			MethodName: "SetLogSettings",
+			Handler:    _CoreManagmentService_SetLogSettings_Handler,
+		},
+		{
+			MethodName: "ListConfigRevisions",
+			Handler:    _CoreManagmentService_ListConfigRevisions_Handler,
+		},
+		{
+			MethodName: "GetConfigRevision",
+			Handler:    _CoreManagmentService_GetConfigRevision_Handler,
+		},
+		{
+			MethodName: "DiffConfigRevisions",
+			Handler:    _CoreManagmentService_DiffConfigRevisions_Handler,
+		},
+		{
+			MethodName: "RollbackConfig",
+			Handler:    _CoreManagmentService_RollbackConfig_Handler,
+		},
+		{
+			MethodName: "ListInbounds",
+			Handler:    _CoreManagmentService_ListInbounds_Handler,
+		},
+		{
+			MethodName: "GetInbound",
+			Handler:    _CoreManagmentService_GetInbound_Handler,
+		},
+		{
+			MethodName: "PutInbound",
+			Handler:    _CoreManagmentService_PutInbound_Handler,
+		},
+		{
+			MethodName: "DeleteInbound",
+			Handler:    _CoreManagmentService_DeleteInbound_Handler,
+		},
+		{
+			MethodName: "ListOutbounds",
+			Handler:    _CoreManagmentService_ListOutbounds_Handler,
+		},
+		{
+			MethodName: "GetOutbound",
+			Handler:    _CoreManagmentService_GetOutbound_Handler,
+		},
+		{
+			MethodName: "PutOutbound",
+			Handler:    _CoreManagmentService_PutOutbound_Handler,
+		},
+		{
+			MethodName: "DeleteOutbound",
+			Handler:    _CoreManagmentService_DeleteOutbound_Handler,
+		},
+		{
+			MethodName: "ListBalancers",
+			Handler:    _CoreManagmentService_ListBalancers_Handler,
+		},
+		{
+			MethodName: "GetBalancer",
+			Handler:    _CoreManagmentService_GetBalancer_Handler,
+		},
+		{
+			MethodName: "PutBalancer",
+			Handler:    _CoreManagmentService_PutBalancer_Handler,
+		},
+		{
+			MethodName: "DeleteBalancer",
+			Handler:    _CoreManagmentService_DeleteBalancer_Handler,
+		},
+		{
+			MethodName: "ListRoutingRules",
+			Handler:    _CoreManagmentService_ListRoutingRules_Handler,
+		},
+		{
+			MethodName: "InsertRoutingRule",
+			Handler:    _CoreManagmentService_InsertRoutingRule_Handler,
+		},
+		{
+			MethodName: "MoveRoutingRule",
+			Handler:    _CoreManagmentService_MoveRoutingRule_Handler,
+		},
+		{
+			MethodName: "ReplaceRoutingRule",
+			Handler:    _CoreManagmentService_ReplaceRoutingRule_Handler,
+		},
+		{
+			MethodName: "DeleteRoutingRule",
+			Handler:    _CoreManagmentService_DeleteRoutingRule_Handler,
+		},
+		{
+			MethodName: "MatchRoute",
+			Handler:    _CoreManagmentService_MatchRoute_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCoreMetrics",
+			Handler:       _CoreManagmentService_WatchCoreMetrics_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "commands.proto",
+}
+
+const (
+	JournalProvider_ConnectionJournal_FullMethodName = "/xraymon.commands.JournalProvider/ConnectionJournal"
+	JournalProvider_StderrJournal_FullMethodName     = "/xraymon.commands.JournalProvider/StderrJournal"
+	JournalProvider_NetworkStats_FullMethodName      = "/xraymon.commands.JournalProvider/NetworkStats"
+	JournalProvider_RotateJournal_FullMethodName     = "/xraymon.commands.JournalProvider/RotateJournal"
+)
+
+// JournalProviderClient is the client API for JournalProvider service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type JournalProviderClient interface {
+	ConnectionJournal(ctx context.Context, in *ConnectionJournalRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ConnectionMeta], error)
+	StderrJournal(ctx context.Context, in *StderrJournalRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StderrLine], error)
+	NetworkStats(ctx context.Context, in *NetworkStatsRequest, opts ...grpc.CallOption) (*NetworkStatsResponse, error)
+	RotateJournal(ctx context.Context, in *RotateJournalRequest, opts ...grpc.CallOption) (*RotateJournalResponse, error)
+}
+
+type journalProviderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJournalProviderClient(cc grpc.ClientConnInterface) JournalProviderClient {
+	return &journalProviderClient{cc}
+}
+
+func (c *journalProviderClient) ConnectionJournal(ctx context.Context, in *ConnectionJournalRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ConnectionMeta], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &JournalProvider_ServiceDesc.Streams[0], JournalProvider_ConnectionJournal_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ConnectionJournalRequest, ConnectionMeta]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JournalProvider_ConnectionJournalClient = grpc.ServerStreamingClient[ConnectionMeta]
+
+func (c *journalProviderClient) StderrJournal(ctx context.Context, in *StderrJournalRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StderrLine], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &JournalProvider_ServiceDesc.Streams[1], JournalProvider_StderrJournal_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StderrJournalRequest, StderrLine]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JournalProvider_StderrJournalClient = grpc.ServerStreamingClient[StderrLine]
+
+func (c *journalProviderClient) NetworkStats(ctx context.Context, in *NetworkStatsRequest, opts ...grpc.CallOption) (*NetworkStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NetworkStatsResponse)
+	err := c.cc.Invoke(ctx, JournalProvider_NetworkStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *journalProviderClient) RotateJournal(ctx context.Context, in *RotateJournalRequest, opts ...grpc.CallOption) (*RotateJournalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateJournalResponse)
+	err := c.cc.Invoke(ctx, JournalProvider_RotateJournal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JournalProviderServer is the server API for JournalProvider service.
+// All implementations must embed UnimplementedJournalProviderServer
+// for forward compatibility.
+type JournalProviderServer interface {
+	ConnectionJournal(*ConnectionJournalRequest, grpc.ServerStreamingServer[ConnectionMeta]) error
+	StderrJournal(*StderrJournalRequest, grpc.ServerStreamingServer[StderrLine]) error
+	NetworkStats(context.Context, *NetworkStatsRequest) (*NetworkStatsResponse, error)
+	RotateJournal(context.Context, *RotateJournalRequest) (*RotateJournalResponse, error)
+	mustEmbedUnimplementedJournalProviderServer()
+}
+
+// UnimplementedJournalProviderServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedJournalProviderServer struct{}
+
+func (UnimplementedJournalProviderServer) ConnectionJournal(*ConnectionJournalRequest, grpc.ServerStreamingServer[ConnectionMeta]) error {
+	return status.Error(codes.Unimplemented, "method ConnectionJournal not implemented")
+}
+func (UnimplementedJournalProviderServer) StderrJournal(*StderrJournalRequest, grpc.ServerStreamingServer[StderrLine]) error {
+	return status.Error(codes.Unimplemented, "method StderrJournal not implemented")
+}
+func (UnimplementedJournalProviderServer) NetworkStats(context.Context, *NetworkStatsRequest) (*NetworkStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method NetworkStats not implemented")
+}
+func (UnimplementedJournalProviderServer) RotateJournal(context.Context, *RotateJournalRequest) (*RotateJournalResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RotateJournal not implemented")
+}
+func (UnimplementedJournalProviderServer) mustEmbedUnimplementedJournalProviderServer() {}
+func (UnimplementedJournalProviderServer) testEmbeddedByValue()                         {}
+
+// UnsafeJournalProviderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JournalProviderServer will
+// result in compilation errors.
+type UnsafeJournalProviderServer interface {
+	mustEmbedUnimplementedJournalProviderServer()
+}
+
+func RegisterJournalProviderServer(s grpc.ServiceRegistrar, srv JournalProviderServer) {
+	// If the following call panics, it indicates UnimplementedJournalProviderServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&JournalProvider_ServiceDesc, srv)
+}
+
+func _JournalProvider_ConnectionJournal_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ConnectionJournalRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JournalProviderServer).ConnectionJournal(m, &grpc.GenericServerStream[ConnectionJournalRequest, ConnectionMeta]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JournalProvider_ConnectionJournalServer = grpc.ServerStreamingServer[ConnectionMeta]
+
+func _JournalProvider_StderrJournal_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StderrJournalRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JournalProviderServer).StderrJournal(m, &grpc.GenericServerStream[StderrJournalRequest, StderrLine]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JournalProvider_StderrJournalServer = grpc.ServerStreamingServer[StderrLine]
+
+func _JournalProvider_NetworkStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JournalProviderServer).NetworkStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JournalProvider_NetworkStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JournalProviderServer).NetworkStats(ctx, req.(*NetworkStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JournalProvider_RotateJournal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateJournalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JournalProviderServer).RotateJournal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JournalProvider_RotateJournal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JournalProviderServer).RotateJournal(ctx, req.(*RotateJournalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// JournalProvider_ServiceDesc is the grpc.ServiceDesc for JournalProvider service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var JournalProvider_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "xraymon.commands.JournalProvider",
+	HandlerType: (*JournalProviderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NetworkStats",
+			Handler:    _JournalProvider_NetworkStats_Handler,
+		},
+		{
+			MethodName: "RotateJournal",
+			Handler:    _JournalProvider_RotateJournal_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ConnectionJournal",
+			Handler:       _JournalProvider_ConnectionJournal_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StderrJournal",
+			Handler:       _JournalProvider_StderrJournal_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "commands.proto",
+}
+
+const (
+	CoreBinaryProvider_ListCoreBinaries_FullMethodName = "/xraymon.commands.CoreBinaryProvider/ListCoreBinaries"
+	CoreBinaryProvider_UploadCoreBinary_FullMethodName = "/xraymon.commands.CoreBinaryProvider/UploadCoreBinary"
+	CoreBinaryProvider_SwitchCoreBinary_FullMethodName = "/xraymon.commands.CoreBinaryProvider/SwitchCoreBinary"
+)
+
+// CoreBinaryProviderClient is the client API for CoreBinaryProvider service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CoreBinaryProviderClient interface {
+	ListCoreBinaries(ctx context.Context, in *ListCoreBinariesRequest, opts ...grpc.CallOption) (*ListCoreBinariesResponse, error)
+	UploadCoreBinary(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CoreBinaryChunk, CoreBinaryInfo], error)
+	SwitchCoreBinary(ctx context.Context, in *SwitchCoreBinaryRequest, opts ...grpc.CallOption) (*SwitchCoreBinaryResponse, error)
+}
+
+type coreBinaryProviderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCoreBinaryProviderClient(cc grpc.ClientConnInterface) CoreBinaryProviderClient {
+	return &coreBinaryProviderClient{cc}
+}
+
+func (c *coreBinaryProviderClient) ListCoreBinaries(ctx context.Context, in *ListCoreBinariesRequest, opts ...grpc.CallOption) (*ListCoreBinariesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCoreBinariesResponse)
+	err := c.cc.Invoke(ctx, CoreBinaryProvider_ListCoreBinaries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreBinaryProviderClient) UploadCoreBinary(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CoreBinaryChunk, CoreBinaryInfo], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetConfigResponse)
-	err := c.cc.Invoke(ctx, CoreManagmentService_GetConfig_FullMethodName, in, out, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &CoreBinaryProvider_ServiceDesc.Streams[0], CoreBinaryProvider_UploadCoreBinary_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &grpc.GenericClientStream[CoreBinaryChunk, CoreBinaryInfo]{ClientStream: stream}
+	return x, nil
 }
 
-func (c *coreManagmentServiceClient) UploadConfig(ctx context.Context, in *UploadConfigRequest, opts ...grpc.CallOption) (*UploadConfigResponse, error) {
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CoreBinaryProvider_UploadCoreBinaryClient = grpc.ClientStreamingClient[CoreBinaryChunk, CoreBinaryInfo]
+
+func (c *coreBinaryProviderClient) SwitchCoreBinary(ctx context.Context, in *SwitchCoreBinaryRequest, opts ...grpc.CallOption) (*SwitchCoreBinaryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(UploadConfigResponse)
-	err := c.cc.Invoke(ctx, CoreManagmentService_UploadConfig_FullMethodName, in, out, cOpts...)
+	out := new(SwitchCoreBinaryResponse)
+	err := c.cc.Invoke(ctx, CoreBinaryProvider_SwitchCoreBinary_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// CoreManagmentServiceServer is the server API for CoreManagmentService service.
-// All implementations must embed UnimplementedCoreManagmentServiceServer
+// CoreBinaryProviderServer is the server API for CoreBinaryProvider service.
+// All implementations must embed UnimplementedCoreBinaryProviderServer
 // for forward compatibility.
-type CoreManagmentServiceServer interface {
-	CoreStatus(context.Context, *CoreStatusRequest) (*CoreStatusResponse, error)
-	CoreRestart(context.Context, *CoreRestartRequest) (*CoreRestartResponse, error)
-	GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error)
-	UploadConfig(context.Context, *UploadConfigRequest) (*UploadConfigResponse, error)
-	mustEmbedUnimplementedCoreManagmentServiceServer()
+type CoreBinaryProviderServer interface {
+	ListCoreBinaries(context.Context, *ListCoreBinariesRequest) (*ListCoreBinariesResponse, error)
+	UploadCoreBinary(grpc.ClientStreamingServer[CoreBinaryChunk, CoreBinaryInfo]) error
+	SwitchCoreBinary(context.Context, *SwitchCoreBinaryRequest) (*SwitchCoreBinaryResponse, error)
+	mustEmbedUnimplementedCoreBinaryProviderServer()
 }
 
-// UnimplementedCoreManagmentServiceServer must be embedded to have
+// UnimplementedCoreBinaryProviderServer must be embedded to have
 // forward compatible implementations.
 //
 // NOTE: this should be embedded by value instead of pointer to avoid a nil
 // pointer dereference when methods are called.
-type UnimplementedCoreManagmentServiceServer struct{}
+type UnimplementedCoreBinaryProviderServer struct{}
 
-func (UnimplementedCoreManagmentServiceServer) CoreStatus(context.Context, *CoreStatusRequest) (*CoreStatusResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method CoreStatus not implemented")
-}
-func (UnimplementedCoreManagmentServiceServer) CoreRestart(context.Context, *CoreRestartRequest) (*CoreRestartResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method CoreRestart not implemented")
+func (UnimplementedCoreBinaryProviderServer) ListCoreBinaries(context.Context, *ListCoreBinariesRequest) (*ListCoreBinariesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCoreBinaries not implemented")
 }
-func (UnimplementedCoreManagmentServiceServer) GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetConfig not implemented")
+func (UnimplementedCoreBinaryProviderServer) UploadCoreBinary(grpc.ClientStreamingServer[CoreBinaryChunk, CoreBinaryInfo]) error {
+	return status.Error(codes.Unimplemented, "method UploadCoreBinary not implemented")
 }
-func (UnimplementedCoreManagmentServiceServer) UploadConfig(context.Context, *UploadConfigRequest) (*UploadConfigResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method UploadConfig not implemented")
+func (UnimplementedCoreBinaryProviderServer) SwitchCoreBinary(context.Context, *SwitchCoreBinaryRequest) (*SwitchCoreBinaryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SwitchCoreBinary not implemented")
 }
-func (UnimplementedCoreManagmentServiceServer) mustEmbedUnimplementedCoreManagmentServiceServer() {}
-func (UnimplementedCoreManagmentServiceServer) testEmbeddedByValue()                              {}
+func (UnimplementedCoreBinaryProviderServer) mustEmbedUnimplementedCoreBinaryProviderServer() {}
+func (UnimplementedCoreBinaryProviderServer) testEmbeddedByValue()                            {}
 
-// UnsafeCoreManagmentServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to CoreManagmentServiceServer will
+// UnsafeCoreBinaryProviderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CoreBinaryProviderServer will
 // result in compilation errors.
-type UnsafeCoreManagmentServiceServer interface {
-	mustEmbedUnimplementedCoreManagmentServiceServer()
+type UnsafeCoreBinaryProviderServer interface {
+	mustEmbedUnimplementedCoreBinaryProviderServer()
 }
 
-func RegisterCoreManagmentServiceServer(s grpc.ServiceRegistrar, srv CoreManagmentServiceServer) {
-	// If the following call panics, it indicates UnimplementedCoreManagmentServiceServer was
+func RegisterCoreBinaryProviderServer(s grpc.ServiceRegistrar, srv CoreBinaryProviderServer) {
+	// If the following call panics, it indicates UnimplementedCoreBinaryProviderServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
-	s.RegisterService(&CoreManagmentService_ServiceDesc, srv)
+	s.RegisterService(&CoreBinaryProvider_ServiceDesc, srv)
 }
 
-func _CoreManagmentService_CoreStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CoreStatusRequest)
+func _CoreBinaryProvider_ListCoreBinaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCoreBinariesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CoreManagmentServiceServer).CoreStatus(ctx, in)
+		return srv.(CoreBinaryProviderServer).ListCoreBinaries(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CoreManagmentService_CoreStatus_FullMethodName,
+		FullMethod: CoreBinaryProvider_ListCoreBinaries_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CoreManagmentServiceServer).CoreStatus(ctx, req.(*CoreStatusRequest))
+		return srv.(CoreBinaryProviderServer).ListCoreBinaries(ctx, req.(*ListCoreBinariesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CoreManagmentService_CoreRestart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CoreRestartRequest)
+func _CoreBinaryProvider_UploadCoreBinary_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CoreBinaryProviderServer).UploadCoreBinary(&grpc.GenericServerStream[CoreBinaryChunk, CoreBinaryInfo]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CoreBinaryProvider_UploadCoreBinaryServer = grpc.ClientStreamingServer[CoreBinaryChunk, CoreBinaryInfo]
+
+func _CoreBinaryProvider_SwitchCoreBinary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwitchCoreBinaryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CoreManagmentServiceServer).CoreRestart(ctx, in)
+		return srv.(CoreBinaryProviderServer).SwitchCoreBinary(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CoreManagmentService_CoreRestart_FullMethodName,
+		FullMethod: CoreBinaryProvider_SwitchCoreBinary_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CoreManagmentServiceServer).CoreRestart(ctx, req.(*CoreRestartRequest))
+		return srv.(CoreBinaryProviderServer).SwitchCoreBinary(ctx, req.(*SwitchCoreBinaryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CoreManagmentService_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetConfigRequest)
-	if err := dec(in); err != nil {
+// CoreBinaryProvider_ServiceDesc is the grpc.ServiceDesc for CoreBinaryProvider service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CoreBinaryProvider_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "xraymon.commands.CoreBinaryProvider",
+	HandlerType: (*CoreBinaryProviderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListCoreBinaries",
+			Handler:    _CoreBinaryProvider_ListCoreBinaries_Handler,
+		},
+		{
+			MethodName: "SwitchCoreBinary",
+			Handler:    _CoreBinaryProvider_SwitchCoreBinary_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadCoreBinary",
+			Handler:       _CoreBinaryProvider_UploadCoreBinary_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "commands.proto",
+}
+
+const (
+	ScheduleProvider_ListSchedule_FullMethodName = "/xraymon.commands.ScheduleProvider/ListSchedule"
+)
+
+// ScheduleProviderClient is the client API for ScheduleProvider service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ScheduleProviderClient interface {
+	ListSchedule(ctx context.Context, in *ListScheduleRequest, opts ...grpc.CallOption) (*ListScheduleResponse, error)
+}
+
+type scheduleProviderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScheduleProviderClient(cc grpc.ClientConnInterface) ScheduleProviderClient {
+	return &scheduleProviderClient{cc}
+}
+
+func (c *scheduleProviderClient) ListSchedule(ctx context.Context, in *ListScheduleRequest, opts ...grpc.CallOption) (*ListScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduleResponse)
+	err := c.cc.Invoke(ctx, ScheduleProvider_ListSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(CoreManagmentServiceServer).GetConfig(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: CoreManagmentService_GetConfig_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CoreManagmentServiceServer).GetConfig(ctx, req.(*GetConfigRequest))
+	return out, nil
+}
+
+// ScheduleProviderServer is the server API for ScheduleProvider service.
+// All implementations must embed UnimplementedScheduleProviderServer
+// for forward compatibility.
+type ScheduleProviderServer interface {
+	ListSchedule(context.Context, *ListScheduleRequest) (*ListScheduleResponse, error)
+	mustEmbedUnimplementedScheduleProviderServer()
+}
+
+// UnimplementedScheduleProviderServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScheduleProviderServer struct{}
+
+func (UnimplementedScheduleProviderServer) ListSchedule(context.Context, *ListScheduleRequest) (*ListScheduleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSchedule not implemented")
+}
+func (UnimplementedScheduleProviderServer) mustEmbedUnimplementedScheduleProviderServer() {}
+func (UnimplementedScheduleProviderServer) testEmbeddedByValue()                          {}
+
+// UnsafeScheduleProviderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScheduleProviderServer will
+// result in compilation errors.
+type UnsafeScheduleProviderServer interface {
+	mustEmbedUnimplementedScheduleProviderServer()
+}
+
+func RegisterScheduleProviderServer(s grpc.ServiceRegistrar, srv ScheduleProviderServer) {
+	// If the following call panics, it indicates UnimplementedScheduleProviderServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
 	}
-	return interceptor(ctx, in, info, handler)
+	s.RegisterService(&ScheduleProvider_ServiceDesc, srv)
 }
 
-func _CoreManagmentService_UploadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UploadConfigRequest)
+func _ScheduleProvider_ListSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CoreManagmentServiceServer).UploadConfig(ctx, in)
+		return srv.(ScheduleProviderServer).ListSchedule(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CoreManagmentService_UploadConfig_FullMethodName,
+		FullMethod: ScheduleProvider_ListSchedule_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CoreManagmentServiceServer).UploadConfig(ctx, req.(*UploadConfigRequest))
+		return srv.(ScheduleProviderServer).ListSchedule(ctx, req.(*ListScheduleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// CoreManagmentService_ServiceDesc is the grpc.ServiceDesc for CoreManagmentService service.
+// ScheduleProvider_ServiceDesc is the grpc.ServiceDesc for ScheduleProvider service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var CoreManagmentService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "xraymon.commands.CoreManagmentService",
-	HandlerType: (*CoreManagmentServiceServer)(nil),
+var ScheduleProvider_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "xraymon.commands.ScheduleProvider",
+	HandlerType: (*ScheduleProviderServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "CoreStatus",
-			Handler:    _CoreManagmentService_CoreStatus_Handler,
-		},
-		{
-			MethodName: "CoreRestart",
-			Handler:    _CoreManagmentService_CoreRestart_Handler,
-		},
-		{
-			MethodName: "GetConfig",
-			Handler:    _CoreManagmentService_GetConfig_Handler,
-		},
-		{
-			MethodName: "UploadConfig",
-			Handler:    _CoreManagmentService_UploadConfig_Handler,
+			MethodName: "ListSchedule",
+			Handler:    _ScheduleProvider_ListSchedule_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -235,35 +1838,31 @@ var CoreManagmentService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	JournalProvider_ConnectionJournal_FullMethodName = "/xraymon.commands.JournalProvider/ConnectionJournal"
-	JournalProvider_NetworkStats_FullMethodName      = "/xraymon.commands.JournalProvider/NetworkStats"
-	JournalProvider_RotateJournal_FullMethodName     = "/xraymon.commands.JournalProvider/RotateJournal"
+	EventProvider_WatchEvents_FullMethodName = "/xraymon.commands.EventProvider/WatchEvents"
 )
 
-// JournalProviderClient is the client API for JournalProvider service.
+// EventProviderClient is the client API for EventProvider service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type JournalProviderClient interface {
-	ConnectionJournal(ctx context.Context, in *ConnectionJournalRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ConnectionMeta], error)
-	NetworkStats(ctx context.Context, in *NetworkStatsRequest, opts ...grpc.CallOption) (*NetworkStatsResponse, error)
-	RotateJournal(ctx context.Context, in *RotateJournalRequest, opts ...grpc.CallOption) (*RotateJournalResponse, error)
+type EventProviderClient interface {
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CoreEvent], error)
 }
 
-type journalProviderClient struct {
+type eventProviderClient struct {
 	cc grpc.ClientConnInterface
 }
 
-func NewJournalProviderClient(cc grpc.ClientConnInterface) JournalProviderClient {
-	return &journalProviderClient{cc}
+func NewEventProviderClient(cc grpc.ClientConnInterface) EventProviderClient {
+	return &eventProviderClient{cc}
 }
 
-func (c *journalProviderClient) ConnectionJournal(ctx context.Context, in *ConnectionJournalRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ConnectionMeta], error) {
+func (c *eventProviderClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CoreEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &JournalProvider_ServiceDesc.Streams[0], JournalProvider_ConnectionJournal_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &EventProvider_ServiceDesc.Streams[0], EventProvider_WatchEvents_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[ConnectionJournalRequest, ConnectionMeta]{ClientStream: stream}
+	x := &grpc.GenericClientStream[WatchEventsRequest, CoreEvent]{ClientStream: stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -274,142 +1873,69 @@ func (c *journalProviderClient) ConnectionJournal(ctx context.Context, in *Conne
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type JournalProvider_ConnectionJournalClient = grpc.ServerStreamingClient[ConnectionMeta]
-
-func (c *journalProviderClient) NetworkStats(ctx context.Context, in *NetworkStatsRequest, opts ...grpc.CallOption) (*NetworkStatsResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(NetworkStatsResponse)
-	err := c.cc.Invoke(ctx, JournalProvider_NetworkStats_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *journalProviderClient) RotateJournal(ctx context.Context, in *RotateJournalRequest, opts ...grpc.CallOption) (*RotateJournalResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(RotateJournalResponse)
-	err := c.cc.Invoke(ctx, JournalProvider_RotateJournal_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
+type EventProvider_WatchEventsClient = grpc.ServerStreamingClient[CoreEvent]
 
-// JournalProviderServer is the server API for JournalProvider service.
-// All implementations must embed UnimplementedJournalProviderServer
+// EventProviderServer is the server API for EventProvider service.
+// All implementations must embed UnimplementedEventProviderServer
 // for forward compatibility.
-type JournalProviderServer interface {
-	ConnectionJournal(*ConnectionJournalRequest, grpc.ServerStreamingServer[ConnectionMeta]) error
-	NetworkStats(context.Context, *NetworkStatsRequest) (*NetworkStatsResponse, error)
-	RotateJournal(context.Context, *RotateJournalRequest) (*RotateJournalResponse, error)
-	mustEmbedUnimplementedJournalProviderServer()
+type EventProviderServer interface {
+	WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[CoreEvent]) error
+	mustEmbedUnimplementedEventProviderServer()
 }
 
-// UnimplementedJournalProviderServer must be embedded to have
+// UnimplementedEventProviderServer must be embedded to have
 // forward compatible implementations.
 //
 // NOTE: this should be embedded by value instead of pointer to avoid a nil
 // pointer dereference when methods are called.
-type UnimplementedJournalProviderServer struct{}
+type UnimplementedEventProviderServer struct{}
 
-func (UnimplementedJournalProviderServer) ConnectionJournal(*ConnectionJournalRequest, grpc.ServerStreamingServer[ConnectionMeta]) error {
-	return status.Error(codes.Unimplemented, "method ConnectionJournal not implemented")
-}
-func (UnimplementedJournalProviderServer) NetworkStats(context.Context, *NetworkStatsRequest) (*NetworkStatsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method NetworkStats not implemented")
+func (UnimplementedEventProviderServer) WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[CoreEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchEvents not implemented")
 }
-func (UnimplementedJournalProviderServer) RotateJournal(context.Context, *RotateJournalRequest) (*RotateJournalResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method RotateJournal not implemented")
-}
-func (UnimplementedJournalProviderServer) mustEmbedUnimplementedJournalProviderServer() {}
-func (UnimplementedJournalProviderServer) testEmbeddedByValue()                         {}
+func (UnimplementedEventProviderServer) mustEmbedUnimplementedEventProviderServer() {}
+func (UnimplementedEventProviderServer) testEmbeddedByValue()                       {}
 
-// UnsafeJournalProviderServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to JournalProviderServer will
+// UnsafeEventProviderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EventProviderServer will
 // result in compilation errors.
-type UnsafeJournalProviderServer interface {
-	mustEmbedUnimplementedJournalProviderServer()
+type UnsafeEventProviderServer interface {
+	mustEmbedUnimplementedEventProviderServer()
 }
 
-func RegisterJournalProviderServer(s grpc.ServiceRegistrar, srv JournalProviderServer) {
-	// If the following call panics, it indicates UnimplementedJournalProviderServer was
+func RegisterEventProviderServer(s grpc.ServiceRegistrar, srv EventProviderServer) {
+	// If the following call panics, it indicates UnimplementedEventProviderServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
-	s.RegisterService(&JournalProvider_ServiceDesc, srv)
+	s.RegisterService(&EventProvider_ServiceDesc, srv)
 }
 
-func _JournalProvider_ConnectionJournal_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ConnectionJournalRequest)
+func _EventProvider_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(JournalProviderServer).ConnectionJournal(m, &grpc.GenericServerStream[ConnectionJournalRequest, ConnectionMeta]{ServerStream: stream})
+	return srv.(EventProviderServer).WatchEvents(m, &grpc.GenericServerStream[WatchEventsRequest, CoreEvent]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type JournalProvider_ConnectionJournalServer = grpc.ServerStreamingServer[ConnectionMeta]
-
-func _JournalProvider_NetworkStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NetworkStatsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(JournalProviderServer).NetworkStats(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: JournalProvider_NetworkStats_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JournalProviderServer).NetworkStats(ctx, req.(*NetworkStatsRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _JournalProvider_RotateJournal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RotateJournalRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(JournalProviderServer).RotateJournal(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: JournalProvider_RotateJournal_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JournalProviderServer).RotateJournal(ctx, req.(*RotateJournalRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
+type EventProvider_WatchEventsServer = grpc.ServerStreamingServer[CoreEvent]
 
-// JournalProvider_ServiceDesc is the grpc.ServiceDesc for JournalProvider service.
+// EventProvider_ServiceDesc is the grpc.ServiceDesc for EventProvider service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var JournalProvider_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "xraymon.commands.JournalProvider",
-	HandlerType: (*JournalProviderServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "NetworkStats",
-			Handler:    _JournalProvider_NetworkStats_Handler,
-		},
-		{
-			MethodName: "RotateJournal",
-			Handler:    _JournalProvider_RotateJournal_Handler,
-		},
-	},
+var EventProvider_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "xraymon.commands.EventProvider",
+	HandlerType: (*EventProviderServer)(nil),
+	Methods:     []grpc.MethodDesc{},
 	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "ConnectionJournal",
-			Handler:       _JournalProvider_ConnectionJournal_Handler,
+			StreamName:    "WatchEvents",
+			Handler:       _EventProvider_WatchEvents_Handler,
 			ServerStreams: true,
 		},
 	},
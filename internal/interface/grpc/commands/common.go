@@ -1,15 +1,172 @@
 package commands
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
 	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/usecase/confdiff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func domain2dtoCoreStatusResponse(s domain.CoreStatus) *CoreStatusResponse {
-	return &CoreStatusResponse{
-		Working:     s.Working,
-		LastLog:     s.LastLog,
-		WorkingTime: durationpb.New(s.WorkingTime),
+	r := &CoreStatusResponse{
+		Working:        s.Working,
+		LastLog:        s.LastLog,
+		WorkingTime:    durationpb.New(s.WorkingTime),
+		State:          determCoreState(s.State),
+		Crashes:        uint32(s.Crashes),
+		RollbackReason: s.RollbackReason,
+		Restarts:       uint32(s.Restarts),
+		Process:        domain2dtoProcessMetrics(s.Process),
+		Binary:         s.Binary,
+		Healthy:        s.Healthy,
+		ProbeLatency:   durationpb.New(s.ProbeLatency),
+		Attached:       s.Attached,
+		Draining:       s.Draining,
+		DrainRate:      s.DrainRate,
+	}
+
+	if !s.LastRollback.IsZero() {
+		r.LastRollback = timestamppb.New(s.LastRollback)
+	}
+
+	return r
+}
+
+// attachedStatus - reports operations refused for an externally managed core as FailedPrecondition.
+func attachedStatus(err error) error {
+	if errors.Is(err, domain.ErrAttached) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+// revisionStatus - reports unknown config revisions as NotFound.
+func revisionStatus(err error) error {
+	if errors.Is(err, domain.ErrRevisionNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return attachedStatus(err)
+}
+
+// requestAuthor - identifies the caller for config revisions: a fingerprint of the API bearer token,
+// the peer address when the request has no token.
+func requestAuthor(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if tokens := md.Get("authorization"); len(tokens) > 0 && tokens[0] != "" {
+			token := strings.TrimSpace(strings.TrimPrefix(tokens[0], "Bearer "))
+			sum := sha256.Sum256([]byte(token))
+			return "token:" + hex.EncodeToString(sum[:4])
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return "peer:" + p.Addr.String()
+	}
+
+	return "unknown"
+}
+
+func domain2dtoConfigRevision(rev domain.ConfigRevision) *ConfigRevision {
+	return &ConfigRevision{
+		Number:  rev.Number,
+		Time:    timestamppb.New(rev.Time),
+		Author:  rev.Author,
+		Comment: rev.Comment,
+		Hash:    rev.Hash,
+	}
+}
+
+func domain2dtoConfigChange(c confdiff.Change) *ConfigChange {
+	return &ConfigChange{
+		Op:   determChangeOp(c.Op),
+		Path: c.Path,
+		Tag:  c.Tag,
+		Old:  string(c.Old),
+		New:  string(c.New),
+	}
+}
+
+func determChangeOp(op confdiff.Op) ConfigChangeOp {
+	switch op {
+	case confdiff.OpAdded:
+		return ConfigChangeOp_CONFIG_CHANGE_ADDED
+	case confdiff.OpRemoved:
+		return ConfigChangeOp_CONFIG_CHANGE_REMOVED
+	case confdiff.OpChanged:
+		return ConfigChangeOp_CONFIG_CHANGE_CHANGED
+	default:
+		return ConfigChangeOp_CONFIG_CHANGE_UNKNOWN
+	}
+}
+
+func domain2dtoLogSettings(ls domain.LogSettings) *LogSettings {
+	return &LogSettings{
+		Level:       ls.Level,
+		DnsLog:      ls.DNSLog,
+		MaskAddress: ls.MaskAddress,
+	}
+}
+
+func dto2domainLogSettings(ls *LogSettings) domain.LogSettings {
+	return domain.LogSettings{
+		Level:       ls.Level,
+		DNSLog:      ls.DnsLog,
+		MaskAddress: ls.MaskAddress,
+	}
+}
+
+func domain2dtoCrashRecord(rec domain.CrashRecord) *CrashRecord {
+	return &CrashRecord{
+		Time:       timestamppb.New(rec.Time),
+		ExitCode:   int32(rec.ExitCode),
+		Signal:     rec.Signal,
+		Reason:     rec.Reason,
+		Uptime:     durationpb.New(rec.Uptime),
+		ConfigHash: rec.ConfigHash,
+		ConfigRev:  rec.ConfigRev,
+		Binary:     rec.Binary,
+		Output:     rec.Output,
+	}
+}
+
+// domain2dtoProcessMetrics - returns nil for a process that is not running.
+func domain2dtoProcessMetrics(p domain.ProcessStats) *ProcessMetrics {
+	if p.PID == 0 {
+		return nil
+	}
+
+	return &ProcessMetrics{
+		Pid:        uint32(p.PID),
+		RssBytes:   p.RSS,
+		CpuTime:    durationpb.New(p.CPUTime),
+		CpuPercent: p.CPUPercent,
+		Threads:    uint32(p.Threads),
+		OpenFds:    uint32(p.OpenFDs),
+	}
+}
+
+func determCoreState(s domain.CoreRunState) CoreState {
+	switch s {
+	case domain.CoreRunning:
+		return CoreState_CORE_RUNNING
+	case domain.CoreCrashed:
+		return CoreState_CORE_CRASHED
+	case domain.CoreBackoff:
+		return CoreState_CORE_BACKOFF
+	case domain.CoreFailed:
+		return CoreState_CORE_FAILED
+	default:
+		return CoreState_CORE_STOPPED
 	}
 }
 
@@ -24,6 +181,13 @@ func domain2dtoConnectionMeta(s domain.ConnectionMetadata) *ConnectionMeta {
 	}
 }
 
+func domain2dtoStderrLine(l domain.JournalLine) *StderrLine {
+	return &StderrLine{
+		Time: timestamppb.New(l.Time),
+		Line: l.Line,
+	}
+}
+
 func selectType(v string) NetType {
 	switch v {
 	case "udp":
@@ -71,3 +235,56 @@ func determConnType(t domain.StatsType) ConnectionType {
 		return ConnectionType_USER
 	}
 }
+
+func domain2dtoCoreEvent(e domain.Event) *CoreEvent {
+	return &CoreEvent{
+		Type:     determEventType(e.Kind),
+		Instance: e.Instance,
+		Time:     timestamppb.New(e.Time),
+		Message:  e.Message,
+		ExitCode: int32(e.ExitCode),
+		Attempt:  uint32(e.Attempt),
+		Delay:    durationpb.New(e.Delay),
+		Journal:  e.Journal,
+		Rate:     e.Rate,
+	}
+}
+
+func determEventType(k domain.EventKind) EventType {
+	switch k {
+	case domain.EventCoreStarted:
+		return EventType_EVENT_CORE_STARTED
+	case domain.EventCoreExited:
+		return EventType_EVENT_CORE_EXITED
+	case domain.EventCoreBackoff:
+		return EventType_EVENT_CORE_BACKOFF
+	case domain.EventCoreFailed:
+		return EventType_EVENT_CORE_FAILED
+	case domain.EventConfigSaved:
+		return EventType_EVENT_CONFIG_SAVED
+	case domain.EventConfigRollback:
+		return EventType_EVENT_CONFIG_ROLLBACK
+	case domain.EventConfigApplied:
+		return EventType_EVENT_CONFIG_APPLIED
+	case domain.EventJournalRotated:
+		return EventType_EVENT_JOURNAL_ROTATED
+	case domain.EventStatsCollected:
+		return EventType_EVENT_STATS_COLLECTED
+	case domain.EventBinarySwitched:
+		return EventType_EVENT_BINARY_SWITCHED
+	case domain.EventBinaryRollback:
+		return EventType_EVENT_BINARY_ROLLBACK
+	case domain.EventCoreUnhealthy:
+		return EventType_EVENT_CORE_UNHEALTHY
+	case domain.EventScheduled:
+		return EventType_EVENT_SCHEDULED
+	case domain.EventDrainStarted:
+		return EventType_EVENT_DRAIN_STARTED
+	case domain.EventDrainProgress:
+		return EventType_EVENT_DRAIN_PROGRESS
+	case domain.EventDrainFinished:
+		return EventType_EVENT_DRAIN_FINISHED
+	default:
+		return EventType_EVENT_UNKNOWN
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package commands
+
+import (
+	context "context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/usecase/confedit"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// editStatus - maps config edit errors to gRPC codes.
+func editStatus(err error) error {
+	var refErr *confedit.ReferencedError
+	if errors.As(err, &refErr) {
+		return referencedStatus(refErr)
+	}
+
+	switch {
+	case errors.Is(err, confedit.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, confedit.ErrConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, confedit.ErrInvalid):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, confedit.ErrReferenced):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return attachedStatus(err)
+}
+
+// referencedStatus - FailedPrecondition listing the references as violations.
+func referencedStatus(err *confedit.ReferencedError) error {
+	failure := &errdetails.PreconditionFailure{}
+	for _, ref := range err.Refs {
+		failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        "REFERENCE",
+			Subject:     ref,
+			Description: fmt.Sprintf("refers to %s %q", err.Kind, err.Tag),
+		})
+	}
+	for _, dep := range err.Dependents {
+		failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        "DEPENDENT",
+			Subject:     dep,
+			Description: fmt.Sprintf("deleted with %s %q only when named in dependents", err.Kind, err.Tag),
+		})
+	}
+
+	st, detailErr := status.New(codes.FailedPrecondition, err.Error()).WithDetails(failure)
+	if detailErr != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return st.Err()
+}
+
+// editConfig - loads the stored config, changes it with edit, tests the result with the core and saves
+// it as a new revision. With apply the saved config is applied to the running core, handler changes
+// are hot-applied. Edits of one instance are serialized instead of rate limited like uploads: a client
+// changes several objects in a row and every edit already waits for the core config test.
+func (cmh *coreManageHandlers) editConfig(ctx context.Context, inst *Instance, log *slog.Logger, edit func(domain.CoreConfiguration) error, change domain.ConfigChange, apply bool) (domain.ConfigRevision, error) {
+	inst.editMu.Lock()
+	defer inst.editMu.Unlock()
+
+	cfg, err := inst.ConfLoad.LoadConfig()
+	if err != nil {
+		log.Error("failed to load config", "error", err)
+		return domain.ConfigRevision{}, attachedStatus(err)
+	}
+
+	if err := edit(cfg); err != nil {
+		log.Warn("config edit refused", "error", err)
+		return domain.ConfigRevision{}, editStatus(err)
+	}
+
+	if err := inst.ConfTest.TestConfig(ctx, cfg); err != nil {
+		if errors.Is(err, domain.ErrConfigRejected) {
+			log.Warn("edited config rejected by core", "error", err)
+			return domain.ConfigRevision{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		log.Error("failed to test config", "error", err)
+		return domain.ConfigRevision{}, attachedStatus(err)
+	}
+
+	change.Author = requestAuthor(ctx)
+
+	rev, err := inst.History.SaveRevision(cfg, change)
+	if err != nil {
+		log.Error("failed to save config", "error", err)
+		return domain.ConfigRevision{}, attachedStatus(err)
+	}
+
+	log.Info("config edited", "revision", rev.Number, "comment", change.Comment)
+
+	if apply {
+		if err := inst.CoreState.ApplyConfig(); err != nil {
+			log.Error("core config apply failed", "error", err)
+			return rev, attachedStatus(err)
+		}
+		log.Info("core config apply scheduled")
+	}
+
+	return rev, nil
+}
+
+// editComment - revision comment of an edit, the request comment wins over the generated one.
+func editComment(requested, generated string) string {
+	if requested != "" {
+		return requested
+	}
+	return generated
+}
+
+// putTag - tag of the object put by an edit: the requested one or the tag of the data.
+func putTag(key, data string) string {
+	if key != "" {
+		return key
+	}
+
+	var obj struct {
+		Tag string `json:"tag"`
+	}
+	_ = json.Unmarshal([]byte(data), &obj)
+	return obj.Tag
+}
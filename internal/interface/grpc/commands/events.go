@@ -0,0 +1,74 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package commands
+
+import (
+	"log/slog"
+
+	"github.com/eterline/xraymon/internal/domain"
+	grpc "google.golang.org/grpc"
+)
+
+const eventStreamBuffer = 64
+
+type EventSubscriber interface {
+	Subscribe(buffer int) (<-chan domain.Event, func())
+}
+
+// eventHandlers - gRPC handler for lifecycle event streaming.
+type eventHandlers struct {
+	events EventSubscriber
+
+	log *slog.Logger
+
+	UnimplementedEventProviderServer
+}
+
+func NewEventHandlers(events EventSubscriber, log *slog.Logger) *eventHandlers {
+	return &eventHandlers{
+		events: events,
+		log:    log,
+	}
+}
+
+// WatchEvents - streams lifecycle events until the client disconnects.
+func (eh *eventHandlers) WatchEvents(r *WatchEventsRequest, stream grpc.ServerStreamingServer[CoreEvent]) error {
+	filter := make(map[EventType]struct{}, len(r.Types))
+	for _, t := range r.Types {
+		filter[t] = struct{}{}
+	}
+
+	events, cancel := eh.events.Subscribe(eventStreamBuffer)
+	defer cancel()
+
+	ctx := stream.Context()
+	eh.log.Debug("event stream opened")
+
+	for {
+		select {
+		case <-ctx.Done():
+			eh.log.Debug("event stream closed by client")
+			return nil
+
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			if r.Instance != "" && e.Instance != r.Instance {
+				continue
+			}
+
+			dto := domain2dtoCoreEvent(e)
+			if _, ok := filter[dto.Type]; len(filter) > 0 && !ok {
+				continue
+			}
+
+			if err := stream.Send(dto); err != nil {
+				eh.log.Warn("failed to send event", "error", err)
+				return err
+			}
+		}
+	}
+}
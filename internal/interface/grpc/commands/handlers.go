@@ -12,8 +12,11 @@ import (
 	"time"
 
 	"github.com/eterline/xraymon/internal/domain"
-	"github.com/eterline/xraymon/internal/utils/usecase"
+	"github.com/eterline/xraymon/internal/usecase/confdiff"
 	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Limiter - interface for call rate limiting.
@@ -24,42 +27,60 @@ type Limiter interface {
 
 // coreManageHandlers - gRPC handler for core management operations.
 type coreManageHandlers struct {
-	confSave  domain.ConfigSaver
-	confLoad  domain.ConfigLoader
-	coreState domain.CoreState
-
-	confSaveLim    Limiter
-	coreRestartLim Limiter
+	instances *Instances
 
 	log *slog.Logger
 
 	UnimplementedCoreManagmentServiceServer
 }
 
-// NewCoreManageHandlers - creates a new coreManageHandlers instance with interval limiters.
-func NewCoreManageHandlers(
-	s domain.ConfigSaver,
-	l domain.ConfigLoader,
-	r domain.CoreState,
-	log *slog.Logger,
-) *coreManageHandlers {
+// NewCoreManageHandlers - creates a new coreManageHandlers instance over the registered core instances.
+func NewCoreManageHandlers(instances *Instances, log *slog.Logger) *coreManageHandlers {
 	return &coreManageHandlers{
-		confSave:  s,
-		confLoad:  l,
-		coreState: r,
+		instances: instances,
+		log:       log,
+	}
+}
+
+// instance - resolves instance selector and returns instance scoped logger.
+func (cmh *coreManageHandlers) instance(name string) (*Instance, *slog.Logger, error) {
+	inst, err := cmh.instances.Get(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inst, cmh.log.With("instance", inst.Name()), nil
+}
+
+// ListInstances - returns supervised core instances with their states.
+func (cmh *coreManageHandlers) ListInstances(ctx context.Context, r *ListInstancesRequest) (*ListInstancesResponse, error) {
+
+	resp := &ListInstancesResponse{}
 
-		confSaveLim:    usecase.NewIntervalLimiter(5 * time.Second),
-		coreRestartLim: usecase.NewIntervalLimiter(5 * time.Second),
+	for _, name := range cmh.instances.Names() {
+		inst, err := cmh.instances.Get(name)
+		if err != nil {
+			return nil, err
+		}
 
-		log: log,
+		resp.Instances = append(resp.Instances, &InstanceInfo{
+			Name:  name,
+			State: determCoreState(inst.CoreState.Status().State),
+		})
 	}
+
+	return resp, nil
 }
 
 // CoreStatus - returns the current core status.
 func (cmh *coreManageHandlers) CoreStatus(ctx context.Context, r *CoreStatusRequest) (*CoreStatusResponse, error) {
 
-	status := cmh.coreState.Status()
-	cmh.log.Debug("core status requested", "status", status)
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	status := inst.CoreState.Status()
+	log.Debug("core status requested", "status", status)
 
 	return domain2dtoCoreStatusResponse(status), nil
 }
@@ -67,80 +88,319 @@ func (cmh *coreManageHandlers) CoreStatus(ctx context.Context, r *CoreStatusRequ
 // CoreRestart - triggers a core restart with rate-limiting protection.
 func (cmh *coreManageHandlers) CoreRestart(ctx context.Context, r *CoreRestartRequest) (*CoreRestartResponse, error) {
 
-	if !cmh.coreRestartLim.InLimits() {
-		cmh.log.Warn("core restart request ignored due to rate limit")
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	if !inst.coreRestartLim.InLimits() {
+		log.Warn("core restart request ignored due to rate limit")
 		return &CoreRestartResponse{}, nil
 	}
 
-	cmh.log.Info("core restart requested")
+	log.Info("core restart requested", "drain", r.Drain)
+
+	restart := inst.CoreState.Restart
+	if r.Drain {
+		restart = inst.CoreState.DrainRestart
+	}
 
-	if err := cmh.coreState.Restart(); err != nil {
-		cmh.log.Error("core restart failed", "error", err)
+	if err := restart(); err != nil {
+		log.Error("core restart failed", "error", err)
 		return nil, err
 	}
 
-	cmh.log.Info("core successfully restarted")
+	log.Info("core successfully restarted")
 	return &CoreRestartResponse{}, nil
 }
 
+// CoreStart - starts a stopped core with rate-limiting protection.
+func (cmh *coreManageHandlers) CoreStart(ctx context.Context, r *CoreStartRequest) (*CoreStartResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	if !inst.coreRestartLim.InLimits() {
+		log.Warn("core start request ignored due to rate limit")
+		return &CoreStartResponse{}, nil
+	}
+
+	log.Info("core start requested")
+
+	if err := inst.CoreState.Start(); err != nil {
+		log.Error("core start failed", "error", err)
+		return nil, err
+	}
+
+	log.Info("core successfully started")
+	return &CoreStartResponse{}, nil
+}
+
+// CoreStop - stops the core. Stopped core is not restarted until CoreStart or CoreRestart.
+func (cmh *coreManageHandlers) CoreStop(ctx context.Context, r *CoreStopRequest) (*CoreStopResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("core stop requested")
+
+	if err := inst.CoreState.Stop(); err != nil {
+		log.Error("core stop failed", "error", err)
+		return nil, err
+	}
+
+	log.Info("core successfully stopped")
+	return &CoreStopResponse{}, nil
+}
+
 // GetConfig - returns the current core configuration in JSON format.
 func (cmh *coreManageHandlers) GetConfig(ctx context.Context, r *GetConfigRequest) (*GetConfigResponse, error) {
 
-	cfg, err := cmh.confLoad.LoadConfig()
+	inst, log, err := cmh.instance(r.Instance)
 	if err != nil {
-		cmh.log.Error("failed to load config", "error", err)
 		return nil, err
 	}
 
+	cfg, err := inst.ConfLoad.LoadConfig()
+	if err != nil {
+		log.Error("failed to load config", "error", err)
+		return nil, attachedStatus(err)
+	}
+
 	data, err := json.Marshal(cfg)
 	if err != nil {
-		cmh.log.Error("failed to marshal config", "error", err)
+		log.Error("failed to marshal config", "error", err)
 		return nil, err
 	}
 
-	cmh.log.Debug("config requested")
+	log.Debug("config requested")
 	return &GetConfigResponse{Data: string(data)}, nil
 }
 
 // UploadConfig - uploads a new core configuration with rate-limiting.
 func (cmh *coreManageHandlers) UploadConfig(ctx context.Context, r *UploadConfigRequest) (*UploadConfigResponse, error) {
 
-	if !cmh.confSaveLim.InLimits() {
-		cmh.log.Warn("config upload rejected due to rate limit")
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	// dry runs spawn the core for the config test too
+	if !inst.confSaveLim.InLimits() {
+		log.Warn("config upload rejected due to rate limit")
 		return nil, errors.New("too many upload requests")
 	}
 
+	if r.DryRun {
+		return cmh.uploadDryRun(ctx, inst, log, r)
+	}
+
 	if !json.Valid([]byte(r.Data)) {
-		cmh.log.Warn("invalid JSON config format")
+		log.Warn("invalid JSON config format")
 		return nil, errors.New("invalid JSON config format")
 	}
 
 	var cfg domain.CoreConfiguration
 	if err := json.Unmarshal([]byte(r.Data), &cfg); err != nil {
-		cmh.log.Warn("invalid config payload", "error", err)
+		log.Warn("invalid config payload", "error", err)
 		return nil, err
 	}
 
-	cmh.log.Info("config upload requested")
+	log.Info("config upload requested")
 
-	if err := cmh.confSave.SaveConfig(cfg); err != nil {
-		cmh.log.Error("failed to save config", "error", err)
-		return nil, err
+	// an edit running at the same time must not save its revision between the test and the save
+	inst.editMu.Lock()
+	defer inst.editMu.Unlock()
+
+	if err := inst.ConfTest.TestConfig(ctx, cfg); err != nil {
+		if errors.Is(err, domain.ErrConfigRejected) {
+			log.Warn("config rejected by core", "error", err)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		log.Error("failed to test config", "error", err)
+		return nil, attachedStatus(err)
+	}
+
+	rev, err := inst.History.SaveRevision(cfg, domain.ConfigChange{
+		Author:  requestAuthor(ctx),
+		Comment: r.Comment,
+	})
+	if err != nil {
+		log.Error("failed to save config", "error", err)
+		return nil, attachedStatus(err)
 	}
 
 	if r.RestartCore {
-		cmh.log.Info("core restart requested")
+		log.Info("core config apply requested", "drain", r.Drain)
+
+		apply := inst.CoreState.ApplyConfig
+		if r.Drain {
+			apply = inst.CoreState.DrainApplyConfig
+		}
 
-		if err := cmh.coreState.Restart(); err != nil {
-			cmh.log.Error("core restart failed", "error", err)
+		if err := apply(); err != nil {
+			log.Error("core config apply failed", "error", err)
 			return nil, err
 		}
 
-		cmh.log.Info("core successfully restarted")
+		log.Info("core config apply scheduled")
+	}
+
+	log.Info("config successfully saved", "revision", rev.Number)
+	return &UploadConfigResponse{Revision: rev.Number}, nil
+}
+
+// uploadDryRun - tests the uploaded config and diffs it against the current one without saving.
+// A config rejected by the core is reported in the response, not as an error.
+func (cmh *coreManageHandlers) uploadDryRun(ctx context.Context, inst *Instance, log *slog.Logger, r *UploadConfigRequest) (*UploadConfigResponse, error) {
+	var cfg domain.CoreConfiguration
+	if err := json.Unmarshal([]byte(r.Data), &cfg); err != nil {
+		log.Warn("invalid config payload", "error", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	current, err := inst.ConfLoad.LoadConfig()
+	if err != nil {
+		log.Error("failed to load config", "error", err)
+		return nil, attachedStatus(err)
+	}
+
+	resp := &UploadConfigResponse{DroppedKeys: cfg.DroppedKeys()}
+	cfg.Clear()
+
+	if err := inst.ConfTest.TestConfig(ctx, cfg); err != nil {
+		if !errors.Is(err, domain.ErrConfigRejected) {
+			log.Error("failed to test config", "error", err)
+			return nil, attachedStatus(err)
+		}
+		resp.Rejected = err.Error()
+	}
+
+	for _, c := range confdiff.Diff(current, cfg) {
+		resp.Changes = append(resp.Changes, domain2dtoConfigChange(c))
+	}
+
+	log.Info("config dry run", "changes", len(resp.Changes), "dropped", len(resp.DroppedKeys), "rejected", resp.Rejected != "")
+	return resp, nil
+}
+
+// CrashHistory - returns recorded core crashes, newest first.
+func (cmh *coreManageHandlers) CrashHistory(ctx context.Context, r *CrashHistoryRequest) (*CrashHistoryResponse, error) {
+
+	inst, _, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	records := inst.Crashes.Crashes()
+	if r.Limit > 0 && int(r.Limit) < len(records) {
+		records = records[:r.Limit]
+	}
+
+	resp := &CrashHistoryResponse{
+		Records: make([]*CrashRecord, 0, len(records)),
+	}
+
+	for _, rec := range records {
+		resp.Records = append(resp.Records, domain2dtoCrashRecord(rec))
+	}
+
+	return resp, nil
+}
+
+// GetLogSettings - returns core log settings.
+func (cmh *coreManageHandlers) GetLogSettings(ctx context.Context, r *GetLogSettingsRequest) (*LogSettings, error) {
+
+	inst, _, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain2dtoLogSettings(inst.Logging.LogSettings()), nil
+}
+
+// SetLogSettings - stores core log settings and optionally restarts the core to apply them.
+func (cmh *coreManageHandlers) SetLogSettings(ctx context.Context, r *SetLogSettingsRequest) (*SetLogSettingsResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Settings == nil {
+		return nil, status.Error(codes.InvalidArgument, "settings are required")
+	}
+
+	if r.RestartCore && !inst.coreRestartLim.InLimits() {
+		log.Warn("log settings change rejected due to rate limit")
+		return nil, errors.New("too many requests")
+	}
+
+	ls := dto2domainLogSettings(r.Settings)
+	if err := ls.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	restarted, err := inst.Logging.SetLogSettings(ls, r.RestartCore)
+	if err != nil {
+		log.Error("failed to set log settings", "error", err)
+		return nil, attachedStatus(err)
+	}
+
+	return &SetLogSettingsResponse{Restarted: restarted}, nil
+}
+
+const (
+	defaultMetricsInterval = 5 * time.Second
+	minMetricsInterval     = time.Second
+)
+
+// WatchCoreMetrics - streams core process resource metrics with the requested interval.
+func (cmh *coreManageHandlers) WatchCoreMetrics(r *WatchCoreMetricsRequest, stream grpc.ServerStreamingServer[CoreMetrics]) error {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return err
+	}
+
+	interval := defaultMetricsInterval
+	if r.Interval != nil {
+		interval = max(r.Interval.AsDuration(), minMetricsInterval)
 	}
 
-	cmh.log.Info("config successfully saved")
-	return &UploadConfigResponse{}, nil
+	log.Debug("core metrics watch started", "interval", interval)
+	defer log.Debug("core metrics watch finished")
+
+	ctx := stream.Context()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		st := inst.CoreState.Status()
+
+		dto := &CoreMetrics{
+			Time:     timestamppb.Now(),
+			State:    determCoreState(st.State),
+			Restarts: uint32(st.Restarts),
+			Process:  domain2dtoProcessMetrics(st.Process),
+		}
+
+		if err := stream.Send(dto); err != nil {
+			log.Warn("failed to send core metrics", "error", err)
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
 }
 
 // ===================================================
@@ -154,47 +414,60 @@ type ConnectionJournal interface {
 	Rotate() error
 }
 
+type CoreLogging interface {
+	LogSettings() domain.LogSettings
+	SetLogSettings(ls domain.LogSettings, restart bool) (bool, error)
+}
+
 type CoreJournal interface {
 	LastLog() string
 	Rotate() error
 }
 
-type journalHandlers struct {
-	coreJr   CoreJournal
-	connJr   ConnectionJournal
-	statsNet StatsActual
+type StderrJournal interface {
+	LastLines(context.Context, int) ([]domain.JournalLine, error)
+	Rotate() error
+}
 
-	rotateLim  Limiter
-	journalLim Limiter
+type journalHandlers struct {
+	instances *Instances
 
 	log *slog.Logger
 
 	UnimplementedJournalProviderServer
 }
 
-func NewJournalHandlers(conn ConnectionJournal, core CoreJournal, sa StatsActual, log *slog.Logger) *journalHandlers {
+func NewJournalHandlers(instances *Instances, log *slog.Logger) *journalHandlers {
 	return &journalHandlers{
-		connJr:   conn,
-		coreJr:   core,
-		statsNet: sa,
-
-		rotateLim:  usecase.NewIntervalLimiter(5 * time.Second),
-		journalLim: usecase.NewIntervalLimiter(5 * time.Second),
+		instances: instances,
+		log:       log,
+	}
+}
 
-		log: log,
+// instance - resolves instance selector and returns instance scoped logger.
+func (jh *journalHandlers) instance(name string) (*Instance, *slog.Logger, error) {
+	inst, err := jh.instances.Get(name)
+	if err != nil {
+		return nil, nil, err
 	}
+	return inst, jh.log.With("instance", inst.Name()), nil
 }
 
 // ConnectionJournal - streams the last connection records to the client.
 func (jh *journalHandlers) ConnectionJournal(r *ConnectionJournalRequest, stream grpc.ServerStreamingServer[ConnectionMeta]) error {
 
-	if !jh.journalLim.InLimits() {
+	inst, log, err := jh.instance(r.Instance)
+	if err != nil {
+		return err
+	}
+
+	if !inst.journalLim.InLimits() {
 		return errors.New("too many requests")
 	}
 
-	metaList, err := jh.connJr.LastConnections(stream.Context(), int(r.Last))
+	metaList, err := inst.ConnJournal.LastConnections(stream.Context(), int(r.Last))
 	if err != nil {
-		jh.log.Error("failed to load connection journal", "error", err)
+		log.Error("failed to load connection journal", "error", err)
 		return err
 	}
 
@@ -202,13 +475,48 @@ func (jh *journalHandlers) ConnectionJournal(r *ConnectionJournalRequest, stream
 
 	for _, meta := range metaList {
 		if err := ctx.Err(); err != nil {
-			jh.log.Debug("connection journal stream canceled by client")
+			log.Debug("connection journal stream canceled by client")
 			return nil
 		}
 
 		dto := domain2dtoConnectionMeta(meta)
 		if err := stream.Send(dto); err != nil {
-			jh.log.Warn("failed to send connection journal item", "error", err)
+			log.Warn("failed to send connection journal item", "error", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// StderrJournal - streams the last core stderr lines to the client.
+func (jh *journalHandlers) StderrJournal(r *StderrJournalRequest, stream grpc.ServerStreamingServer[StderrLine]) error {
+
+	inst, log, err := jh.instance(r.Instance)
+	if err != nil {
+		return err
+	}
+
+	if !inst.journalLim.InLimits() {
+		return errors.New("too many requests")
+	}
+
+	lines, err := inst.ErrJournal.LastLines(stream.Context(), int(r.Last))
+	if err != nil {
+		log.Error("failed to load stderr journal", "error", err)
+		return err
+	}
+
+	ctx := stream.Context()
+
+	for _, line := range lines {
+		if err := ctx.Err(); err != nil {
+			log.Debug("stderr journal stream canceled by client")
+			return nil
+		}
+
+		if err := stream.Send(domain2dtoStderrLine(line)); err != nil {
+			log.Warn("failed to send stderr journal item", "error", err)
 			return err
 		}
 	}
@@ -217,7 +525,12 @@ func (jh *journalHandlers) ConnectionJournal(r *ConnectionJournalRequest, stream
 }
 
 func (jh *journalHandlers) NetworkStats(ctx context.Context, r *NetworkStatsRequest) (*NetworkStatsResponse, error) {
-	stats, err := jh.statsNet.StatsNow(ctx)
+	inst, _, err := jh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := inst.Stats.StatsNow(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -227,7 +540,12 @@ func (jh *journalHandlers) NetworkStats(ctx context.Context, r *NetworkStatsRequ
 
 func (jh *journalHandlers) RotateJournal(ctx context.Context, r *RotateJournalRequest) (*RotateJournalResponse, error) {
 
-	if !jh.rotateLim.InLimits() {
+	inst, _, err := jh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	if !inst.rotateLim.InLimits() {
 		return nil, errors.New("too many requests")
 	}
 
@@ -237,7 +555,7 @@ func (jh *journalHandlers) RotateJournal(ctx context.Context, r *RotateJournalRe
 		return nil, err
 	}
 
-	if err := jh.connJr.Rotate(); err != nil {
+	if err := inst.ConnJournal.Rotate(); err != nil {
 		errs = append(errs, err)
 	}
 
@@ -245,7 +563,11 @@ func (jh *journalHandlers) RotateJournal(ctx context.Context, r *RotateJournalRe
 		return nil, err
 	}
 
-	if err := jh.coreJr.Rotate(); err != nil {
+	if err := inst.CoreJournal.Rotate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := inst.ErrJournal.Rotate(); err != nil {
 		errs = append(errs, err)
 	}
 
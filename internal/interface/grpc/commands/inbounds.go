@@ -0,0 +1,126 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package commands
+
+import (
+	context "context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/usecase/confedit"
+)
+
+func domain2dtoInbound(h confedit.Handler) *Inbound {
+	return &Inbound{
+		Tag:      h.Tag,
+		Protocol: h.Protocol,
+		Listen:   h.Listen,
+		Port:     h.Port,
+		Data:     string(h.Raw),
+	}
+}
+
+// ListInbounds - returns inbounds of the stored config.
+func (cmh *coreManageHandlers) ListInbounds(ctx context.Context, r *ListInboundsRequest) (*ListInboundsResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := inst.ConfLoad.LoadConfig()
+	if err != nil {
+		log.Error("failed to load config", "error", err)
+		return nil, attachedStatus(err)
+	}
+
+	list, err := confedit.Inbounds(cfg)
+	if err != nil {
+		log.Error("failed to read inbounds", "error", err)
+		return nil, err
+	}
+
+	resp := &ListInboundsResponse{
+		Inbounds: make([]*Inbound, 0, len(list)),
+	}
+
+	for _, h := range list {
+		resp.Inbounds = append(resp.Inbounds, domain2dtoInbound(h))
+	}
+
+	return resp, nil
+}
+
+// GetInbound - returns the inbound of the stored config by tag.
+func (cmh *coreManageHandlers) GetInbound(ctx context.Context, r *GetInboundRequest) (*Inbound, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := inst.ConfLoad.LoadConfig()
+	if err != nil {
+		log.Error("failed to load config", "error", err)
+		return nil, attachedStatus(err)
+	}
+
+	h, err := confedit.Inbound(cfg, r.Tag)
+	if err != nil {
+		return nil, editStatus(err)
+	}
+
+	return domain2dtoInbound(h), nil
+}
+
+// PutInbound - adds or replaces an inbound of the stored config.
+func (cmh *coreManageHandlers) PutInbound(ctx context.Context, r *PutInboundRequest) (*PutInboundResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("inbound put requested", "tag", r.Tag)
+
+	var created bool
+	edit := func(cfg domain.CoreConfiguration) (err error) {
+		created, err = confedit.PutInbound(cfg, r.Tag, json.RawMessage(r.Data))
+		return err
+	}
+
+	change := domain.ConfigChange{Comment: editComment(r.Comment, fmt.Sprintf("put inbound %q", putTag(r.Tag, r.Data)))}
+
+	rev, err := cmh.editConfig(ctx, inst, log, edit, change, r.Apply)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PutInboundResponse{Created: created, Revision: rev.Number}, nil
+}
+
+// DeleteInbound - removes an inbound from the stored config.
+func (cmh *coreManageHandlers) DeleteInbound(ctx context.Context, r *DeleteInboundRequest) (*DeleteInboundResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("inbound delete requested", "tag", r.Tag)
+
+	edit := func(cfg domain.CoreConfiguration) error {
+		return confedit.DeleteInbound(cfg, r.Tag)
+	}
+
+	change := domain.ConfigChange{Comment: editComment(r.Comment, fmt.Sprintf("delete inbound %q", r.Tag))}
+
+	rev, err := cmh.editConfig(ctx, inst, log, edit, change, r.Apply)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeleteInboundResponse{Revision: rev.Number}, nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package commands
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/utils/usecase"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Instance - dependencies of one supervised core instance.
+type Instance struct {
+	History     domain.ConfigHistory
+	ConfLoad    domain.ConfigLoader
+	ConfTest    domain.ConfigTester
+	CoreState   domain.CoreState
+	CoreJournal CoreJournal
+	ConnJournal ConnectionJournal
+	ErrJournal  StderrJournal
+	Stats       StatsActual
+	Binaries    BinarySwitcher
+	Crashes     domain.CrashHistory
+	Logging     CoreLogging
+
+	name   string
+	editMu sync.Mutex // serializes config saves by uploads, rollbacks and edit RPCs
+
+	confSaveLim    Limiter
+	coreRestartLim Limiter
+	rotateLim      Limiter
+	journalLim     Limiter
+}
+
+func (inst *Instance) Name() string {
+	return inst.name
+}
+
+// Instances - named set of core instances addressed by the gRPC instance selector.
+// Filled at startup and read-only afterwards.
+type Instances struct {
+	list  map[string]*Instance
+	names []string
+}
+
+func NewInstances() *Instances {
+	return &Instances{
+		list: make(map[string]*Instance),
+	}
+}
+
+// Add - registers instance with interval limiters.
+func (is *Instances) Add(name string, inst *Instance) error {
+	if _, ok := is.list[name]; ok {
+		return fmt.Errorf("instance %q already registered", name)
+	}
+
+	inst.name = name
+	inst.confSaveLim = usecase.NewIntervalLimiter(5 * time.Second)
+	inst.coreRestartLim = usecase.NewIntervalLimiter(5 * time.Second)
+	inst.rotateLim = usecase.NewIntervalLimiter(5 * time.Second)
+	inst.journalLim = usecase.NewIntervalLimiter(5 * time.Second)
+
+	is.list[name] = inst
+	is.names = append(is.names, name)
+
+	return nil
+}
+
+// Get - resolves instance selector. Empty selector is allowed only when there is a single instance.
+func (is *Instances) Get(name string) (*Instance, error) {
+	if name == "" {
+		if len(is.names) == 1 {
+			return is.list[is.names[0]], nil
+		}
+		return nil, status.Error(codes.InvalidArgument, "instance selector is required")
+	}
+
+	inst, ok := is.list[name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "instance %q not found", name)
+	}
+
+	return inst, nil
+}
+
+// Names - returns instance names in registration order.
+func (is *Instances) Names() []string {
+	return append([]string(nil), is.names...)
+}
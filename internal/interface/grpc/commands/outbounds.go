@@ -0,0 +1,243 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package commands
+
+import (
+	context "context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/usecase/confedit"
+)
+
+func domain2dtoOutbound(h confedit.Handler) *Outbound {
+	return &Outbound{
+		Tag:      h.Tag,
+		Protocol: h.Protocol,
+		Data:     string(h.Raw),
+	}
+}
+
+func domain2dtoBalancer(b confedit.Balancer) *Balancer {
+	return &Balancer{
+		Tag:         b.Tag,
+		Selector:    b.Selector,
+		FallbackTag: b.FallbackTag,
+		Strategy:    b.Strategy,
+		Data:        string(b.Raw),
+	}
+}
+
+// ListOutbounds - returns outbounds of the stored config.
+func (cmh *coreManageHandlers) ListOutbounds(ctx context.Context, r *ListOutboundsRequest) (*ListOutboundsResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := inst.ConfLoad.LoadConfig()
+	if err != nil {
+		log.Error("failed to load config", "error", err)
+		return nil, attachedStatus(err)
+	}
+
+	list, err := confedit.Outbounds(cfg)
+	if err != nil {
+		log.Error("failed to read outbounds", "error", err)
+		return nil, err
+	}
+
+	resp := &ListOutboundsResponse{
+		Outbounds: make([]*Outbound, 0, len(list)),
+	}
+
+	for _, h := range list {
+		resp.Outbounds = append(resp.Outbounds, domain2dtoOutbound(h))
+	}
+
+	return resp, nil
+}
+
+// GetOutbound - returns the outbound of the stored config by tag.
+func (cmh *coreManageHandlers) GetOutbound(ctx context.Context, r *GetOutboundRequest) (*Outbound, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := inst.ConfLoad.LoadConfig()
+	if err != nil {
+		log.Error("failed to load config", "error", err)
+		return nil, attachedStatus(err)
+	}
+
+	h, err := confedit.Outbound(cfg, r.Tag)
+	if err != nil {
+		return nil, editStatus(err)
+	}
+
+	return domain2dtoOutbound(h), nil
+}
+
+// PutOutbound - adds or replaces an outbound of the stored config.
+func (cmh *coreManageHandlers) PutOutbound(ctx context.Context, r *PutOutboundRequest) (*PutOutboundResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("outbound put requested", "tag", r.Tag)
+
+	var created bool
+	edit := func(cfg domain.CoreConfiguration) (err error) {
+		created, err = confedit.PutOutbound(cfg, r.Tag, json.RawMessage(r.Data))
+		return err
+	}
+
+	change := domain.ConfigChange{Comment: editComment(r.Comment, fmt.Sprintf("put outbound %q", putTag(r.Tag, r.Data)))}
+
+	rev, err := cmh.editConfig(ctx, inst, log, edit, change, r.Apply)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PutOutboundResponse{Created: created, Revision: rev.Number}, nil
+}
+
+// DeleteOutbound - removes an outbound from the stored config. A referenced outbound is kept unless
+// cascade deletion is requested, dependent outbounds and emptied balancers must be named explicitly.
+func (cmh *coreManageHandlers) DeleteOutbound(ctx context.Context, r *DeleteOutboundRequest) (*DeleteOutboundResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("outbound delete requested", "tag", r.Tag, "cascade", r.Cascade, "dependents", r.Dependents)
+
+	var removed []string
+	edit := func(cfg domain.CoreConfiguration) (err error) {
+		removed, err = confedit.DeleteOutbound(cfg, r.Tag, r.Cascade, r.Dependents)
+		return err
+	}
+
+	change := domain.ConfigChange{Comment: editComment(r.Comment, fmt.Sprintf("delete outbound %q", r.Tag))}
+
+	rev, err := cmh.editConfig(ctx, inst, log, edit, change, r.Apply)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeleteOutboundResponse{Revision: rev.Number, Removed: removed}, nil
+}
+
+// ListBalancers - returns routing balancers of the stored config.
+func (cmh *coreManageHandlers) ListBalancers(ctx context.Context, r *ListBalancersRequest) (*ListBalancersResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := inst.ConfLoad.LoadConfig()
+	if err != nil {
+		log.Error("failed to load config", "error", err)
+		return nil, attachedStatus(err)
+	}
+
+	list, err := confedit.Balancers(cfg)
+	if err != nil {
+		log.Error("failed to read balancers", "error", err)
+		return nil, err
+	}
+
+	resp := &ListBalancersResponse{
+		Balancers: make([]*Balancer, 0, len(list)),
+	}
+
+	for _, b := range list {
+		resp.Balancers = append(resp.Balancers, domain2dtoBalancer(b))
+	}
+
+	return resp, nil
+}
+
+// GetBalancer - returns the routing balancer of the stored config by tag.
+func (cmh *coreManageHandlers) GetBalancer(ctx context.Context, r *GetBalancerRequest) (*Balancer, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := inst.ConfLoad.LoadConfig()
+	if err != nil {
+		log.Error("failed to load config", "error", err)
+		return nil, attachedStatus(err)
+	}
+
+	b, err := confedit.GetBalancer(cfg, r.Tag)
+	if err != nil {
+		return nil, editStatus(err)
+	}
+
+	return domain2dtoBalancer(b), nil
+}
+
+// PutBalancer - adds or replaces a routing balancer of the stored config.
+func (cmh *coreManageHandlers) PutBalancer(ctx context.Context, r *PutBalancerRequest) (*PutBalancerResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("balancer put requested", "tag", r.Tag)
+
+	var created bool
+	edit := func(cfg domain.CoreConfiguration) (err error) {
+		created, err = confedit.PutBalancer(cfg, r.Tag, json.RawMessage(r.Data))
+		return err
+	}
+
+	change := domain.ConfigChange{Comment: editComment(r.Comment, fmt.Sprintf("put balancer %q", putTag(r.Tag, r.Data)))}
+
+	rev, err := cmh.editConfig(ctx, inst, log, edit, change, r.Apply)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PutBalancerResponse{Created: created, Revision: rev.Number}, nil
+}
+
+// DeleteBalancer - removes a routing balancer from the stored config. A balancer used by routing rules
+// is kept unless cascade deletion is requested.
+func (cmh *coreManageHandlers) DeleteBalancer(ctx context.Context, r *DeleteBalancerRequest) (*DeleteBalancerResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("balancer delete requested", "tag", r.Tag, "cascade", r.Cascade)
+
+	var removed []string
+	edit := func(cfg domain.CoreConfiguration) (err error) {
+		removed, err = confedit.DeleteBalancer(cfg, r.Tag, r.Cascade)
+		return err
+	}
+
+	change := domain.ConfigChange{Comment: editComment(r.Comment, fmt.Sprintf("delete balancer %q", r.Tag))}
+
+	rev, err := cmh.editConfig(ctx, inst, log, edit, change, r.Apply)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeleteBalancerResponse{Revision: rev.Number, Removed: removed}, nil
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package commands
+
+import (
+	context "context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/usecase/confdiff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListConfigRevisions - returns kept config revisions, newest first.
+func (cmh *coreManageHandlers) ListConfigRevisions(ctx context.Context, r *ListConfigRevisionsRequest) (*ListConfigRevisionsResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	revs, err := inst.History.Revisions()
+	if err != nil {
+		log.Error("failed to list config revisions", "error", err)
+		return nil, attachedStatus(err)
+	}
+
+	if r.Limit > 0 && int(r.Limit) < len(revs) {
+		revs = revs[:r.Limit]
+	}
+
+	resp := &ListConfigRevisionsResponse{
+		Revisions: make([]*ConfigRevision, 0, len(revs)),
+	}
+
+	for _, rev := range revs {
+		resp.Revisions = append(resp.Revisions, domain2dtoConfigRevision(rev))
+	}
+
+	return resp, nil
+}
+
+// GetConfigRevision - returns the config revision with its config in JSON format.
+func (cmh *coreManageHandlers) GetConfigRevision(ctx context.Context, r *GetConfigRevisionRequest) (*GetConfigRevisionResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	rev, err := inst.History.Revision(r.Revision)
+	if err != nil {
+		log.Warn("failed to get config revision", "revision", r.Revision, "error", err)
+		return nil, revisionStatus(err)
+	}
+
+	data, err := json.Marshal(rev.Config)
+	if err != nil {
+		log.Error("failed to marshal config", "error", err)
+		return nil, err
+	}
+
+	return &GetConfigRevisionResponse{
+		Revision: domain2dtoConfigRevision(rev),
+		Data:     string(data),
+	}, nil
+}
+
+// DiffConfigRevisions - returns changes between two config revisions, revision 0 is the current config.
+func (cmh *coreManageHandlers) DiffConfigRevisions(ctx context.Context, r *DiffConfigRevisionsRequest) (*DiffConfigRevisionsResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := revisionConfig(inst, r.From)
+	if err != nil {
+		log.Warn("failed to load config for diff", "revision", r.From, "error", err)
+		return nil, revisionStatus(err)
+	}
+
+	to, err := revisionConfig(inst, r.To)
+	if err != nil {
+		log.Warn("failed to load config for diff", "revision", r.To, "error", err)
+		return nil, revisionStatus(err)
+	}
+
+	changes := confdiff.Diff(from, to)
+
+	resp := &DiffConfigRevisionsResponse{
+		Changes: make([]*ConfigChange, 0, len(changes)),
+	}
+
+	for _, c := range changes {
+		resp.Changes = append(resp.Changes, domain2dtoConfigChange(c))
+	}
+
+	return resp, nil
+}
+
+// RollbackConfig - saves the config of an older revision as a new revision and optionally applies it.
+func (cmh *coreManageHandlers) RollbackConfig(ctx context.Context, r *RollbackConfigRequest) (*RollbackConfigResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Revision == 0 {
+		return nil, status.Error(codes.InvalidArgument, "revision is required")
+	}
+
+	if !inst.confSaveLim.InLimits() {
+		log.Warn("config rollback rejected due to rate limit")
+		return nil, errors.New("too many upload requests")
+	}
+
+	inst.editMu.Lock()
+	defer inst.editMu.Unlock()
+
+	old, err := inst.History.Revision(r.Revision)
+	if err != nil {
+		log.Warn("failed to get config revision", "revision", r.Revision, "error", err)
+		return nil, revisionStatus(err)
+	}
+
+	log.Info("config rollback requested", "revision", r.Revision)
+
+	// the binary or the log settings may have changed since the revision was saved
+	if err := inst.ConfTest.TestConfig(ctx, old.Config); err != nil {
+		if errors.Is(err, domain.ErrConfigRejected) {
+			log.Warn("config revision rejected by core", "revision", r.Revision, "error", err)
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		log.Error("failed to test config", "error", err)
+		return nil, attachedStatus(err)
+	}
+
+	comment := r.Comment
+	if comment == "" {
+		comment = fmt.Sprintf("rollback to revision %d", r.Revision)
+	}
+
+	rev, err := inst.History.SaveRevision(old.Config, domain.ConfigChange{
+		Author:  requestAuthor(ctx),
+		Comment: comment,
+	})
+	if err != nil {
+		log.Error("failed to save config", "error", err)
+		return nil, attachedStatus(err)
+	}
+
+	if r.RestartCore {
+		log.Info("core config apply requested", "drain", r.Drain)
+
+		apply := inst.CoreState.ApplyConfig
+		if r.Drain {
+			apply = inst.CoreState.DrainApplyConfig
+		}
+
+		if err := apply(); err != nil {
+			log.Error("core config apply failed", "error", err)
+			return nil, err
+		}
+	}
+
+	log.Info("config rolled back", "revision", r.Revision, "new_revision", rev.Number)
+	return &RollbackConfigResponse{Revision: domain2dtoConfigRevision(rev)}, nil
+}
+
+// revisionConfig - returns config of the revision, the current config for revision 0.
+func revisionConfig(inst *Instance, number uint64) (domain.CoreConfiguration, error) {
+	if number == 0 {
+		return inst.ConfLoad.LoadConfig()
+	}
+
+	rev, err := inst.History.Revision(number)
+	if err != nil {
+		return nil, err
+	}
+
+	return rev.Config, nil
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package commands
+
+import (
+	context "context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+
+	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/usecase/confedit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func domain2dtoRoutingRule(r confedit.Rule) *RoutingRule {
+	return &RoutingRule{
+		Tag:         r.Tag,
+		Index:       int32(r.Index),
+		OutboundTag: r.OutboundTag,
+		BalancerTag: r.BalancerTag,
+		Data:        string(r.Raw),
+	}
+}
+
+// ListRoutingRules - returns routing rules of the stored config in matching order.
+func (cmh *coreManageHandlers) ListRoutingRules(ctx context.Context, r *ListRoutingRulesRequest) (*ListRoutingRulesResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := inst.ConfLoad.LoadConfig()
+	if err != nil {
+		log.Error("failed to load config", "error", err)
+		return nil, attachedStatus(err)
+	}
+
+	list, err := confedit.Rules(cfg)
+	if err != nil {
+		log.Error("failed to read routing rules", "error", err)
+		return nil, err
+	}
+
+	resp := &ListRoutingRulesResponse{
+		Rules: make([]*RoutingRule, 0, len(list)),
+	}
+
+	for _, rule := range list {
+		resp.Rules = append(resp.Rules, domain2dtoRoutingRule(rule))
+	}
+
+	return resp, nil
+}
+
+// InsertRoutingRule - inserts a routing rule at the position.
+func (cmh *coreManageHandlers) InsertRoutingRule(ctx context.Context, r *InsertRoutingRuleRequest) (*InsertRoutingRuleResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("routing rule insert requested", "position", r.Position)
+
+	edit := func(cfg domain.CoreConfiguration) error {
+		return confedit.InsertRule(cfg, int(r.Position), json.RawMessage(r.Data))
+	}
+
+	change := domain.ConfigChange{Comment: editComment(r.Comment, fmt.Sprintf("insert routing rule %q", dataRuleTag(r.Data)))}
+
+	rev, err := cmh.editConfig(ctx, inst, log, edit, change, r.Apply)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InsertRoutingRuleResponse{Revision: rev.Number}, nil
+}
+
+// MoveRoutingRule - moves a routing rule to the position.
+func (cmh *coreManageHandlers) MoveRoutingRule(ctx context.Context, r *MoveRoutingRuleRequest) (*MoveRoutingRuleResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("routing rule move requested", "tag", r.Tag, "position", r.Position)
+
+	edit := func(cfg domain.CoreConfiguration) error {
+		return confedit.MoveRule(cfg, r.Tag, int(r.Position))
+	}
+
+	change := domain.ConfigChange{Comment: editComment(r.Comment, fmt.Sprintf("move routing rule %q to %d", r.Tag, r.Position))}
+
+	rev, err := cmh.editConfig(ctx, inst, log, edit, change, r.Apply)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MoveRoutingRuleResponse{Revision: rev.Number}, nil
+}
+
+// ReplaceRoutingRule - replaces a routing rule keeping its position.
+func (cmh *coreManageHandlers) ReplaceRoutingRule(ctx context.Context, r *ReplaceRoutingRuleRequest) (*ReplaceRoutingRuleResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("routing rule replace requested", "tag", r.Tag)
+
+	edit := func(cfg domain.CoreConfiguration) error {
+		return confedit.ReplaceRule(cfg, r.Tag, json.RawMessage(r.Data))
+	}
+
+	change := domain.ConfigChange{Comment: editComment(r.Comment, fmt.Sprintf("replace routing rule %q", r.Tag))}
+
+	rev, err := cmh.editConfig(ctx, inst, log, edit, change, r.Apply)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplaceRoutingRuleResponse{Revision: rev.Number}, nil
+}
+
+// DeleteRoutingRule - removes a routing rule.
+func (cmh *coreManageHandlers) DeleteRoutingRule(ctx context.Context, r *DeleteRoutingRuleRequest) (*DeleteRoutingRuleResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("routing rule delete requested", "tag", r.Tag)
+
+	edit := func(cfg domain.CoreConfiguration) error {
+		return confedit.DeleteRule(cfg, r.Tag)
+	}
+
+	change := domain.ConfigChange{Comment: editComment(r.Comment, fmt.Sprintf("delete routing rule %q", r.Tag))}
+
+	rev, err := cmh.editConfig(ctx, inst, log, edit, change, r.Apply)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeleteRoutingRuleResponse{Revision: rev.Number}, nil
+}
+
+// MatchRoute - simulates routing of a connection by the stored config.
+func (cmh *coreManageHandlers) MatchRoute(ctx context.Context, r *MatchRouteRequest) (*MatchRouteResponse, error) {
+
+	inst, log, err := cmh.instance(r.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	probe := confedit.Probe{
+		InboundTag: r.InboundTag,
+		Network:    r.Network,
+		Domain:     r.Domain,
+		Port:       int(r.Port),
+		SourcePort: int(r.SourcePort),
+		Protocol:   r.Protocol,
+		User:       r.User,
+	}
+
+	if probe.IP, err = parseProbeAddr(r.Ip); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "ip: %v", err)
+	}
+	if probe.SourceIP, err = parseProbeAddr(r.SourceIp); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "source ip: %v", err)
+	}
+
+	cfg, err := inst.ConfLoad.LoadConfig()
+	if err != nil {
+		log.Error("failed to load config", "error", err)
+		return nil, attachedStatus(err)
+	}
+
+	m, err := confedit.MatchRoute(cfg, probe)
+	if err != nil {
+		log.Error("failed to match route", "error", err)
+		return nil, err
+	}
+
+	resp := &MatchRouteResponse{
+		Matched:     m.Matched,
+		OutboundTag: m.Default,
+	}
+
+	if m.Matched {
+		resp.Rule = domain2dtoRoutingRule(m.Rule)
+		resp.OutboundTag = m.Rule.OutboundTag
+		resp.BalancerTag = m.Rule.BalancerTag
+	}
+
+	for _, rule := range m.Undetermined {
+		resp.Undetermined = append(resp.Undetermined, domain2dtoRoutingRule(rule))
+	}
+
+	return resp, nil
+}
+
+// parseProbeAddr - parses an optional address of a route probe.
+func parseProbeAddr(s string) (netip.Addr, error) {
+	if s == "" {
+		return netip.Addr{}, nil
+	}
+	return netip.ParseAddr(s)
+}
+
+// dataRuleTag - ruleTag of a rule in JSON format.
+func dataRuleTag(data string) string {
+	var rule struct {
+		RuleTag string `json:"ruleTag"`
+	}
+	_ = json.Unmarshal([]byte(data), &rule)
+	return rule.RuleTag
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package commands
+
+import (
+	context "context"
+	"log/slog"
+
+	"github.com/eterline/xraymon/internal/domain"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ScheduleLister interface {
+	List() []domain.ScheduledJob
+}
+
+// scheduleHandlers - gRPC handler for the built-in scheduler.
+type scheduleHandlers struct {
+	schedule ScheduleLister
+
+	log *slog.Logger
+
+	UnimplementedScheduleProviderServer
+}
+
+func NewScheduleHandlers(schedule ScheduleLister, log *slog.Logger) *scheduleHandlers {
+	return &scheduleHandlers{
+		schedule: schedule,
+		log:      log,
+	}
+}
+
+// ListSchedule - returns scheduled jobs with their next run times.
+func (sh *scheduleHandlers) ListSchedule(ctx context.Context, r *ListScheduleRequest) (*ListScheduleResponse, error) {
+	resp := &ListScheduleResponse{}
+
+	for _, job := range sh.schedule.List() {
+		if r.Instance != "" && job.Instance != r.Instance {
+			continue
+		}
+		resp.Jobs = append(resp.Jobs, domain2dtoScheduledJob(job))
+	}
+
+	return resp, nil
+}
+
+func domain2dtoScheduledJob(j domain.ScheduledJob) *ScheduledJob {
+	dto := &ScheduledJob{
+		Name:       j.Name,
+		Instance:   j.Instance,
+		Action:     j.Action,
+		Cron:       j.Cron,
+		Window:     j.Window,
+		WindowCron: j.WindowCron,
+		LastError:  j.LastError,
+	}
+
+	if j.WindowDuration > 0 {
+		dto.WindowDuration = durationpb.New(j.WindowDuration)
+	}
+	if !j.NextRun.IsZero() {
+		dto.NextRun = timestamppb.New(j.NextRun)
+	}
+	if !j.LastRun.IsZero() {
+		dto.LastRun = timestamppb.New(j.LastRun)
+	}
+
+	return dto
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package confdiff
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/eterline/xraymon/internal/domain"
+)
+
+// Op - kind of a config change.
+type Op string
+
+const (
+	OpAdded   Op = "added"
+	OpRemoved Op = "removed"
+	OpChanged Op = "changed"
+)
+
+// Change - one difference between two configs.
+type Change struct {
+	Op   Op
+	Path string          // JSON pointer (RFC 6901) of the value, array items by index in its config
+	Tag  string          // handler tag or ruleTag of a routing rule, empty for other values
+	Old  json.RawMessage // empty for added values
+	New  json.RawMessage // empty for removed values
+}
+
+// Diff - returns differences between configs old and new ordered by section. Objects are compared
+// member by member, inbounds and outbounds are matched by tag and routing rules by index.
+// Other arrays and scalars are compared as a whole.
+func Diff(old, new domain.CoreConfiguration) []Change {
+	var changes []Change
+	for _, key := range sectionKeys(old, new) {
+		changes = diffValue(changes, Pointer("", key), old[key], new[key])
+	}
+	return changes
+}
+
+func diffValue(changes []Change, path string, old, new json.RawMessage) []Change {
+	switch {
+	case isNull(old) && isNull(new):
+		return changes
+	case isNull(old):
+		return append(changes, Change{Op: OpAdded, Path: path, New: new})
+	case isNull(new):
+		return append(changes, Change{Op: OpRemoved, Path: path, Old: old})
+	case EqualJSON(old, new):
+		return changes
+	}
+
+	switch path {
+	case "/inbounds", "/outbounds":
+		if handlers, ok := diffHandlers(path, old, new); ok {
+			return append(changes, handlers...)
+		}
+	case "/routing/rules":
+		if rules, ok := diffRules(path, old, new); ok {
+			return append(changes, rules...)
+		}
+	}
+
+	oldObj, okOld := object(old)
+	newObj, okNew := object(new)
+	if !okOld || !okNew {
+		return append(changes, Change{Op: OpChanged, Path: path, Old: old, New: new})
+	}
+
+	for _, key := range sectionKeys(oldObj, newObj) {
+		changes = diffValue(changes, Pointer(path, key), oldObj[key], newObj[key])
+	}
+
+	return changes
+}
+
+// diffHandlers - matches handlers by tag. Removed handlers are addressed by their index in old,
+// others by the index in new. Returns false when handlers can't be addressed by tag.
+func diffHandlers(path string, old, new json.RawMessage) ([]Change, bool) {
+	oldList, oldTags, ok := indexHandlers(old)
+	if !ok {
+		return nil, false
+	}
+
+	newList, newTags, ok := indexHandlers(new)
+	if !ok {
+		return nil, false
+	}
+
+	var changes []Change
+
+	for i, tag := range oldTags {
+		if _, ok := newList[tag]; !ok {
+			changes = append(changes, Change{Op: OpRemoved, Path: Pointer(path, strconv.Itoa(i)), Tag: tag, Old: oldList[tag]})
+		}
+	}
+
+	for i, tag := range newTags {
+		prev, ok := oldList[tag]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Op: OpAdded, Path: Pointer(path, strconv.Itoa(i)), Tag: tag, New: newList[tag]})
+		case !EqualJSON(prev, newList[tag]):
+			changes = append(changes, Change{Op: OpChanged, Path: Pointer(path, strconv.Itoa(i)), Tag: tag, Old: prev, New: newList[tag]})
+		}
+	}
+
+	return changes, true
+}
+
+// diffRules - compares routing rules position by position.
+func diffRules(path string, old, new json.RawMessage) ([]Change, bool) {
+	var oldRules, newRules []json.RawMessage
+
+	if !isNull(old) && json.Unmarshal(old, &oldRules) != nil {
+		return nil, false
+	}
+	if !isNull(new) && json.Unmarshal(new, &newRules) != nil {
+		return nil, false
+	}
+
+	var changes []Change
+
+	for i := range max(len(oldRules), len(newRules)) {
+		c := Change{Path: Pointer(path, strconv.Itoa(i))}
+
+		switch {
+		case i >= len(oldRules):
+			c.Op, c.New, c.Tag = OpAdded, newRules[i], ruleTag(newRules[i])
+		case i >= len(newRules):
+			c.Op, c.Old, c.Tag = OpRemoved, oldRules[i], ruleTag(oldRules[i])
+		case !EqualJSON(oldRules[i], newRules[i]):
+			c.Op, c.Old, c.New, c.Tag = OpChanged, oldRules[i], newRules[i], ruleTag(newRules[i])
+		default:
+			continue
+		}
+
+		changes = append(changes, c)
+	}
+
+	return changes, true
+}
+
+func ruleTag(raw json.RawMessage) string {
+	var rule struct {
+		RuleTag string `json:"ruleTag"`
+	}
+	_ = json.Unmarshal(raw, &rule)
+	return rule.RuleTag
+}
+
+// object - decodes a JSON object, false for any other value.
+func object(raw json.RawMessage) (map[string]json.RawMessage, bool) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || raw[0] != '{' {
+		return nil, false
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, false
+	}
+
+	return obj, true
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Pointer - appends an escaped reference token to the JSON pointer.
+func Pointer(base, token string) string {
+	return base + "/" + pointerEscaper.Replace(token)
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package confdiff
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/eterline/xraymon/internal/domain"
+)
+
+// hotSections - top-level sections that can be changed on a running core through HandlerService.
+var hotSections = map[string]struct{}{
+	"inbounds":  {},
+	"outbounds": {},
+}
+
+// HandlerChanges - handler operations for one section. Changed handlers are removed and added again.
+type HandlerChanges struct {
+	Remove []string          // tags of handlers to remove
+	Add    []json.RawMessage // handler objects to add
+}
+
+func (c HandlerChanges) Empty() bool {
+	return len(c.Remove) == 0 && len(c.Add) == 0
+}
+
+// Plan - how a config change can be applied to the running core.
+type Plan struct {
+	Restart   []string // sections that can't be hot-applied and require a core restart
+	Inbounds  HandlerChanges
+	Outbounds HandlerChanges
+}
+
+func (p Plan) NeedRestart() bool {
+	return len(p.Restart) > 0
+}
+
+func (p Plan) Empty() bool {
+	return !p.NeedRestart() && p.Inbounds.Empty() && p.Outbounds.Empty()
+}
+
+// Compare - builds an apply plan for switching the running config old to new.
+func Compare(old, new domain.CoreConfiguration) Plan {
+	var plan Plan
+
+	for _, key := range sectionKeys(old, new) {
+		if _, hot := hotSections[key]; hot {
+			continue
+		}
+		if !EqualJSON(old[key], new[key]) {
+			plan.Restart = append(plan.Restart, key)
+		}
+	}
+
+	in, ok := compareHandlers(old["inbounds"], new["inbounds"], false)
+	if !ok {
+		plan.Restart = append(plan.Restart, "inbounds")
+	}
+
+	out, ok := compareHandlers(old["outbounds"], new["outbounds"], true)
+	if !ok {
+		plan.Restart = append(plan.Restart, "outbounds")
+	}
+
+	if !plan.NeedRestart() {
+		plan.Inbounds = in
+		plan.Outbounds = out
+	}
+
+	return plan
+}
+
+// compareHandlers - diffs handler arrays by tag. Returns false when handlers can't be addressed by tag
+// or, for outbounds, when the default (first) handler changes.
+func compareHandlers(old, new json.RawMessage, keepFirst bool) (HandlerChanges, bool) {
+	var changes HandlerChanges
+
+	oldList, oldTags, ok := indexHandlers(old)
+	if !ok {
+		return changes, false
+	}
+
+	newList, newTags, ok := indexHandlers(new)
+	if !ok {
+		return changes, false
+	}
+
+	if keepFirst && len(oldTags) > 0 && len(newTags) > 0 {
+		if oldTags[0] != newTags[0] || !EqualJSON(oldList[oldTags[0]], newList[newTags[0]]) {
+			return changes, false
+		}
+	}
+
+	for _, tag := range oldTags {
+		if _, ok := newList[tag]; !ok {
+			changes.Remove = append(changes.Remove, tag)
+		}
+	}
+
+	for _, tag := range newTags {
+		prev, ok := oldList[tag]
+		switch {
+		case !ok:
+			changes.Add = append(changes.Add, newList[tag])
+		case !EqualJSON(prev, newList[tag]):
+			changes.Remove = append(changes.Remove, tag)
+			changes.Add = append(changes.Add, newList[tag])
+		}
+	}
+
+	return changes, true
+}
+
+// indexHandlers - maps handlers by tag and keeps their order. Fails on missing or duplicate tags.
+func indexHandlers(raw json.RawMessage) (map[string]json.RawMessage, []string, bool) {
+	list := map[string]json.RawMessage{}
+
+	if isNull(raw) {
+		return list, nil, true
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, nil, false
+	}
+
+	tags := make([]string, 0, len(items))
+	for _, item := range items {
+		var h struct {
+			Tag string `json:"tag"`
+		}
+		if err := json.Unmarshal(item, &h); err != nil || h.Tag == "" {
+			return nil, nil, false
+		}
+		if _, dup := list[h.Tag]; dup {
+			return nil, nil, false
+		}
+
+		list[h.Tag] = item
+		tags = append(tags, h.Tag)
+	}
+
+	return list, tags, true
+}
+
+// EqualJSON - compares JSON values ignoring formatting and object key order.
+func EqualJSON(a, b json.RawMessage) bool {
+	if isNull(a) || isNull(b) {
+		return isNull(a) && isNull(b)
+	}
+
+	if bytes.Equal(a, b) {
+		return true
+	}
+
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(va, vb)
+}
+
+func isNull(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) == 0 || bytes.Equal(raw, []byte("null"))
+}
+
+func sectionKeys(a, b domain.CoreConfiguration) []string {
+	seen := make(map[string]struct{}, len(a)+len(b))
+	keys := make([]string, 0, len(a)+len(b))
+
+	for _, cfg := range []domain.CoreConfiguration{a, b} {
+		for key := range cfg {
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
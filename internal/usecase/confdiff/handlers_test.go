@@ -0,0 +1,155 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package confdiff_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/usecase/confdiff"
+)
+
+func cfg(sections map[string]string) domain.CoreConfiguration {
+	c := domain.CoreConfiguration{}
+	for k, v := range sections {
+		c[k] = json.RawMessage(v)
+	}
+	return c
+}
+
+func Test_CompareHandlers(t *testing.T) {
+	old := cfg(map[string]string{
+		"inbounds":  `[{"tag":"a","port":1},{"tag":"b","port":2}]`,
+		"outbounds": `[{"tag":"direct","protocol":"freedom"},{"tag":"block","protocol":"blackhole"}]`,
+		"routing":   `{"rules":[]}`,
+	})
+
+	new := cfg(map[string]string{
+		"inbounds":  `[{"port":1, "tag":"a"},{"tag":"b","port":3},{"tag":"c","port":4}]`,
+		"outbounds": `[{"tag":"direct","protocol":"freedom"}]`,
+		"routing":   `{ "rules": [] }`,
+	})
+
+	plan := confdiff.Compare(old, new)
+
+	if plan.NeedRestart() {
+		t.Fatalf("unexpected restart sections: %v", plan.Restart)
+	}
+
+	if want := []string{"b"}; !reflect.DeepEqual(plan.Inbounds.Remove, want) {
+		t.Errorf("inbounds remove = %v, want %v", plan.Inbounds.Remove, want)
+	}
+
+	if len(plan.Inbounds.Add) != 2 {
+		t.Errorf("inbounds add = %d items, want 2", len(plan.Inbounds.Add))
+	}
+
+	if want := []string{"block"}; !reflect.DeepEqual(plan.Outbounds.Remove, want) {
+		t.Errorf("outbounds remove = %v, want %v", plan.Outbounds.Remove, want)
+	}
+
+	if len(plan.Outbounds.Add) != 0 {
+		t.Errorf("outbounds add = %d items, want 0", len(plan.Outbounds.Add))
+	}
+}
+
+func Test_CompareRestart(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new map[string]string
+		expected []string
+	}{
+		{
+			"routing changed",
+			map[string]string{"routing": `{"rules":[]}`},
+			map[string]string{"routing": `{"rules":[{"outboundTag":"block"}]}`},
+			[]string{"routing"},
+		},
+		{
+			"dns added",
+			map[string]string{},
+			map[string]string{"dns": `{"servers":["1.1.1.1"]}`},
+			[]string{"dns"},
+		},
+		{
+			"untagged inbound",
+			map[string]string{"inbounds": `[]`},
+			map[string]string{"inbounds": `[{"port":1}]`},
+			[]string{"inbounds"},
+		},
+		{
+			"default outbound changed",
+			map[string]string{"outbounds": `[{"tag":"direct"},{"tag":"block"}]`},
+			map[string]string{"outbounds": `[{"tag":"block"},{"tag":"direct"}]`},
+			[]string{"outbounds"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := confdiff.Compare(cfg(tt.old), cfg(tt.new))
+			if !reflect.DeepEqual(plan.Restart, tt.expected) {
+				t.Errorf("restart = %v, want %v", plan.Restart, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_CompareEmpty(t *testing.T) {
+	c := cfg(map[string]string{
+		"inbounds": `[{"tag":"a"}]`,
+		"routing":  `{"rules":[]}`,
+	})
+
+	if plan := confdiff.Compare(c, c); !plan.Empty() {
+		t.Errorf("plan for equal configs is not empty: %+v", plan)
+	}
+}
+
+func Test_Diff(t *testing.T) {
+	old := cfg(map[string]string{
+		"log":       `{"loglevel":"warning"}`,
+		"inbounds":  `[{"tag":"a","port":1},{"tag":"b","port":2}]`,
+		"outbounds": `[{"tag":"direct"},{"tag":"block"}]`,
+		"routing":   `{"domainStrategy":"AsIs","rules":[{"ruleTag":"r1","outboundTag":"direct"}]}`,
+		"dns":       `{"servers":["1.1.1.1"]}`,
+	})
+
+	new := cfg(map[string]string{
+		"inbounds":  `[{"tag":"b","port":3},{"tag":"c","port":4}]`,
+		"outbounds": `[{"tag":"block"},{"tag":"direct"}]`,
+		"routing":   `{"rules":[{"ruleTag":"r1","outboundTag":"block"},{"outboundTag":"direct"}],"domainStrategy":"AsIs","a/b":1}`,
+		"dns":       `{ "servers": ["1.1.1.1"] }`,
+		"policy":    `{}`,
+	})
+
+	expected := []struct {
+		op   confdiff.Op
+		path string
+		tag  string
+	}{
+		{confdiff.OpRemoved, "/inbounds/0", "a"},
+		{confdiff.OpChanged, "/inbounds/0", "b"},
+		{confdiff.OpAdded, "/inbounds/1", "c"},
+		{confdiff.OpRemoved, "/log", ""},
+		{confdiff.OpAdded, "/policy", ""},
+		{confdiff.OpAdded, "/routing/a~1b", ""},
+		{confdiff.OpChanged, "/routing/rules/0", "r1"},
+		{confdiff.OpAdded, "/routing/rules/1", ""},
+	}
+
+	changes := confdiff.Diff(old, new)
+	if len(changes) != len(expected) {
+		t.Fatalf("got %d changes, want %d: %+v", len(changes), len(expected), changes)
+	}
+
+	for i, want := range expected {
+		c := changes[i]
+		if c.Op != want.op || c.Path != want.path || c.Tag != want.tag {
+			t.Errorf("change %d = %s %s %q, want %s %s %q", i, c.Op, c.Path, c.Tag, want.op, want.path, want.tag)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package confedit
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/eterline/xraymon/internal/domain"
+)
+
+// Balancer - routing balancer with its identifying fields.
+type Balancer struct {
+	Tag         string
+	Selector    []string // outbound tag prefixes
+	FallbackTag string
+	Strategy    string
+	Raw         json.RawMessage
+}
+
+// balancerHeader - fields of a balancer object the editor looks at.
+type balancerHeader struct {
+	Tag         string   `json:"tag"`
+	Selector    []string `json:"selector"`
+	FallbackTag string   `json:"fallbackTag"`
+	Strategy    struct {
+		Type string `json:"type"`
+	} `json:"strategy"`
+}
+
+// Balancers - returns balancers of the routing section in config order.
+func Balancers(cfg domain.CoreConfiguration) ([]Balancer, error) {
+	obj, err := routing(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := routingArray(obj, balancersKey)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]Balancer, 0, len(items))
+	for i, item := range items {
+		var b balancerHeader
+		if err := decodeObject(item, &b); err != nil {
+			return nil, fmt.Errorf("%s.%s[%d]: %w", routingKey, balancersKey, i, err)
+		}
+
+		list = append(list, Balancer{
+			Tag:         b.Tag,
+			Selector:    b.Selector,
+			FallbackTag: b.FallbackTag,
+			Strategy:    b.Strategy.Type,
+			Raw:         item,
+		})
+	}
+
+	return list, nil
+}
+
+// GetBalancer - returns the balancer with the tag.
+func GetBalancer(cfg domain.CoreConfiguration, tag string) (Balancer, error) {
+	list, err := Balancers(cfg)
+	if err != nil {
+		return Balancer{}, err
+	}
+
+	for _, b := range list {
+		if b.Tag == tag {
+			return b, nil
+		}
+	}
+
+	return Balancer{}, fmt.Errorf("%w: balancer %q", ErrNotFound, tag)
+}
+
+// PutBalancer - replaces the balancer with tag key or adds a new one, see putTagged. The selector is
+// required, the fallback must be an existing outbound. A balancer still used by routing rules can't be
+// renamed. Returns true for a new balancer.
+func PutBalancer(cfg domain.CoreConfiguration, key string, raw json.RawMessage) (bool, error) {
+	var b balancerHeader
+	if err := decodeObject(raw, &b); err != nil {
+		return false, err
+	}
+
+	if len(b.Selector) == 0 {
+		return false, fmt.Errorf("%w: balancer selector is required", ErrInvalid)
+	}
+
+	if b.FallbackTag != "" {
+		if _, err := Outbound(cfg, b.FallbackTag); err != nil {
+			return false, fmt.Errorf("%w: balancer fallback: %v", ErrInvalid, err)
+		}
+	}
+
+	obj, err := routing(cfg)
+	if err != nil {
+		return false, err
+	}
+
+	if key != "" && b.Tag != "" && b.Tag != key {
+		refs, err := dropRules(maps.Clone(obj), nil, map[string]bool{key: true})
+		if err != nil {
+			return false, err
+		}
+		if len(refs) > 0 {
+			return false, &ReferencedError{Kind: "balancer", Tag: key, Refs: refs}
+		}
+	}
+
+	items, err := routingArray(obj, balancersKey)
+	if err != nil {
+		return false, err
+	}
+
+	items, created, err := putTagged(items, routingKey+"."+balancersKey, "balancer", key, raw, nil)
+	if err != nil {
+		return false, err
+	}
+
+	if err := setRoutingArray(obj, balancersKey, items); err != nil {
+		return false, err
+	}
+
+	return created, setRouting(cfg, obj)
+}
+
+// DeleteBalancer - removes the balancer with the tag. The balancer must not be used by routing rules,
+// with cascade the rules are removed too. Returns the removed references.
+func DeleteBalancer(cfg domain.CoreConfiguration, tag string, cascade bool) ([]string, error) {
+	obj, err := routing(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := routingArray(obj, balancersKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if items, err = deleteTagged(items, "balancer", tag); err != nil {
+		return nil, err
+	}
+
+	refs, err := dropRules(obj, nil, map[string]bool{tag: true})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(refs) > 0 && !cascade {
+		return nil, &ReferencedError{Kind: "balancer", Tag: tag, Refs: refs}
+	}
+
+	if err := setRoutingArray(obj, balancersKey, items); err != nil {
+		return nil, err
+	}
+
+	return refs, setRouting(cfg, obj)
+}
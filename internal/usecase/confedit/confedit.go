@@ -0,0 +1,287 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package confedit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/eterline/xraymon/internal/domain"
+)
+
+// Edit errors, details are wrapped around them.
+var (
+	ErrNotFound = errors.New("not found")
+	ErrConflict = errors.New("conflict")
+	ErrInvalid  = errors.New("invalid")
+
+	ErrReferenced = errors.New("referenced")
+)
+
+// ReferencedError - the object can't be removed or renamed while other parts of the config refer to it.
+type ReferencedError struct {
+	Kind       string
+	Tag        string
+	Refs       []string // JSON pointers of the references
+	Dependents []string // tags of the objects a cascade delete removes only when they are named
+}
+
+func (e *ReferencedError) Error() string {
+	msg := fmt.Sprintf("%s %q is referenced by %s", e.Kind, e.Tag, strings.Join(e.Refs, ", "))
+	if len(e.Dependents) > 0 {
+		msg += fmt.Sprintf(", name dependents %s to delete them", strings.Join(e.Dependents, ", "))
+	}
+	return msg
+}
+
+func (e *ReferencedError) Unwrap() error {
+	return ErrReferenced
+}
+
+// Handler - inbound or outbound object with its identifying fields.
+type Handler struct {
+	Tag      string
+	Protocol string
+	Listen   string // inbounds only
+	Port     string // inbounds only, as written in the config
+	Raw      json.RawMessage
+}
+
+// handlerHeader - fields of a handler object the editor looks at.
+type handlerHeader struct {
+	Tag      string          `json:"tag"`
+	Protocol string          `json:"protocol"`
+	Listen   string          `json:"listen"`
+	Port     json.RawMessage `json:"port"`
+}
+
+func (h handlerHeader) handler(raw json.RawMessage) Handler {
+	return Handler{
+		Tag:      h.Tag,
+		Protocol: h.Protocol,
+		Listen:   h.Listen,
+		Port:     portString(h.Port),
+		Raw:      raw,
+	}
+}
+
+// decodeObject - decodes the header of a JSON object, other JSON values are invalid.
+func decodeObject(raw json.RawMessage, v any) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || raw[0] != '{' {
+		return fmt.Errorf("%w: JSON object expected", ErrInvalid)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	return nil
+}
+
+// section - decodes an array section of the config, missing or null sections are empty.
+func section(cfg domain.CoreConfiguration, key string) ([]json.RawMessage, error) {
+	return decodeArray(cfg[key], key)
+}
+
+func decodeArray(raw json.RawMessage, name string) ([]json.RawMessage, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", name, err)
+	}
+
+	return items, nil
+}
+
+func setSection(cfg domain.CoreConfiguration, key string, items []json.RawMessage) error {
+	data, err := encodeArray(items, key)
+	if err != nil {
+		return err
+	}
+
+	cfg[key] = data
+	return nil
+}
+
+func encodeArray(items []json.RawMessage, name string) (json.RawMessage, error) {
+	if items == nil {
+		items = []json.RawMessage{}
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s: %w", name, err)
+	}
+
+	return data, nil
+}
+
+// handlers - returns handlers of an inbounds or outbounds section.
+func handlers(cfg domain.CoreConfiguration, key string) ([]Handler, error) {
+	items, err := section(cfg, key)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]Handler, 0, len(items))
+	for i, item := range items {
+		var h handlerHeader
+		if err := decodeObject(item, &h); err != nil {
+			return nil, fmt.Errorf("%s[%d]: %w", key, i, err)
+		}
+		list = append(list, h.handler(item))
+	}
+
+	return list, nil
+}
+
+// handler - returns the handler of a section by tag.
+func handler(cfg domain.CoreConfiguration, key, kind, tag string) (Handler, error) {
+	list, err := handlers(cfg, key)
+	if err != nil {
+		return Handler{}, err
+	}
+
+	for _, h := range list {
+		if h.Tag == tag {
+			return h, nil
+		}
+	}
+
+	return Handler{}, fmt.Errorf("%w: %s %q", ErrNotFound, kind, tag)
+}
+
+// putHandler - puts the handler into a section of the config, see putTagged.
+func putHandler(cfg domain.CoreConfiguration, section, kind, key string, raw json.RawMessage, check func(h, other handlerHeader) error) (bool, error) {
+	items, err := decodeArray(cfg[section], section)
+	if err != nil {
+		return false, err
+	}
+
+	items, created, err := putTagged(items, section, kind, key, raw, check)
+	if err != nil {
+		return false, err
+	}
+
+	return created, setSection(cfg, section, items)
+}
+
+// putTagged - replaces the item with tag key, appends a new one when key is empty or not used yet.
+// The item may change its tag, the new tag must not belong to another item of the list.
+// An item without tag gets key. check validates the item against every other one.
+func putTagged(items []json.RawMessage, name, kind, key string, raw json.RawMessage, check func(h, other handlerHeader) error) ([]json.RawMessage, bool, error) {
+	var h handlerHeader
+	if err := decodeObject(raw, &h); err != nil {
+		return nil, false, err
+	}
+
+	if h.Tag == "" {
+		if key == "" {
+			return nil, false, fmt.Errorf("%w: %s tag is required", ErrInvalid, kind)
+		}
+
+		var err error
+		if raw, err = setMember(raw, "tag", key); err != nil {
+			return nil, false, err
+		}
+		h.Tag = key
+	}
+
+	pos := -1
+	for i, item := range items {
+		var other handlerHeader
+		if err := decodeObject(item, &other); err != nil {
+			return nil, false, fmt.Errorf("%s[%d]: %w", name, i, err)
+		}
+
+		if key != "" && other.Tag == key {
+			pos = i
+			continue
+		}
+		if other.Tag == h.Tag {
+			return nil, false, fmt.Errorf("%w: %s %q already exists", ErrConflict, kind, h.Tag)
+		}
+		if check != nil {
+			if err := check(h, other); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+
+	if key != "" && pos < 0 && h.Tag != key {
+		return nil, false, fmt.Errorf("%w: %s %q", ErrNotFound, kind, key)
+	}
+
+	result := slices.Clone(items)
+
+	created := pos < 0
+	if created {
+		result = append(result, raw)
+	} else {
+		result[pos] = raw
+	}
+
+	return result, created, nil
+}
+
+// setMember - returns the JSON object with the member set to value.
+func setMember(raw json.RawMessage, name string, value any) (json.RawMessage, error) {
+	var obj map[string]json.RawMessage
+	if err := decodeObject(raw, &obj); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	obj[name] = data
+
+	return json.Marshal(obj)
+}
+
+// deleteMember - returns the JSON object without the member.
+func deleteMember(raw json.RawMessage, name string) (json.RawMessage, error) {
+	var obj map[string]json.RawMessage
+	if err := decodeObject(raw, &obj); err != nil {
+		return nil, err
+	}
+
+	delete(obj, name)
+	return json.Marshal(obj)
+}
+
+// deleteHandler - removes the handler with the tag from a section.
+func deleteHandler(cfg domain.CoreConfiguration, section, kind, tag string) error {
+	items, err := decodeArray(cfg[section], section)
+	if err != nil {
+		return err
+	}
+
+	items, err = deleteTagged(items, kind, tag)
+	if err != nil {
+		return err
+	}
+
+	return setSection(cfg, section, items)
+}
+
+// deleteTagged - returns items without the one with the tag.
+func deleteTagged(items []json.RawMessage, kind, tag string) ([]json.RawMessage, error) {
+	for i, item := range items {
+		var h handlerHeader
+		if json.Unmarshal(item, &h) == nil && h.Tag == tag {
+			return append(items[:i:i], items[i+1:]...), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s %q", ErrNotFound, kind, tag)
+}
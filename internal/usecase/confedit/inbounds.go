@@ -0,0 +1,178 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package confedit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/eterline/xraymon/internal/domain"
+)
+
+const inboundsKey = "inbounds"
+
+// Inbounds - returns inbounds of the config in config order.
+func Inbounds(cfg domain.CoreConfiguration) ([]Handler, error) {
+	return handlers(cfg, inboundsKey)
+}
+
+// Inbound - returns the inbound with the tag.
+func Inbound(cfg domain.CoreConfiguration, tag string) (Handler, error) {
+	return handler(cfg, inboundsKey, "inbound", tag)
+}
+
+// PutInbound - replaces the inbound with tag key or adds a new one, see putHandler. Inbounds must not
+// share a tag, a unix socket or a port on overlapping listen addresses. An inbound still referenced
+// by routing rules can't be renamed. Returns true for a new inbound.
+func PutInbound(cfg domain.CoreConfiguration, key string, raw json.RawMessage) (bool, error) {
+	var h handlerHeader
+	if err := decodeObject(raw, &h); err != nil {
+		return false, err
+	}
+
+	if key != "" && h.Tag != "" && h.Tag != key {
+		if err := checkInboundRefs(cfg, key); err != nil && !errors.Is(err, ErrNotFound) {
+			return false, err
+		}
+	}
+
+	return putHandler(cfg, inboundsKey, "inbound", key, raw, checkListen)
+}
+
+// DeleteInbound - removes the inbound with the tag. The inbound must not be referenced by routing rules.
+func DeleteInbound(cfg domain.CoreConfiguration, tag string) error {
+	if err := checkInboundRefs(cfg, tag); err != nil {
+		return err
+	}
+
+	return deleteHandler(cfg, inboundsKey, "inbound", tag)
+}
+
+// checkInboundRefs - returns a ReferencedError listing routing rules matching the inbound by tag.
+func checkInboundRefs(cfg domain.CoreConfiguration, tag string) error {
+	if _, err := Inbound(cfg, tag); err != nil {
+		return err
+	}
+
+	obj, err := routing(cfg)
+	if err != nil {
+		return err
+	}
+
+	rules, err := routingArray(obj, rulesKey)
+	if err != nil {
+		return err
+	}
+
+	var refs []string
+	for i, rule := range rules {
+		var r ruleHeader
+		if err := decodeObject(rule, &r); err != nil {
+			return fmt.Errorf("%s.%s[%d]: %w", routingKey, rulesKey, i, err)
+		}
+
+		if slices.Contains(r.InboundTag, tag) {
+			refs = append(refs, fmt.Sprintf("/%s/%s/%d/inboundTag", routingKey, rulesKey, i))
+		}
+	}
+
+	if len(refs) > 0 {
+		return &ReferencedError{Kind: "inbound", Tag: tag, Refs: refs}
+	}
+	return nil
+}
+
+// checkListen - reports a port or socket collision of inbound h with the other inbound.
+func checkListen(h, other handlerHeader) error {
+	if isSocket(h.Listen) || isSocket(other.Listen) {
+		if h.Listen == other.Listen {
+			return fmt.Errorf("%w: inbound %q already listens on %s", ErrConflict, other.Tag, h.Listen)
+		}
+		return nil
+	}
+
+	if !listenOverlap(h.Listen, other.Listen) {
+		return nil
+	}
+
+	ports, ok := portRanges(portString(h.Port))
+	if !ok {
+		return nil
+	}
+
+	otherPorts, ok := portRanges(portString(other.Port))
+	if !ok {
+		return nil
+	}
+
+	for _, a := range ports {
+		for _, b := range otherPorts {
+			if a[0] <= b[1] && b[0] <= a[1] {
+				return fmt.Errorf("%w: inbound %q already listens on port %s", ErrConflict, other.Tag, portString(other.Port))
+			}
+		}
+	}
+
+	return nil
+}
+
+// isSocket - unix socket listen address: a path or an abstract socket name.
+func isSocket(listen string) bool {
+	return strings.HasPrefix(listen, "/") || strings.HasPrefix(listen, "@")
+}
+
+func listenOverlap(a, b string) bool {
+	wildcard := func(addr string) bool {
+		switch addr {
+		case "", "0.0.0.0", "::", "[::]":
+			return true
+		}
+		return false
+	}
+
+	return a == b || wildcard(a) || wildcard(b)
+}
+
+// portString - inbound port as written in the config, numbers without quotes.
+func portString(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(bytes.TrimSpace(raw))
+}
+
+// portRanges - parses inbound port: a number, "from-to" range or a comma separated list of both.
+// Ports taken from the environment can't be checked and report false.
+func portRanges(spec string) ([][2]int, bool) {
+	if spec == "" || spec == "null" {
+		return nil, false
+	}
+
+	var ranges [][2]int
+	for _, part := range strings.Split(spec, ",") {
+		from, to, isRange := strings.Cut(strings.TrimSpace(part), "-")
+
+		lo, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil {
+			return nil, false
+		}
+
+		hi := lo
+		if isRange {
+			if hi, err = strconv.Atoi(strings.TrimSpace(to)); err != nil {
+				return nil, false
+			}
+		}
+
+		ranges = append(ranges, [2]int{lo, hi})
+	}
+
+	return ranges, true
+}
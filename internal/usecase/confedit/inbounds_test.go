@@ -0,0 +1,154 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package confedit_test
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/usecase/confedit"
+)
+
+func inboundsConfig() domain.CoreConfiguration {
+	return domain.CoreConfiguration{
+		"inbounds": json.RawMessage(`[
+			{"tag":"socks","listen":"127.0.0.1","port":1080,"protocol":"socks"},
+			{"tag":"vless","port":"443,8000-8010","protocol":"vless"},
+			{"tag":"sock","listen":"/run/xray.sock","protocol":"vless"}
+		]`),
+	}
+}
+
+func Test_PutInbound(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		data    string
+		created bool
+		err     error
+	}{
+		{"new inbound", "", `{"tag":"http","port":8080}`, true, nil},
+		{"replace", "socks", `{"tag":"socks","listen":"127.0.0.1","port":1081}`, false, nil},
+		{"rename", "socks", `{"tag":"socks2","port":1080,"listen":"127.0.0.1"}`, false, nil},
+		{"tag from key", "http", `{"port":8080}`, true, nil},
+		{"no tag", "", `{"port":8080}`, false, confedit.ErrInvalid},
+		{"not an object", "", `[1]`, false, confedit.ErrInvalid},
+		{"unknown key", "http", `{"tag":"other","port":8080}`, false, confedit.ErrNotFound},
+		{"tag taken", "", `{"tag":"vless","port":9000}`, false, confedit.ErrConflict},
+		{"rename to taken tag", "socks", `{"tag":"vless","port":1080}`, false, confedit.ErrConflict},
+		{"same port on wildcard", "", `{"tag":"x","port":1080}`, false, confedit.ErrConflict},
+		{"port in range", "", `{"tag":"x","listen":"10.0.0.1","port":8005}`, false, confedit.ErrConflict},
+		{"range overlaps", "", `{"tag":"x","port":"440-450"}`, false, confedit.ErrConflict},
+		{"same port other address", "", `{"tag":"x","listen":"127.0.0.2","port":1080}`, true, nil},
+		{"same socket", "", `{"tag":"x","listen":"/run/xray.sock"}`, false, confedit.ErrConflict},
+		{"env port", "", `{"tag":"x","port":"env:PORT"}`, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := inboundsConfig()
+
+			created, err := confedit.PutInbound(cfg, tt.key, json.RawMessage(tt.data))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+			if created != tt.created {
+				t.Errorf("created = %v, want %v", created, tt.created)
+			}
+		})
+	}
+}
+
+func Test_InboundsEdit(t *testing.T) {
+	cfg := inboundsConfig()
+
+	if _, err := confedit.PutInbound(cfg, "socks", json.RawMessage(`{"port":1081,"protocol":"socks"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := confedit.DeleteInbound(cfg, "vless"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := confedit.DeleteInbound(cfg, "vless"); !errors.Is(err, confedit.ErrNotFound) {
+		t.Errorf("second delete error = %v, want %v", err, confedit.ErrNotFound)
+	}
+
+	list, err := confedit.Inbounds(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list) != 2 || list[0].Tag != "socks" || list[0].Port != "1081" || list[1].Tag != "sock" {
+		t.Errorf("unexpected inbounds: %+v", list)
+	}
+
+	h, err := confedit.Inbound(cfg, "sock")
+	if err != nil || h.Listen != "/run/xray.sock" {
+		t.Errorf("Inbound(sock) = %+v, %v", h, err)
+	}
+}
+
+func Test_InboundReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(cfg domain.CoreConfiguration) error
+		refs []string
+	}{
+		{
+			name: "delete",
+			edit: func(cfg domain.CoreConfiguration) error { return confedit.DeleteInbound(cfg, "socks") },
+			refs: []string{"/routing/rules/0/inboundTag", "/routing/rules/2/inboundTag"},
+		},
+		{
+			name: "rename",
+			edit: func(cfg domain.CoreConfiguration) error {
+				_, err := confedit.PutInbound(cfg, "vless", json.RawMessage(`{"tag":"vless2","port":443}`))
+				return err
+			},
+			refs: []string{"/routing/rules/1/inboundTag"},
+		},
+		{
+			name: "delete unreferenced",
+			edit: func(cfg domain.CoreConfiguration) error { return confedit.DeleteInbound(cfg, "sock") },
+		},
+		{
+			name: "replace referenced",
+			edit: func(cfg domain.CoreConfiguration) error {
+				_, err := confedit.PutInbound(cfg, "socks", json.RawMessage(`{"tag":"socks","port":1081}`))
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := inboundsConfig()
+			cfg["routing"] = json.RawMessage(`{"rules":[
+				{"inboundTag":["socks"],"outboundTag":"direct"},
+				{"inboundTag":"api,vless","outboundTag":"api"},
+				{"inboundTag":["http","socks"],"outboundTag":"block"}
+			]}`)
+
+			err := tt.edit(cfg)
+			if tt.refs == nil {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			var refErr *confedit.ReferencedError
+			if !errors.As(err, &refErr) {
+				t.Fatalf("error = %v, want ReferencedError", err)
+			}
+			if !slices.Equal(refErr.Refs, tt.refs) {
+				t.Errorf("refs = %v, want %v", refErr.Refs, tt.refs)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package confedit
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/eterline/xraymon/internal/domain"
+)
+
+// Probe - connection described for a routing simulation, empty fields are unknown.
+type Probe struct {
+	InboundTag string
+	Network    string // tcp or udp
+	Domain     string
+	IP         netip.Addr
+	Port       int
+	SourceIP   netip.Addr
+	SourcePort int
+	Protocol   string // sniffed protocol: http, tls, quic, bittorrent
+	User       string // user email
+}
+
+// RouteMatch - result of a routing simulation.
+type RouteMatch struct {
+	Matched bool
+	Rule    Rule   // the first matching rule
+	Default string // first outbound, used when no rule matched
+
+	// Undetermined - rules before the match whose conditions can't be evaluated offline:
+	// geosite, geoip, external lists, attributes and the like.
+	Undetermined []Rule
+}
+
+// match - result of one rule condition.
+type match int
+
+const (
+	matchNo match = iota
+	matchYes
+	matchUnknown
+)
+
+// MatchRoute - finds the rule the core would route the probe by. Conditions are evaluated like the core
+// does except those depending on geo data or connection details unknown offline, rules with such
+// conditions are reported as undetermined and skipped.
+func MatchRoute(cfg domain.CoreConfiguration, probe Probe) (RouteMatch, error) {
+	var result RouteMatch
+
+	outbounds, err := Outbounds(cfg)
+	if err != nil {
+		return result, err
+	}
+	if len(outbounds) > 0 {
+		result.Default = outbounds[0].Tag
+	}
+
+	rules, err := Rules(cfg)
+	if err != nil {
+		return result, err
+	}
+
+	probe.Domain = strings.ToLower(probe.Domain)
+
+	for _, rule := range rules {
+		var body ruleBody
+		if err := decodeObject(rule.Raw, &body); err != nil {
+			return result, fmt.Errorf("%s.%s[%d]: %w", routingKey, rulesKey, rule.Index, err)
+		}
+
+		switch matchRule(body, probe) {
+		case matchYes:
+			result.Matched = true
+			result.Rule = rule
+			return result, nil
+		case matchUnknown:
+			result.Undetermined = append(result.Undetermined, rule)
+		}
+	}
+
+	return result, nil
+}
+
+// matchRule - all conditions of a rule must match.
+func matchRule(r ruleBody, p Probe) match {
+	var conditions []match
+
+	if d := r.domains(); len(d) > 0 {
+		conditions = append(conditions, matchAny(d, func(m string) match { return matchDomain(m, p.Domain) }))
+	}
+	if len(r.IP) > 0 {
+		conditions = append(conditions, matchAny(r.IP, func(m string) match { return matchIP(m, p.IP) }))
+	}
+	if s := r.sources(); len(s) > 0 {
+		conditions = append(conditions, matchAny(s, func(m string) match { return matchIP(m, p.SourceIP) }))
+	}
+	if !isNull(r.Port) {
+		conditions = append(conditions, matchPort(portString(r.Port), p.Port))
+	}
+	if !isNull(r.SourcePort) {
+		conditions = append(conditions, matchPort(portString(r.SourcePort), p.SourcePort))
+	}
+	if len(r.Network) > 0 {
+		conditions = append(conditions, matchValue(r.Network, p.Network))
+	}
+	if len(r.InboundTag) > 0 {
+		conditions = append(conditions, matchValue(r.InboundTag, p.InboundTag))
+	}
+	if len(r.Protocol) > 0 {
+		conditions = append(conditions, matchValue(r.Protocol, p.Protocol))
+	}
+	if len(r.User) > 0 {
+		conditions = append(conditions, matchValue(r.User, p.User))
+	}
+	if len(r.LocalIP) > 0 || !isNull(r.LocalPort) || !isNull(r.VlessRoute) || len(r.Attrs) > 0 || len(r.Process) > 0 {
+		conditions = append(conditions, matchUnknown)
+	}
+
+	result := matchYes
+	for _, c := range conditions {
+		if c == matchNo {
+			return matchNo
+		}
+		if c == matchUnknown {
+			result = matchUnknown
+		}
+	}
+
+	return result
+}
+
+// matchAny - a condition with several matchers matches when any of them does.
+func matchAny(matchers []string, fn func(string) match) match {
+	result := matchNo
+	for _, m := range matchers {
+		switch fn(strings.TrimSpace(m)) {
+		case matchYes:
+			return matchYes
+		case matchUnknown:
+			result = matchUnknown
+		}
+	}
+	return result
+}
+
+// matchValue - an empty value is unknown.
+func matchValue(values []string, value string) match {
+	if value == "" {
+		return matchUnknown
+	}
+	if slices.ContainsFunc(values, func(v string) bool { return strings.TrimSpace(v) == value }) {
+		return matchYes
+	}
+	return matchNo
+}
+
+// matchDomain - evaluates a domain matcher, geosite, external lists and an empty domain are unknown.
+func matchDomain(matcher, d string) match {
+	if d == "" {
+		return matchUnknown
+	}
+
+	kind, value, ok := strings.Cut(matcher, ":")
+	if !ok {
+		kind, value = "", matcher
+	}
+
+	var hit bool
+	switch kind {
+	case "geosite", "ext", "ext-domain":
+		return matchUnknown
+	case "domain":
+		value = strings.ToLower(value)
+		hit = d == value || strings.HasSuffix(d, "."+value)
+	case "full":
+		hit = d == strings.ToLower(value)
+	case "keyword":
+		hit = strings.Contains(d, strings.ToLower(value))
+	case "regexp":
+		re, err := regexp.Compile(value)
+		hit = err == nil && re.MatchString(d)
+	case "dotless":
+		hit = !strings.Contains(d, ".") && strings.Contains(d, value)
+	default:
+		hit = strings.Contains(d, strings.ToLower(matcher))
+	}
+
+	if hit {
+		return matchYes
+	}
+	return matchNo
+}
+
+// matchIP - evaluates an IP matcher, geoip, external lists and an unset address are unknown.
+func matchIP(matcher string, ip netip.Addr) match {
+	if !ip.IsValid() {
+		return matchUnknown
+	}
+
+	if kind, _, ok := strings.Cut(matcher, ":"); ok {
+		switch kind {
+		case "geoip", "ext", "ext-ip":
+			return matchUnknown
+		}
+	}
+
+	prefix, err := parseCIDR(matcher)
+	if err != nil || !prefix.Contains(ip.Unmap()) {
+		return matchNo
+	}
+	return matchYes
+}
+
+// matchPort - evaluates a port list, ports taken from the environment and an unset port are unknown.
+func matchPort(spec string, port int) match {
+	ranges, ok := portRanges(spec)
+	if !ok || port <= 0 {
+		return matchUnknown
+	}
+
+	for _, r := range ranges {
+		if r[0] <= port && port <= r[1] {
+			return matchYes
+		}
+	}
+	return matchNo
+}
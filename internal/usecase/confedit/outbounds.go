@@ -0,0 +1,292 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package confedit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/eterline/xraymon/internal/domain"
+)
+
+const outboundsKey = "outbounds"
+
+// outboundHeader - fields of an outbound naming the outbound it is dialed through.
+type outboundHeader struct {
+	Tag           string `json:"tag"`
+	ProxySettings struct {
+		Tag string `json:"tag"`
+	} `json:"proxySettings"`
+	StreamSettings struct {
+		Sockopt struct {
+			DialerProxy string `json:"dialerProxy"`
+		} `json:"sockopt"`
+	} `json:"streamSettings"`
+}
+
+// Outbounds - returns outbounds of the config in config order.
+func Outbounds(cfg domain.CoreConfiguration) ([]Handler, error) {
+	return handlers(cfg, outboundsKey)
+}
+
+// Outbound - returns the outbound with the tag.
+func Outbound(cfg domain.CoreConfiguration, tag string) (Handler, error) {
+	return handler(cfg, outboundsKey, "outbound", tag)
+}
+
+// PutOutbound - replaces the outbound with tag key or adds a new one, see putHandler. An outbound
+// still referenced by its old tag can't be renamed. Returns true for a new outbound.
+func PutOutbound(cfg domain.CoreConfiguration, key string, raw json.RawMessage) (bool, error) {
+	var h handlerHeader
+	if err := decodeObject(raw, &h); err != nil {
+		return false, err
+	}
+
+	if key != "" && h.Tag != "" && h.Tag != key {
+		u, err := unlinkOutbound(maps.Clone(cfg), key, nil)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return false, err
+		}
+		if refs := u.all(); len(refs) > 0 {
+			return false, &ReferencedError{Kind: "outbound", Tag: key, Refs: refs}
+		}
+	}
+
+	return putHandler(cfg, outboundsKey, "outbound", key, raw, nil)
+}
+
+// DeleteOutbound - removes the outbound with the tag. The outbound must not be referenced by routing
+// rules, balancers or outbounds dialing through it. With cascade the referencing rules, balancer selectors
+// and fallbacks are removed instead. Outbounds dialing through the outbound and balancers left without
+// selectors are removed only when named in dependents, otherwise the delete fails with a ReferencedError
+// listing them. Returns the removed references.
+func DeleteOutbound(cfg domain.CoreConfiguration, tag string, cascade bool, dependents []string) ([]string, error) {
+	if tag == "" {
+		return nil, fmt.Errorf("%w: outbound tag is required", ErrInvalid)
+	}
+	if len(dependents) > 0 && !cascade {
+		return nil, fmt.Errorf("%w: dependents are deleted only with cascade", ErrInvalid)
+	}
+
+	named := make(map[string]bool, len(dependents))
+	for _, dep := range dependents {
+		named[dep] = true
+	}
+
+	work := maps.Clone(cfg)
+
+	u, err := unlinkOutbound(work, tag, named)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !cascade && len(u.all()) > 0:
+		return nil, &ReferencedError{Kind: "outbound", Tag: tag, Refs: u.all()}
+	case len(u.dependents) > 0:
+		return nil, &ReferencedError{Kind: "outbound", Tag: tag, Refs: u.blocking, Dependents: u.dependents}
+	}
+
+	for _, dep := range dependents {
+		if !u.deleted[dep] && !u.balancers[dep] {
+			return nil, fmt.Errorf("%w: %q is not a dependent of outbound %q", ErrInvalid, dep, tag)
+		}
+	}
+
+	maps.Copy(cfg, work)
+	return u.removed, nil
+}
+
+// unlinked - result of unlinkOutbound. Locations are JSON pointers into the original config.
+type unlinked struct {
+	removed    []string        // references removed from the config
+	blocking   []string        // references of dependents that are not named, kept in the config
+	dependents []string        // tags of the dependents that are not named
+	deleted    map[string]bool // tags of the removed outbounds
+	balancers  map[string]bool // tags of the removed balancers
+}
+
+// all - every reference to the outbound, removed or not.
+func (u unlinked) all() []string {
+	return append(slices.Clone(u.removed), u.blocking...)
+}
+
+// unlinkOutbound - removes the outbound with the references to it: rules, balancer selectors and fallbacks.
+// Outbounds dialing through a removed outbound and balancers left without selectors are removed when
+// named, and reported as blocking dependents otherwise.
+func unlinkOutbound(cfg domain.CoreConfiguration, tag string, named map[string]bool) (unlinked, error) {
+	u := unlinked{deleted: map[string]bool{tag: true}, balancers: map[string]bool{}}
+
+	items, err := section(cfg, outboundsKey)
+	if err != nil {
+		return u, err
+	}
+
+	headers := make([]outboundHeader, len(items))
+	found := false
+	for i, item := range items {
+		if err := decodeObject(item, &headers[i]); err != nil {
+			return u, fmt.Errorf("%s[%d]: %w", outboundsKey, i, err)
+		}
+		found = found || headers[i].Tag == tag
+	}
+
+	if !found {
+		return u, fmt.Errorf("%w: outbound %q", ErrNotFound, tag)
+	}
+
+	dialsDeleted := func(h outboundHeader) bool {
+		return u.deleted[h.ProxySettings.Tag] || u.deleted[h.StreamSettings.Sockopt.DialerProxy]
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, h := range headers {
+			if h.Tag != "" && !u.deleted[h.Tag] && named[h.Tag] && dialsDeleted(h) {
+				u.deleted[h.Tag] = true
+				changed = true
+			}
+		}
+	}
+
+	kept := make([]json.RawMessage, 0, len(items))
+	var remaining []string
+
+	for i, h := range headers {
+		switch {
+		case h.Tag == tag:
+			continue
+		case u.deleted[h.Tag]:
+			u.removed = append(u.removed, fmt.Sprintf("/%s/%d", outboundsKey, i))
+			continue
+		}
+
+		if u.deleted[h.ProxySettings.Tag] {
+			u.blocking = append(u.blocking, fmt.Sprintf("/%s/%d/proxySettings/tag", outboundsKey, i))
+		}
+		if u.deleted[h.StreamSettings.Sockopt.DialerProxy] {
+			u.blocking = append(u.blocking, fmt.Sprintf("/%s/%d/streamSettings/sockopt/dialerProxy", outboundsKey, i))
+		}
+		if dialsDeleted(h) {
+			u.dependents = append(u.dependents, h.Tag)
+		}
+
+		kept = append(kept, items[i])
+		remaining = append(remaining, h.Tag)
+	}
+
+	if err := setSection(cfg, outboundsKey, kept); err != nil {
+		return u, err
+	}
+
+	obj, err := routing(cfg)
+	if err != nil {
+		return u, err
+	}
+
+	balancersChanged, err := unlinkBalancers(obj, &u, remaining, named)
+	if err != nil {
+		return u, err
+	}
+
+	ruleRefs, err := dropRules(obj, u.deleted, u.balancers)
+	if err != nil {
+		return u, err
+	}
+
+	if !balancersChanged && len(ruleRefs) == 0 {
+		return u, nil
+	}
+
+	u.removed = append(u.removed, ruleRefs...)
+
+	return u, setRouting(cfg, obj)
+}
+
+// unlinkBalancers - removes the deleted outbounds from balancers: selectors matching no remaining outbound
+// and fallbacks to them. Balancers left without selectors are removed when named and reported as blocking
+// dependents otherwise. Reports whether the balancers changed.
+func unlinkBalancers(obj map[string]json.RawMessage, u *unlinked, remaining []string, named map[string]bool) (bool, error) {
+	items, err := routingArray(obj, balancersKey)
+	if err != nil {
+		return false, err
+	}
+
+	kept := make([]json.RawMessage, 0, len(items))
+	changed := false
+
+	for i, item := range items {
+		var b balancerHeader
+		if err := decodeObject(item, &b); err != nil {
+			return false, fmt.Errorf("%s.%s[%d]: %w", routingKey, balancersKey, i, err)
+		}
+
+		var selector, selectorRefs []string
+		for j, prefix := range b.Selector {
+			if selects(prefix, u.deleted) && !selectsAny(prefix, remaining) {
+				selectorRefs = append(selectorRefs, fmt.Sprintf("/%s/%s/%d/selector/%d", routingKey, balancersKey, i, j))
+				continue
+			}
+			selector = append(selector, prefix)
+		}
+
+		if len(b.Selector) > 0 && len(selector) == 0 {
+			if !named[b.Tag] {
+				u.blocking = append(u.blocking, selectorRefs...)
+				u.dependents = append(u.dependents, b.Tag)
+				kept = append(kept, item)
+				continue
+			}
+
+			u.removed = append(u.removed, fmt.Sprintf("/%s/%s/%d", routingKey, balancersKey, i))
+			u.balancers[b.Tag] = true
+			changed = true
+			continue
+		}
+
+		if len(selectorRefs) > 0 {
+			u.removed = append(u.removed, selectorRefs...)
+			if item, err = setMember(item, "selector", selector); err != nil {
+				return false, err
+			}
+			changed = true
+		}
+
+		if b.FallbackTag != "" && u.deleted[b.FallbackTag] {
+			u.removed = append(u.removed, fmt.Sprintf("/%s/%s/%d/fallbackTag", routingKey, balancersKey, i))
+			if item, err = deleteMember(item, "fallbackTag"); err != nil {
+				return false, err
+			}
+			changed = true
+		}
+
+		kept = append(kept, item)
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	return true, setRoutingArray(obj, balancersKey, kept)
+}
+
+// selects - reports whether the balancer selector prefix matches one of the tags.
+func selects(prefix string, tags map[string]bool) bool {
+	for tag := range tags {
+		if strings.HasPrefix(tag, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func selectsAny(prefix string, tags []string) bool {
+	return slices.ContainsFunc(tags, func(tag string) bool {
+		return strings.HasPrefix(tag, prefix)
+	})
+}
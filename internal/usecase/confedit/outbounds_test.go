@@ -0,0 +1,229 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package confedit_test
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/usecase/confedit"
+)
+
+func outboundsConfig() domain.CoreConfiguration {
+	return domain.CoreConfiguration{
+		"outbounds": json.RawMessage(`[
+			{"tag":"direct","protocol":"freedom"},
+			{"tag":"block","protocol":"blackhole"},
+			{"tag":"proxy-a","protocol":"vless"},
+			{"tag":"proxy-b","protocol":"vless","proxySettings":{"tag":"proxy-a"}},
+			{"tag":"chain","protocol":"vless","streamSettings":{"sockopt":{"dialerProxy":"direct"}}}
+		]`),
+		"routing": json.RawMessage(`{
+			"domainStrategy":"AsIs",
+			"rules":[
+				{"ruleTag":"lan","outboundTag":"direct"},
+				{"ruleTag":"proxied","balancerTag":"lb"},
+				{"ruleTag":"ads","outboundTag":"block"}
+			],
+			"balancers":[{"tag":"lb","selector":["proxy-"],"fallbackTag":"direct"}]
+		}`),
+	}
+}
+
+func Test_DeleteOutbound(t *testing.T) {
+	tests := []struct {
+		name       string
+		tag        string
+		cascade    bool
+		named      []string
+		refs       []string
+		dependents []string
+		left       []string
+		err        error
+	}{
+		{
+			name: "referenced by rule",
+			tag:  "block",
+			refs: []string{"/routing/rules/2/outboundTag"},
+			err:  confedit.ErrReferenced,
+		},
+		{
+			name:    "cascade rule",
+			tag:     "block",
+			cascade: true,
+			refs:    []string{"/routing/rules/2/outboundTag"},
+			left:    []string{"direct", "proxy-a", "proxy-b", "chain"},
+		},
+		{
+			name: "referenced by dialer, fallback and rule",
+			tag:  "direct",
+			refs: []string{
+				"/routing/balancers/0/fallbackTag",
+				"/routing/rules/0/outboundTag",
+				"/outbounds/4/streamSettings/sockopt/dialerProxy",
+			},
+			err: confedit.ErrReferenced,
+		},
+		{
+			name:       "cascade keeps unnamed dialer",
+			tag:        "direct",
+			cascade:    true,
+			refs:       []string{"/outbounds/4/streamSettings/sockopt/dialerProxy"},
+			dependents: []string{"chain"},
+			err:        confedit.ErrReferenced,
+		},
+		{
+			name:    "cascade named dialer, fallback and rule",
+			tag:     "direct",
+			cascade: true,
+			named:   []string{"chain"},
+			refs: []string{
+				"/outbounds/4",
+				"/routing/balancers/0/fallbackTag",
+				"/routing/rules/0/outboundTag",
+			},
+			left: []string{"block", "proxy-a", "proxy-b"},
+		},
+		{
+			name: "selector still matches other outbound",
+			tag:  "proxy-a",
+			refs: []string{"/outbounds/3/proxySettings/tag"},
+			err:  confedit.ErrReferenced,
+		},
+		{
+			name:       "cascade keeps emptied balancer",
+			tag:        "proxy-a",
+			cascade:    true,
+			named:      []string{"proxy-b"},
+			refs:       []string{"/routing/balancers/0/selector/0"},
+			dependents: []string{"lb"},
+			err:        confedit.ErrReferenced,
+		},
+		{
+			name:    "cascade named proxy chain and emptied balancer",
+			tag:     "proxy-a",
+			cascade: true,
+			named:   []string{"proxy-b", "lb"},
+			refs: []string{
+				"/outbounds/3",
+				"/routing/balancers/0",
+				"/routing/rules/1/balancerTag",
+			},
+			left: []string{"direct", "block", "chain"},
+		},
+		{
+			name:    "named not dependent",
+			tag:     "block",
+			cascade: true,
+			named:   []string{"chain"},
+			err:     confedit.ErrInvalid,
+		},
+		{
+			name:  "named without cascade",
+			tag:   "direct",
+			named: []string{"chain"},
+			err:   confedit.ErrInvalid,
+		},
+		{
+			name: "unknown",
+			tag:  "none",
+			err:  confedit.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := outboundsConfig()
+
+			refs, err := confedit.DeleteOutbound(cfg, tt.tag, tt.cascade, tt.named)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+
+			var dependents []string
+			var refErr *confedit.ReferencedError
+			if errors.As(err, &refErr) {
+				refs, dependents = refErr.Refs, refErr.Dependents
+			}
+			if !slices.Equal(refs, tt.refs) {
+				t.Errorf("refs = %v, want %v", refs, tt.refs)
+			}
+			if !slices.Equal(dependents, tt.dependents) {
+				t.Errorf("dependents = %v, want %v", dependents, tt.dependents)
+			}
+
+			if err != nil {
+				if !slices.Equal(tags(t, cfg), tags(t, outboundsConfig())) {
+					t.Errorf("config changed on error")
+				}
+				return
+			}
+
+			if left := tags(t, cfg); !slices.Equal(left, tt.left) {
+				t.Errorf("outbounds left = %v, want %v", left, tt.left)
+			}
+		})
+	}
+}
+
+func Test_BalancersEdit(t *testing.T) {
+	cfg := outboundsConfig()
+
+	if _, err := confedit.PutBalancer(cfg, "lb", json.RawMessage(`{"tag":"lb2","selector":["proxy-"]}`)); !errors.Is(err, confedit.ErrReferenced) {
+		t.Errorf("rename used balancer error = %v, want %v", err, confedit.ErrReferenced)
+	}
+
+	if _, err := confedit.PutBalancer(cfg, "", json.RawMessage(`{"tag":"lb2"}`)); !errors.Is(err, confedit.ErrInvalid) {
+		t.Errorf("no selector error = %v, want %v", err, confedit.ErrInvalid)
+	}
+
+	if _, err := confedit.PutBalancer(cfg, "", json.RawMessage(`{"tag":"lb2","selector":["x"],"fallbackTag":"none"}`)); !errors.Is(err, confedit.ErrInvalid) {
+		t.Errorf("unknown fallback error = %v, want %v", err, confedit.ErrInvalid)
+	}
+
+	created, err := confedit.PutBalancer(cfg, "", json.RawMessage(`{"tag":"lb2","selector":["proxy-b"],"strategy":{"type":"leastPing"}}`))
+	if err != nil || !created {
+		t.Fatalf("put new balancer = %v, %v", created, err)
+	}
+
+	if _, err := confedit.DeleteBalancer(cfg, "lb", false); !errors.Is(err, confedit.ErrReferenced) {
+		t.Errorf("delete used balancer error = %v, want %v", err, confedit.ErrReferenced)
+	}
+
+	refs, err := confedit.DeleteBalancer(cfg, "lb", true)
+	if err != nil || !slices.Equal(refs, []string{"/routing/rules/1/balancerTag"}) {
+		t.Fatalf("cascade delete = %v, %v", refs, err)
+	}
+
+	list, err := confedit.Balancers(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Tag != "lb2" || list[0].Strategy != "leastPing" {
+		t.Errorf("unexpected balancers: %+v", list)
+	}
+
+	var routing map[string]json.RawMessage
+	if err := json.Unmarshal(cfg["routing"], &routing); err != nil || string(routing["domainStrategy"]) != `"AsIs"` {
+		t.Errorf("routing settings lost: %s", cfg["routing"])
+	}
+}
+
+func tags(t *testing.T, cfg domain.CoreConfiguration) []string {
+	t.Helper()
+
+	list, err := confedit.Outbounds(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result []string
+	for _, h := range list {
+		result = append(result, h.Tag)
+	}
+	return result
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package confedit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/eterline/xraymon/internal/domain"
+)
+
+const (
+	routingKey   = "routing"
+	rulesKey     = "rules"
+	balancersKey = "balancers"
+)
+
+// ruleHeader - fields of a routing rule the editor looks at.
+type ruleHeader struct {
+	RuleTag     string     `json:"ruleTag"`
+	InboundTag  stringList `json:"inboundTag"`
+	OutboundTag string     `json:"outboundTag"`
+	BalancerTag string     `json:"balancerTag"`
+}
+
+// stringList - a JSON array of strings or a comma separated string, read like the core does.
+type stringList []string
+
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*l = list
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("string list expected, got %s", data)
+	}
+
+	*l = strings.Split(s, ",")
+	return nil
+}
+
+// routing - members of the routing section, a missing section is empty.
+func routing(cfg domain.CoreConfiguration) (map[string]json.RawMessage, error) {
+	obj := map[string]json.RawMessage{}
+
+	raw, ok := cfg[routingKey]
+	if !ok || string(raw) == "null" {
+		return obj, nil
+	}
+
+	if err := decodeObject(raw, &obj); err != nil {
+		return nil, fmt.Errorf("%s: %w", routingKey, err)
+	}
+	if obj == nil {
+		obj = map[string]json.RawMessage{}
+	}
+
+	return obj, nil
+}
+
+func setRouting(cfg domain.CoreConfiguration, obj map[string]json.RawMessage) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", routingKey, err)
+	}
+
+	cfg[routingKey] = data
+	return nil
+}
+
+// routingArray - decodes an array member of the routing section.
+func routingArray(obj map[string]json.RawMessage, key string) ([]json.RawMessage, error) {
+	return decodeArray(obj[key], routingKey+"."+key)
+}
+
+func setRoutingArray(obj map[string]json.RawMessage, key string, items []json.RawMessage) error {
+	data, err := encodeArray(items, routingKey+"."+key)
+	if err != nil {
+		return err
+	}
+
+	obj[key] = data
+	return nil
+}
+
+// dropRules - removes routing rules targeting one of the outbounds or balancers, returns their locations.
+func dropRules(obj map[string]json.RawMessage, outbounds, balancers map[string]bool) ([]string, error) {
+	rules, err := routingArray(obj, rulesKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []string
+	kept := make([]json.RawMessage, 0, len(rules))
+
+	for i, rule := range rules {
+		var r ruleHeader
+		if err := decodeObject(rule, &r); err != nil {
+			return nil, fmt.Errorf("%s.%s[%d]: %w", routingKey, rulesKey, i, err)
+		}
+
+		switch {
+		case r.OutboundTag != "" && outbounds[r.OutboundTag]:
+			refs = append(refs, fmt.Sprintf("/%s/%s/%d/outboundTag", routingKey, rulesKey, i))
+		case r.BalancerTag != "" && balancers[r.BalancerTag]:
+			refs = append(refs, fmt.Sprintf("/%s/%s/%d/balancerTag", routingKey, rulesKey, i))
+		default:
+			kept = append(kept, rule)
+		}
+	}
+
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	return refs, setRoutingArray(obj, rulesKey, kept)
+}
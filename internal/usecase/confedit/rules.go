@@ -0,0 +1,404 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package confedit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/eterline/xraymon/internal/domain"
+)
+
+// Rule - routing rule with its identifying fields.
+type Rule struct {
+	Tag         string // ruleTag, generated from the content for rules without one, see ruleTags
+	Index       int
+	OutboundTag string
+	BalancerTag string
+	Raw         json.RawMessage
+}
+
+// ruleBody - routing rule conditions the editor validates and simulates.
+type ruleBody struct {
+	ruleHeader
+
+	Domain     stringList        `json:"domain"`
+	Domains    stringList        `json:"domains"`
+	IP         stringList        `json:"ip"`
+	Source     stringList        `json:"source"`
+	SourceIP   stringList        `json:"sourceIP"`
+	LocalIP    stringList        `json:"localIP"`
+	Port       json.RawMessage   `json:"port"`
+	SourcePort json.RawMessage   `json:"sourcePort"`
+	LocalPort  json.RawMessage   `json:"localPort"`
+	VlessRoute json.RawMessage   `json:"vlessRoute"`
+	Network    stringList        `json:"network"`
+	Protocol   stringList        `json:"protocol"`
+	User       stringList        `json:"user"`
+	Attrs      map[string]string `json:"attrs"`
+	Process    stringList        `json:"process"`
+}
+
+func (r ruleBody) domains() []string {
+	return append(slices.Clone(r.Domain), r.Domains...)
+}
+
+func (r ruleBody) sources() []string {
+	return append(slices.Clone(r.Source), r.SourceIP...)
+}
+
+// empty - reports a rule without conditions, xray refuses such rules.
+func (r ruleBody) empty() bool {
+	return len(r.domains()) == 0 && len(r.IP) == 0 && len(r.sources()) == 0 && len(r.LocalIP) == 0 &&
+		isNull(r.Port) && isNull(r.SourcePort) && isNull(r.LocalPort) && isNull(r.VlessRoute) &&
+		len(r.Network) == 0 && len(r.InboundTag) == 0 && len(r.Protocol) == 0 && len(r.User) == 0 &&
+		len(r.Attrs) == 0 && len(r.Process) == 0
+}
+
+func isNull(raw json.RawMessage) bool {
+	return len(raw) == 0 || string(raw) == "null"
+}
+
+// Rules - returns routing rules in matching order.
+func Rules(cfg domain.CoreConfiguration) ([]Rule, error) {
+	obj, err := routing(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := routingArray(obj, rulesKey)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, _, err := ruleTags(items)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]Rule, 0, len(items))
+	for i, item := range items {
+		var r ruleHeader
+		if err := decodeObject(item, &r); err != nil {
+			return nil, fmt.Errorf("%s.%s[%d]: %w", routingKey, rulesKey, i, err)
+		}
+
+		list = append(list, Rule{
+			Tag:         tags[i],
+			Index:       i,
+			OutboundTag: r.OutboundTag,
+			BalancerTag: r.BalancerTag,
+			Raw:         item,
+		})
+	}
+
+	return list, nil
+}
+
+// InsertRule - validates the rule and inserts it at position, a negative or too large position appends it.
+// The rule must have a ruleTag not used by other rules.
+func InsertRule(cfg domain.CoreConfiguration, position int, raw json.RawMessage) error {
+	return editRules(cfg, func(items []json.RawMessage, tags []string) ([]json.RawMessage, error) {
+		tag, err := validateRule(cfg, raw)
+		if err != nil {
+			return nil, err
+		}
+		if tag == "" {
+			return nil, fmt.Errorf("%w: ruleTag is required", ErrInvalid)
+		}
+		if slices.Contains(tags, tag) {
+			return nil, fmt.Errorf("%w: rule %q already exists", ErrConflict, tag)
+		}
+
+		if position < 0 || position > len(items) {
+			position = len(items)
+		}
+		return slices.Insert(items, position, raw), nil
+	})
+}
+
+// ReplaceRule - validates the rule and puts it in place of the rule with the tag. A rule without
+// ruleTag keeps the tag, a new ruleTag must not be used by other rules.
+func ReplaceRule(cfg domain.CoreConfiguration, tag string, raw json.RawMessage) error {
+	return editRules(cfg, func(items []json.RawMessage, tags []string) ([]json.RawMessage, error) {
+		pos, err := rulePosition(tags, tag)
+		if err != nil {
+			return nil, err
+		}
+
+		newTag, err := validateRule(cfg, raw)
+		if err != nil {
+			return nil, err
+		}
+
+		if newTag == "" {
+			if raw, err = setMember(raw, "ruleTag", tag); err != nil {
+				return nil, err
+			}
+		} else if newTag != tag && slices.Contains(tags, newTag) {
+			return nil, fmt.Errorf("%w: rule %q already exists", ErrConflict, newTag)
+		}
+
+		items[pos] = raw
+		return items, nil
+	})
+}
+
+// MoveRule - moves the rule with the tag to position, a negative or too large position moves it to the end.
+func MoveRule(cfg domain.CoreConfiguration, tag string, position int) error {
+	return editRules(cfg, func(items []json.RawMessage, tags []string) ([]json.RawMessage, error) {
+		pos, err := rulePosition(tags, tag)
+		if err != nil {
+			return nil, err
+		}
+
+		rule := items[pos]
+		items = slices.Delete(items, pos, pos+1)
+
+		if position < 0 || position > len(items) {
+			position = len(items)
+		}
+		return slices.Insert(items, position, rule), nil
+	})
+}
+
+// DeleteRule - removes the rule with the tag.
+func DeleteRule(cfg domain.CoreConfiguration, tag string) error {
+	return editRules(cfg, func(items []json.RawMessage, tags []string) ([]json.RawMessage, error) {
+		pos, err := rulePosition(tags, tag)
+		if err != nil {
+			return nil, err
+		}
+		return slices.Delete(items, pos, pos+1), nil
+	})
+}
+
+// editRules - replaces routing rules with the result of edit, which gets the rules and their tags.
+// Generated tags of untagged rules are stored in the rules, they stay stable after the first edit.
+func editRules(cfg domain.CoreConfiguration, edit func(items []json.RawMessage, tags []string) ([]json.RawMessage, error)) error {
+	obj, err := routing(cfg)
+	if err != nil {
+		return err
+	}
+
+	items, err := routingArray(obj, rulesKey)
+	if err != nil {
+		return err
+	}
+
+	tags, generated, err := ruleTags(items)
+	if err != nil {
+		return err
+	}
+
+	for i := range items {
+		if !generated[i] {
+			continue
+		}
+		if items[i], err = setMember(items[i], "ruleTag", tags[i]); err != nil {
+			return err
+		}
+	}
+
+	if items, err = edit(items, tags); err != nil {
+		return err
+	}
+
+	if err := setRoutingArray(obj, rulesKey, items); err != nil {
+		return err
+	}
+
+	return setRouting(cfg, obj)
+}
+
+// ruleTags - returns tags of the rules. An untagged rule gets "rule-" with a hash of its content, so
+// listings and edits of the same config address it alike; a tag taken by another rule gets a suffix.
+func ruleTags(items []json.RawMessage) ([]string, []bool, error) {
+	tags := make([]string, len(items))
+	generated := make([]bool, len(items))
+	used := map[string]bool{}
+
+	for i, item := range items {
+		var r ruleHeader
+		if err := decodeObject(item, &r); err != nil {
+			return nil, nil, fmt.Errorf("%s.%s[%d]: %w", routingKey, rulesKey, i, err)
+		}
+		tags[i] = r.RuleTag
+		used[r.RuleTag] = true
+	}
+
+	for i, item := range items {
+		if tags[i] != "" {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, item); err != nil {
+			return nil, nil, fmt.Errorf("%s.%s[%d]: %w", routingKey, rulesKey, i, err)
+		}
+
+		sum := sha256.Sum256(buf.Bytes())
+		base := "rule-" + hex.EncodeToString(sum[:4])
+
+		tag := base
+		for n := 2; used[tag]; n++ {
+			tag = fmt.Sprintf("%s-%d", base, n)
+		}
+
+		tags[i], generated[i] = tag, true
+		used[tag] = true
+	}
+
+	return tags, generated, nil
+}
+
+func rulePosition(tags []string, tag string) (int, error) {
+	if tag == "" {
+		return 0, fmt.Errorf("%w: ruleTag is required", ErrInvalid)
+	}
+
+	pos := slices.Index(tags, tag)
+	if pos < 0 {
+		return 0, fmt.Errorf("%w: rule %q", ErrNotFound, tag)
+	}
+
+	return pos, nil
+}
+
+// validateRule - checks the rule target and matchers, returns its ruleTag.
+func validateRule(cfg domain.CoreConfiguration, raw json.RawMessage) (string, error) {
+	var r ruleBody
+	if err := decodeObject(raw, &r); err != nil {
+		return "", err
+	}
+
+	switch {
+	case r.OutboundTag == "" && r.BalancerTag == "":
+		return "", fmt.Errorf("%w: rule needs outboundTag or balancerTag", ErrInvalid)
+	case r.OutboundTag != "" && r.BalancerTag != "":
+		return "", fmt.Errorf("%w: rule has both outboundTag and balancerTag", ErrInvalid)
+	case r.OutboundTag != "":
+		if _, err := Outbound(cfg, r.OutboundTag); err != nil {
+			return "", fmt.Errorf("%w: rule outboundTag: %v", ErrInvalid, err)
+		}
+	default:
+		if _, err := GetBalancer(cfg, r.BalancerTag); err != nil {
+			return "", fmt.Errorf("%w: rule balancerTag: %v", ErrInvalid, err)
+		}
+	}
+
+	if r.empty() {
+		return "", fmt.Errorf("%w: rule has no conditions", ErrInvalid)
+	}
+
+	for _, d := range r.domains() {
+		if err := validateDomain(d); err != nil {
+			return "", fmt.Errorf("%w: domain %q: %v", ErrInvalid, d, err)
+		}
+	}
+
+	for _, list := range [][]string{r.IP, r.sources(), r.LocalIP} {
+		for _, ip := range list {
+			if err := validateIP(ip); err != nil {
+				return "", fmt.Errorf("%w: ip %q: %v", ErrInvalid, ip, err)
+			}
+		}
+	}
+
+	return r.RuleTag, nil
+}
+
+// validateDomain - checks a domain matcher: plain substring, domain:, full:, keyword:, regexp:, dotless:,
+// geosite: or ext:file:list.
+func validateDomain(d string) error {
+	kind, value, ok := strings.Cut(d, ":")
+	if !ok {
+		kind, value = "", d
+	}
+
+	switch kind {
+	case "geosite":
+		if value == "" {
+			return errors.New("empty geosite list")
+		}
+	case "ext", "ext-domain":
+		return validateExt(value)
+	case "regexp":
+		if _, err := regexp.Compile(value); err != nil {
+			return err
+		}
+	case "domain", "full", "keyword":
+		if value == "" {
+			return errors.New("empty value")
+		}
+	case "dotless":
+		if strings.Contains(value, ".") {
+			return errors.New("dotless value must not contain a dot")
+		}
+	default:
+		if d == "" {
+			return errors.New("empty value")
+		}
+	}
+
+	return nil
+}
+
+// validateIP - checks an IP matcher: address, CIDR, geoip:[!]code or ext:file:[!]code.
+func validateIP(ip string) error {
+	kind, value, ok := strings.Cut(ip, ":")
+	if ok {
+		switch kind {
+		case "geoip":
+			if strings.TrimPrefix(value, "!") == "" {
+				return errors.New("empty geoip code")
+			}
+			return nil
+		case "ext", "ext-ip":
+			return validateExt(value)
+		}
+	}
+
+	_, err := parseCIDR(ip)
+	return err
+}
+
+func validateExt(value string) error {
+	file, list, ok := strings.Cut(value, ":")
+	if !ok || file == "" || strings.TrimPrefix(list, "!") == "" || strings.Contains(list, ":") {
+		return errors.New("ext:file:list expected")
+	}
+	return nil
+}
+
+// parseCIDR - parses an address or a CIDR, a bare address is a single host network.
+func parseCIDR(s string) (netip.Prefix, error) {
+	if addr, mask, ok := strings.Cut(s, "/"); ok {
+		a, err := netip.ParseAddr(addr)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		bits, err := strconv.Atoi(mask)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid mask %q", mask)
+		}
+		return a.Unmap().Prefix(bits)
+	}
+
+	a, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	a = a.Unmap()
+	return netip.PrefixFrom(a, a.BitLen()), nil
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package confedit_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/usecase/confedit"
+)
+
+func ruleTags(t *testing.T, cfg domain.CoreConfiguration) []string {
+	t.Helper()
+
+	rules, err := confedit.Rules(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result []string
+	for _, r := range rules {
+		result = append(result, r.Tag)
+	}
+	return result
+}
+
+func Test_InsertRule(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{"outbound rule", `{"ruleTag":"x","domain":["geosite:cn","domain:example.com","full:a.b","regexp:^a+$","keyword:ads","plain"],"outboundTag":"direct"}`, nil},
+		{"balancer rule", `{"ruleTag":"x","ip":["10.0.0.0/8","::1","geoip:!ru","ext:extra.dat:list"],"balancerTag":"lb"}`, nil},
+		{"comma separated list", `{"ruleTag":"x","network":"tcp,udp","outboundTag":"block"}`, nil},
+		{"no tag", `{"domain":["a.com"],"outboundTag":"direct"}`, confedit.ErrInvalid},
+		{"tag taken", `{"ruleTag":"lan","domain":["a.com"],"outboundTag":"direct"}`, confedit.ErrConflict},
+		{"no target", `{"ruleTag":"x","domain":["a.com"]}`, confedit.ErrInvalid},
+		{"both targets", `{"ruleTag":"x","domain":["a.com"],"outboundTag":"direct","balancerTag":"lb"}`, confedit.ErrInvalid},
+		{"unknown outbound", `{"ruleTag":"x","domain":["a.com"],"outboundTag":"none"}`, confedit.ErrInvalid},
+		{"unknown balancer", `{"ruleTag":"x","domain":["a.com"],"balancerTag":"none"}`, confedit.ErrInvalid},
+		{"no conditions", `{"ruleTag":"x","outboundTag":"direct"}`, confedit.ErrInvalid},
+		{"bad regexp", `{"ruleTag":"x","domain":["regexp:("],"outboundTag":"direct"}`, confedit.ErrInvalid},
+		{"empty geosite", `{"ruleTag":"x","domain":["geosite:"],"outboundTag":"direct"}`, confedit.ErrInvalid},
+		{"dotless with dot", `{"ruleTag":"x","domain":["dotless:a.b"],"outboundTag":"direct"}`, confedit.ErrInvalid},
+		{"bad cidr", `{"ruleTag":"x","ip":["10.0.0.0/33"],"outboundTag":"direct"}`, confedit.ErrInvalid},
+		{"bad ip", `{"ruleTag":"x","source":["10.0.0"],"outboundTag":"direct"}`, confedit.ErrInvalid},
+		{"bad ext", `{"ruleTag":"x","ip":["ext:extra.dat"],"outboundTag":"direct"}`, confedit.ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := outboundsConfig()
+
+			err := confedit.InsertRule(cfg, 1, json.RawMessage(tt.data))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+
+			want := []string{"lan", "proxied", "ads"}
+			if err == nil {
+				want = []string{"lan", "x", "proxied", "ads"}
+			}
+			if got := ruleTags(t, cfg); !slices.Equal(got, want) {
+				t.Errorf("rules = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func Test_RulesEdit(t *testing.T) {
+	cfg := outboundsConfig()
+
+	steps := []struct {
+		name string
+		edit func() error
+		want []string
+	}{
+		{"move to top", func() error { return confedit.MoveRule(cfg, "ads", 0) }, []string{"ads", "lan", "proxied"}},
+		{"move to end", func() error { return confedit.MoveRule(cfg, "ads", -1) }, []string{"lan", "proxied", "ads"}},
+		{"append", func() error {
+			return confedit.InsertRule(cfg, -1, json.RawMessage(`{"ruleTag":"dns","port":53,"outboundTag":"direct"}`))
+		}, []string{"lan", "proxied", "ads", "dns"}},
+		{"replace keeps tag", func() error {
+			return confedit.ReplaceRule(cfg, "lan", json.RawMessage(`{"ip":["192.168.0.0/16"],"outboundTag":"direct"}`))
+		}, []string{"lan", "proxied", "ads", "dns"}},
+		{"replace renames", func() error {
+			return confedit.ReplaceRule(cfg, "dns", json.RawMessage(`{"ruleTag":"dns53","port":"53","outboundTag":"direct"}`))
+		}, []string{"lan", "proxied", "ads", "dns53"}},
+		{"delete", func() error { return confedit.DeleteRule(cfg, "proxied") }, []string{"lan", "ads", "dns53"}},
+	}
+
+	for _, st := range steps {
+		if err := st.edit(); err != nil {
+			t.Fatalf("%s: %v", st.name, err)
+		}
+		if got := ruleTags(t, cfg); !slices.Equal(got, st.want) {
+			t.Fatalf("%s: rules = %v, want %v", st.name, got, st.want)
+		}
+	}
+
+	if err := confedit.ReplaceRule(cfg, "lan", json.RawMessage(`{"ruleTag":"ads","port":1,"outboundTag":"direct"}`)); !errors.Is(err, confedit.ErrConflict) {
+		t.Errorf("rename to taken tag error = %v, want %v", err, confedit.ErrConflict)
+	}
+	if err := confedit.MoveRule(cfg, "none", 0); !errors.Is(err, confedit.ErrNotFound) {
+		t.Errorf("move unknown error = %v, want %v", err, confedit.ErrNotFound)
+	}
+}
+
+func Test_UntaggedRules(t *testing.T) {
+	cfg := domain.CoreConfiguration{
+		"outbounds": json.RawMessage(`[{"tag":"direct"},{"tag":"block"}]`),
+		"routing": json.RawMessage(`{"rules":[
+			{"ruleTag":"lan","ip":["10.0.0.0/8"],"outboundTag":"direct"},
+			{"port":"25","outboundTag":"block"},
+			{"port":"25","outboundTag":"block"}
+		]}`),
+	}
+
+	listed := ruleTags(t, cfg)
+	if listed[0] != "lan" || !strings.HasPrefix(listed[1], "rule-") || listed[2] != listed[1]+"-2" {
+		t.Fatalf("rules = %v, want lan and generated tags", listed)
+	}
+
+	if err := confedit.MoveRule(cfg, listed[2], 0); err != nil {
+		t.Fatalf("move by generated tag: %v", err)
+	}
+
+	want := []string{listed[2], "lan", listed[1]}
+	if got := ruleTags(t, cfg); !slices.Equal(got, want) {
+		t.Fatalf("rules = %v, want %v", got, want)
+	}
+
+	rules, err := confedit.Rules(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stored struct {
+		RuleTag string `json:"ruleTag"`
+	}
+	if err := json.Unmarshal(rules[0].Raw, &stored); err != nil || stored.RuleTag != listed[2] {
+		t.Errorf("generated tag not stored: %s", rules[0].Raw)
+	}
+
+	if err := confedit.DeleteRule(cfg, listed[1]); err != nil {
+		t.Fatalf("delete by generated tag: %v", err)
+	}
+}
+
+func Test_MatchRoute(t *testing.T) {
+	cfg := domain.CoreConfiguration{
+		"outbounds": json.RawMessage(`[{"tag":"proxy"},{"tag":"direct"},{"tag":"block"}]`),
+		"routing": json.RawMessage(`{"rules":[
+			{"ruleTag":"api","inboundTag":["api"],"outboundTag":"direct"},
+			{"ruleTag":"ads","domain":["keyword:ads","geosite:category-ads"],"outboundTag":"block"},
+			{"ruleTag":"lan","ip":["10.0.0.0/8","fc00::/7"],"outboundTag":"direct"},
+			{"ruleTag":"cn","domain":["geosite:cn"],"outboundTag":"direct"},
+			{"ruleTag":"dns","network":"udp","port":"53,853","outboundTag":"direct"},
+			{"ruleTag":"site","domain":["domain:example.com","full:exact.org","regexp:^shop\\d+\\.net$"],"outboundTag":"direct"}
+		]}`),
+	}
+
+	// probe of a tcp connection to a domain, fields not set by tt are known
+	site := func(domain string) confedit.Probe {
+		return confedit.Probe{
+			InboundTag: "socks",
+			Network:    "tcp",
+			Domain:     domain,
+			IP:         netip.MustParseAddr("93.184.216.34"),
+			Port:       443,
+		}
+	}
+	addr := func(network, ip string, port int) confedit.Probe {
+		return confedit.Probe{
+			InboundTag: "socks",
+			Network:    network,
+			Domain:     "host.example",
+			IP:         netip.MustParseAddr(ip),
+			Port:       port,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		probe        confedit.Probe
+		rule         string
+		undetermined []string
+	}{
+		{"inbound tag", confedit.Probe{InboundTag: "api"}, "api", nil},
+		{"keyword", site("ads.example.com"), "ads", nil},
+		{"subdomain after geosite", site("www.Example.com"), "site", []string{"ads", "cn"}},
+		{"full", site("exact.org"), "site", []string{"ads", "cn"}},
+		{"full mismatch", site("www.exact.org"), "", []string{"ads", "cn"}},
+		{"regexp", site("shop42.net"), "site", []string{"ads", "cn"}},
+		{"cidr", addr("tcp", "10.1.2.3", 443), "lan", []string{"ads"}},
+		{"ipv6 cidr", addr("tcp", "fd00::1", 443), "lan", []string{"ads"}},
+		{"network and port", addr("udp", "8.8.8.8", 853), "dns", []string{"ads", "cn"}},
+		{"wrong network", addr("tcp", "8.8.8.8", 53), "", []string{"ads", "cn"}},
+		{"unknown fields", confedit.Probe{Domain: "www.example.com"}, "site", []string{"api", "ads", "lan", "cn", "dns"}},
+		{"unknown port", addr("udp", "8.8.8.8", 0), "", []string{"ads", "cn", "dns"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := confedit.MatchRoute(cfg, tt.probe)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if m.Matched != (tt.rule != "") || m.Rule.Tag != tt.rule {
+				t.Errorf("matched %v rule %q, want %q", m.Matched, m.Rule.Tag, tt.rule)
+			}
+			if m.Default != "proxy" {
+				t.Errorf("default = %q, want proxy", m.Default)
+			}
+
+			var undetermined []string
+			for _, r := range m.Undetermined {
+				undetermined = append(undetermined, r.Tag)
+			}
+			if !slices.Equal(undetermined, tt.undetermined) {
+				t.Errorf("undetermined = %v, want %v", undetermined, tt.undetermined)
+			}
+		})
+	}
+}
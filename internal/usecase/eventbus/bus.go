@@ -0,0 +1,88 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package eventbus
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/eterline/xraymon/internal/domain"
+)
+
+// Bus - in-process fan-out of lifecycle events.
+// Publish never blocks: events are dropped for subscribers with a full buffer.
+type Bus struct {
+	mu     sync.RWMutex
+	nextID uint64
+	subs   map[uint64]chan domain.Event
+
+	logger *slog.Logger
+}
+
+func New(logger *slog.Logger) *Bus {
+	return &Bus{
+		subs:   make(map[uint64]chan domain.Event),
+		logger: logger,
+	}
+}
+
+func (b *Bus) Publish(e domain.Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for id, ch := range b.subs {
+		select {
+		case ch <- e:
+		default:
+			b.logger.Debug("event dropped for slow subscriber", "subscriber", id, "kind", e.Kind)
+		}
+	}
+}
+
+// Subscribe - registers a new subscriber. The returned function unsubscribes and closes the channel.
+func (b *Bus) Subscribe(buffer int) (<-chan domain.Event, func()) {
+	if buffer <= 0 {
+		buffer = 1
+	}
+
+	ch := make(chan domain.Event, buffer)
+
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = ch
+	b.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, id)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, cancel
+}
+
+type namedPublisher struct {
+	pub  domain.EventPublisher
+	name string
+}
+
+// Named - returns publisher that stamps events with the core instance name.
+func Named(pub domain.EventPublisher, instance string) domain.EventPublisher {
+	return namedPublisher{pub: pub, name: instance}
+}
+
+func (np namedPublisher) Publish(e domain.Event) {
+	e.Instance = np.name
+	np.pub.Publish(e)
+}
@@ -0,0 +1,10 @@
+package manager
+
+// WithAttached - supervises an externally managed core. Runs attach to the core instead of starting it,
+// so the config is neither loaded nor tested, and config applies, binary switches and log settings
+// changes are refused with domain.ErrAttached. Restarts re-attach, Stop detaches without touching the core.
+func WithAttached() Option {
+	return func(m *CoreManager) {
+		m.attached = true
+	}
+}
@@ -3,21 +3,39 @@ package manager
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
 	"sync"
 	"time"
 
 	"github.com/eterline/xraymon/internal/domain"
 	"github.com/eterline/xraymon/internal/infra/log"
+	"github.com/eterline/xraymon/internal/usecase/confdiff"
 )
 
 type CoreRunner interface {
-	Run(ctx context.Context, conf domain.CoreConfiguration, level string) error
+	Run(ctx context.Context, conf domain.CoreConfiguration, logs domain.LogSettings) error
+	Test(ctx context.Context, conf domain.CoreConfiguration, logs domain.LogSettings) error
+	PID() int
+	SetBinary(path string)
+}
+
+// ProcessSampler - reads resource usage of a process.
+type ProcessSampler interface {
+	Sample(pid int) (domain.ProcessStats, error)
 }
 
 type LastLogger interface {
 	LastLog() string
 }
 
+var (
+	ErrManagerClosed = errors.New("core manager closed")
+	ErrCoreRunning   = errors.New("core is already running")
+	ErrCoreNotActive = errors.New("core process is not running")
+	ErrNoBinaries    = errors.New("core binary switching disabled")
+)
+
 type CoreManager struct {
 	rootCtx context.Context
 
@@ -27,32 +45,147 @@ type CoreManager struct {
 
 	loader domain.ConfigLoader
 	dsp    CoreRunner
-	level  string
+	logs   domain.LogSettings
+
+	logStore domain.LogSettingsStore
+	attached bool // the core is managed externally, runs only attach to it
 
 	restartCh chan restartType
+	exited    chan struct{} // closed when the current run returns and the core is reaped
+	done      chan struct{} // closed when the manager loop exits after shutdown
 	closed    bool
+	stopped   bool // core stopped on purpose, crash loop must not revive it
+
+	policy RestartPolicy
+	events domain.EventPublisher
+
+	keeper         domain.ConfigKeeper
+	grace          time.Duration // run time after which the config is known-good
+	guarded        bool          // early crash of the current run rolls the config back
+	graceTimer     *time.Timer   // marks the running config good when the grace period passes
+	lastRollback   time.Time
+	rollbackReason string
+
+	applier    domain.HandlerApplier
+	running    domain.CoreConfiguration // config of the current run, kept in sync by hot-apply
+	runningRev uint64                   // revision of the running config, 0 - unknown
+
+	sampler ProcessSampler
+
+	meter       TrafficMeter
+	drainPolicy DrainPolicy
+	draining    bool
+	drainRate   uint64
+
+	prober        domain.CoreProber
+	health        HealthPolicy
+	healthy       bool
+	probeFailures int
+	probeLatency  time.Duration
+
+	binaries      domain.CoreBinarySelector
+	binary        string // active core binary name
+	pendingBinary string // switched binary awaiting a stable run
+	prevBinary    string // binary restored when the pending one fails
 
 	lastLine      LastLogger
-	working       bool
+	state         domain.CoreRunState
+	runID         uint64 // id of the current run, results of older runs are ignored
 	lastStartTime time.Time
 	crashRestarts int // число рестартов подряд после краша
+	restarts      int // total core restarts since the manager start
+
+	crashLog   []domain.CrashRecord
+	crashLimit int
+	history    domain.ConfigHistory
 }
 
+const defaultBinaryGrace = 30 * time.Second
+
 type restartType int
 
 const (
 	restartManual restartType = iota
 	restartCrash
+	restartApply
+	restartDrain      // manual restart after draining connections
+	restartDrainApply // apply that drains connections before a restart
 )
 
-func NewCoreManager(ctx context.Context, dsp CoreRunner, loader domain.ConfigLoader, last LastLogger, level string) *CoreManager {
+// Option - functional option for CoreManager.
+type Option func(*CoreManager)
+
+// WithEvents - sets publisher for core lifecycle events.
+func WithEvents(pub domain.EventPublisher) Option {
+	return func(m *CoreManager) {
+		m.events = pub
+	}
+}
+
+// WithRollback - enables last-known-good config tracking.
+// A config is marked good after the core has run for grace; a crash within grace after ApplyConfig restores it.
+func WithRollback(keeper domain.ConfigKeeper, grace time.Duration) Option {
+	return func(m *CoreManager) {
+		m.keeper = keeper
+		m.grace = grace
+	}
+}
+
+// WithHotApply - enables applying inbound and outbound changes without core restart.
+func WithHotApply(applier domain.HandlerApplier) Option {
+	return func(m *CoreManager) {
+		m.applier = applier
+	}
+}
+
+// WithProcessSampler - enables core process resource metrics.
+func WithProcessSampler(s ProcessSampler) Option {
+	return func(m *CoreManager) {
+		m.sampler = s
+	}
+}
+
+// WithBinaries - enables core binary switching, the active binary of the selector is used from the start.
+func WithBinaries(sel domain.CoreBinarySelector) Option {
+	return func(m *CoreManager) {
+		m.binaries = sel
+	}
+}
+
+// WithRestartPolicy - sets crash restart policy.
+func WithRestartPolicy(p RestartPolicy) Option {
+	return func(m *CoreManager) {
+		m.policy = p.normalize()
+	}
+}
+
+func NewCoreManager(ctx context.Context, dsp CoreRunner, loader domain.ConfigLoader, last LastLogger, logs domain.LogSettings, opts ...Option) *CoreManager {
 	m := &CoreManager{
 		dsp:       dsp,
 		loader:    loader,
-		level:     level,
+		logs:      logs,
 		rootCtx:   ctx,
 		lastLine:  last,
+		policy:    DefaultRestartPolicy(),
+		events:    domain.NopPublisher,
+		state:     domain.CoreStopped,
 		restartCh: make(chan restartType, 1),
+		done:      make(chan struct{}),
+
+		crashLimit: defaultCrashHistory,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if m.binaries != nil {
+		m.binary = m.binaries.Active()
+		if path, err := m.binaries.Path(m.binary); err == nil {
+			m.dsp.SetBinary(path)
+		} else {
+			log.MustLoggerFromContext(ctx).Error("active core binary unavailable", "binary", m.binary, "error", err)
+		}
 	}
 
 	go m.loop()
@@ -60,26 +193,165 @@ func NewCoreManager(ctx context.Context, dsp CoreRunner, loader domain.ConfigLoa
 	return m
 }
 
+// Start - starts the core if it is not running. Clears the stopped and failed states.
 func (m *CoreManager) Start() error {
-	return m.Restart()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return ErrManagerClosed
+	}
+
+	if m.state == domain.CoreRunning {
+		return ErrCoreRunning
+	}
+
+	m.stopped = false
+	m.guarded = false
+	m.request(restartManual)
+
+	return nil
 }
 
-func (m *CoreManager) Restart() error {
+// Stop - stops the core on purpose. A stopped core is not restarted after crashes.
+func (m *CoreManager) Stop() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	if m.closed {
-		return errors.New("core manager closed")
+		return ErrManagerClosed
 	}
 
+	m.stopped = true
+	m.guarded = false
+	m.runID++
+
+	// drop pending restart requests
 	select {
-	case m.restartCh <- restartManual:
+	case <-m.restartCh:
 	default:
 	}
 
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+
+	m.state = domain.CoreStopped
+
+	return nil
+}
+
+// Restart - restarts the core. Starts it when stopped or failed.
+func (m *CoreManager) Restart() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return ErrManagerClosed
+	}
+
+	m.stopped = false
+	m.guarded = false
+	m.request(restartManual)
+
+	return nil
+}
+
+// ApplyConfig - applies the saved config. Handler changes are hot-applied to the running core,
+// other changes restart it and guard the new run with rollback.
+func (m *CoreManager) ApplyConfig() error {
+	if m.attached {
+		return domain.ErrAttached
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return ErrManagerClosed
+	}
+
+	m.stopped = false
+	m.request(restartApply)
+
 	return nil
 }
 
+// SwitchBinary - restarts the core with another binary. The switch is persisted after the core
+// survives the guard window, an earlier failure restores the previous binary.
+func (m *CoreManager) SwitchBinary(name string) error {
+	if m.attached {
+		return domain.ErrAttached
+	}
+
+	if m.binaries == nil {
+		return ErrNoBinaries
+	}
+
+	path, err := m.binaries.Path(name)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return ErrManagerClosed
+	}
+
+	if m.pendingBinary == "" {
+		if name == m.binary {
+			return nil
+		}
+		m.prevBinary = m.binary
+	}
+
+	m.pendingBinary = name
+	m.dsp.SetBinary(path)
+
+	m.stopped = false
+	m.request(restartManual)
+
+	return nil
+}
+
+func (m *CoreManager) rollbackEnabled() bool {
+	return m.keeper != nil && m.grace > 0
+}
+
+// binaryGrace - run time after which a switched binary is considered working.
+func (m *CoreManager) binaryGrace() time.Duration {
+	if m.grace > 0 {
+		return m.grace
+	}
+	return defaultBinaryGrace
+}
+
+// request - queues restart request, manual request replaces a pending one. Must be called with m.mu held.
+func (m *CoreManager) request(t restartType) {
+	select {
+	case m.restartCh <- t:
+		return
+	default:
+	}
+
+	if t == restartCrash {
+		return
+	}
+
+	select {
+	case <-m.restartCh:
+	default:
+	}
+
+	select {
+	case m.restartCh <- t:
+	default:
+	}
+}
+
 func (m *CoreManager) loop() {
 	log := log.MustLoggerFromContext(m.rootCtx)
 
@@ -87,100 +359,575 @@ func (m *CoreManager) loop() {
 		select {
 		case <-m.rootCtx.Done():
 			log.Info("core manager exit")
-			if m.cancel != nil {
-				m.cancel()
-			}
+			m.shutdown()
+			m.waitExited()
+			close(m.done)
 			return
 
 		case t := <-m.restartCh:
 			if t == restartCrash {
-				delay, t := m.handleCrashDelay()
-				log.Error("core crash", "restart_in", int(delay.Seconds()))
-				<-t
+				var ok bool
+				if t, ok = m.waitCrashDelay(); !ok {
+					continue
+				}
+			}
+
+			switch t {
+			case restartApply, restartDrainApply:
+				m.performApply(t == restartDrainApply)
+			default:
+				m.performRestart(t)
 			}
+		}
+	}
+}
+
+// performApply - hot-applies handler changes to the running core or falls back to a guarded restart,
+// drained when drain is set.
+func (m *CoreManager) performApply(drain bool) {
+	log := log.MustLoggerFromContext(m.rootCtx)
+
+	m.mu.Lock()
+	hot := m.applier != nil && m.state == domain.CoreRunning && m.running != nil
+	old, id := m.running, m.runID
+	m.mu.Unlock()
+
+	if hot {
+		applied, err := m.hotApply(old, id)
+		if err != nil {
+			log.Error("hot apply failed, restarting core", "error", err)
+		}
+		if applied {
+			return
+		}
+	}
+
+	m.mu.Lock()
+	m.guarded = m.rollbackEnabled()
+	m.mu.Unlock()
+
+	if drain {
+		m.performRestart(restartDrain)
+		return
+	}
+	m.performRestart(restartManual)
+}
+
+// hotApply - applies the saved config to the running core through HandlerService.
+// Returns false when the change requires a restart.
+func (m *CoreManager) hotApply(old domain.CoreConfiguration, id uint64) (bool, error) {
+	log := log.MustLoggerFromContext(m.rootCtx)
+
+	cfg, err := m.loader.LoadConfig()
+	if err != nil {
+		return false, fmt.Errorf("config load: %w", err)
+	}
+
+	plan := confdiff.Compare(old, cfg)
+
+	switch {
+	case plan.Empty():
+		log.Info("config unchanged, nothing to apply")
+		return true, nil
+	case plan.NeedRestart():
+		log.Info("config change requires core restart", "sections", plan.Restart)
+		return false, nil
+	}
+
+	ctx := m.rootCtx
+
+	for _, tag := range plan.Inbounds.Remove {
+		if err := m.applier.RemoveInbound(ctx, tag); err != nil {
+			return false, fmt.Errorf("remove inbound %q: %w", tag, err)
+		}
+	}
+
+	for _, tag := range plan.Outbounds.Remove {
+		if err := m.applier.RemoveOutbound(ctx, tag); err != nil {
+			return false, fmt.Errorf("remove outbound %q: %w", tag, err)
+		}
+	}
+
+	for _, raw := range plan.Outbounds.Add {
+		if err := m.applier.AddOutbound(ctx, raw); err != nil {
+			return false, fmt.Errorf("add outbound: %w", err)
+		}
+	}
 
-			m.performRestart(t)
+	for _, raw := range plan.Inbounds.Add {
+		if err := m.applier.AddInbound(ctx, raw); err != nil {
+			return false, fmt.Errorf("add inbound: %w", err)
 		}
 	}
+
+	rev := m.revisionOf(cfg)
+
+	// the applied config is guarded like a restarted one: a crash within grace rolls it back
+	m.mu.Lock()
+	if id == m.runID {
+		m.running = cfg
+		m.runningRev = rev
+		if m.rollbackEnabled() {
+			m.guarded = true
+			m.armGrace(id, m.grace)
+		}
+	}
+	m.mu.Unlock()
+
+	msg := fmt.Sprintf(
+		"config hot-applied: inbounds -%d +%d, outbounds -%d +%d",
+		len(plan.Inbounds.Remove), len(plan.Inbounds.Add),
+		len(plan.Outbounds.Remove), len(plan.Outbounds.Add),
+	)
+	log.Info(msg)
+	m.events.Publish(domain.NewEvent(domain.EventConfigApplied, msg))
+
+	return true, nil
+}
+
+// waitCrashDelay - blocks for crash restart delay. Manual request interrupts the waiting.
+func (m *CoreManager) waitCrashDelay() (restartType, bool) {
+	m.mu.Lock()
+	if m.stopped {
+		m.mu.Unlock()
+		return restartCrash, false
+	}
+	m.state = domain.CoreBackoff
+	m.mu.Unlock()
+
+	delay, next := m.handleCrashDelay()
+	log.MustLoggerFromContext(m.rootCtx).Error("core crash", "restart_in", delay.Round(time.Second).String())
+
+	select {
+	case <-m.rootCtx.Done():
+		return restartCrash, false
+	case <-next:
+		return restartCrash, true
+	case t := <-m.restartCh:
+		return t, true
+	}
 }
 
 func (m *CoreManager) handleCrashDelay() (delay time.Duration, next <-chan time.Time) {
 	m.mu.Lock()
 	n := m.crashRestarts
 	m.mu.Unlock()
-	delay = time.Duration(10+5*n) * time.Second
+	delay = m.policy.Delay(n)
+
+	ev := domain.NewEvent(domain.EventCoreBackoff, "core restart delayed after crash")
+	ev.Attempt = n
+	ev.Delay = delay
+	m.events.Publish(ev)
+
 	return delay, time.After(delay)
 }
 
 func (m *CoreManager) performRestart(t restartType) {
+	if t == restartDrain {
+		m.drain()
+	}
+
+	cfg, ok := m.prepareRestart(t)
+	if !ok {
+		return
+	}
+
+	// the old core may still hold the API endpoint while it stops
+	m.waitExited()
+
+	m.mu.Lock()
+	logs := m.logs
+	m.mu.Unlock()
+
+	log := log.MustLoggerFromContext(m.rootCtx)
+
+	var testErr error
+	if !m.attached {
+		testErr = m.dsp.Test(m.rootCtx, cfg, logs)
+	}
+	rev := m.revisionOf(cfg)
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if m.closed {
+	if m.closed || m.stopped {
 		return
 	}
 
-	if t == restartManual {
+	if testErr != nil {
+		log.Error("core config test failed", "error", testErr)
+		m.recordCrash(testErr, cfg, rev, 0)
+		if !m.rollback(log, testErr) {
+			m.registerCrash(log)
+		}
+		return
+	}
+
+	ctx, cancel := context.WithCancel(m.rootCtx)
+	m.ctx = ctx
+	m.cancel = cancel
+
+	m.runID++
+	m.running = cfg
+	m.runningRev = rev
+	m.state = domain.CoreRunning
+	m.healthy = true
+	m.probeFailures = 0
+	m.probeLatency = 0
+	if !m.lastStartTime.IsZero() {
+		m.restarts++
+	}
+	m.lastStartTime = time.Now()
+
+	m.events.Publish(domain.NewEvent(domain.EventCoreStarted, "core started"))
+
+	// a binary switch is confirmed by its own grace period
+	switch {
+	case m.pendingBinary != "":
+		m.armGrace(m.runID, m.binaryGrace())
+	case m.rollbackEnabled():
+		m.armGrace(m.runID, m.grace)
+	}
+
+	exited := make(chan struct{})
+	m.exited = exited
+
+	go func(id uint64) {
+		defer close(exited)
+		m.run(ctx, id, cfg, logs)
+	}(m.runID)
+}
+
+// waitExited - blocks until the last started run returns.
+func (m *CoreManager) waitExited() {
+	m.mu.Lock()
+	exited := m.exited
+	m.mu.Unlock()
+
+	if exited != nil {
+		<-exited
+	}
+}
+
+// Done - closed when the manager has shut down and the core process is reaped.
+func (m *CoreManager) Done() <-chan struct{} {
+	return m.done
+}
+
+// prepareRestart - stops the current run and loads config for the next one.
+func (m *CoreManager) prepareRestart(t restartType) (domain.CoreConfiguration, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, false
+	}
+
+	if t == restartCrash && m.stopped {
+		return nil, false
+	}
+
+	if t == restartManual || t == restartDrain {
 		m.crashRestarts = 0
 	}
 
+	m.runID++
 	if m.cancel != nil {
 		m.cancel()
+		m.cancel = nil
+	}
+
+	if m.attached {
+		return domain.CoreConfiguration{}, true
 	}
 
 	cfg, err := m.loader.LoadConfig()
 	if err != nil {
 		log.MustLoggerFromContext(m.rootCtx).Error("config load failed", "error", err)
+		m.state = domain.CoreCrashed
+		return nil, false
+	}
+
+	return cfg, true
+}
+
+// TestConfig - validates config with the core binary before it is saved or applied.
+func (m *CoreManager) TestConfig(ctx context.Context, cfg domain.CoreConfiguration) error {
+	if m.attached {
+		return domain.ErrAttached
+	}
+
+	m.mu.Lock()
+	logs := m.logs
+	m.mu.Unlock()
+
+	return m.dsp.Test(ctx, cfg, logs)
+}
+
+func (m *CoreManager) run(ctx context.Context, id uint64, cfg domain.CoreConfiguration, logs domain.LogSettings) {
+	log := log.MustLoggerFromContext(ctx)
+
+	log.Info("run core", "log_level", logs.Level)
+
+	if m.prober != nil {
+		probeCtx, stopProbe := context.WithCancel(ctx)
+		defer stopProbe()
+		go m.probeLoop(probeCtx, id)
+	}
+
+	err := m.dsp.Run(ctx, cfg, logs)
+
+	ev := domain.NewEvent(domain.EventCoreExited, "core exited")
+	ev.ExitCode = exitCode(err)
+	if err != nil {
+		ev.Message = err.Error()
+	}
+	m.events.Publish(ev)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if id != m.runID {
+		// core was stopped or restarted on purpose
 		return
 	}
 
-	ctx, cancel := context.WithCancel(m.rootCtx)
-	m.ctx = ctx
-	m.cancel = cancel
+	if err != nil {
+		log.Error("core crashed", "error", err)
+		m.recordCrash(err, m.running, m.runningRev, time.Since(m.lastStartTime))
+		m.handleCrash(log, err)
+		return
+	}
 
-	m.working = true
-	m.lastStartTime = time.Now()
+	log.Info("core exited")
+	m.state = domain.CoreStopped
+	m.crashRestarts = 0
+}
 
-	go func() {
-		log := log.MustLoggerFromContext(ctx)
+// armGrace - starts the grace period of the run, replacing the pending one. Must be called with m.mu held.
+func (m *CoreManager) armGrace(id uint64, d time.Duration) {
+	if m.graceTimer != nil {
+		m.graceTimer.Stop()
+	}
+	m.graceTimer = time.AfterFunc(d, func() { m.markGood(id) })
+}
 
-		log.Info("run core", "log_level", m.level)
+// markGood - stores the running config and the binary of a run that survived the grace period
+// as last-known-good. The config is the one started or hot-applied last, the config file may hold a newer one.
+func (m *CoreManager) markGood(id uint64) {
+	log := log.MustLoggerFromContext(m.rootCtx)
 
-		err := m.dsp.Run(ctx, cfg, m.level)
-		if err != nil {
-			log.Error("core crashed", "error", err)
+	m.mu.Lock()
+	if id != m.runID || m.state != domain.CoreRunning {
+		m.mu.Unlock()
+		return
+	}
+	m.guarded = false
+	cfg := m.running
+
+	bin := m.pendingBinary
+	if bin != "" {
+		m.binary = bin
+		m.pendingBinary = ""
+		m.prevBinary = ""
+	}
+	m.mu.Unlock()
 
-			m.mu.Lock()
-			m.working = false
-			m.crashRestarts++
-			m.mu.Unlock()
+	if bin != "" {
+		if err := m.binaries.Activate(bin); err != nil {
+			log.Error("failed to persist active core binary", "binary", bin, "error", err)
+		}
+		log.Info("core binary switched", "binary", bin)
+		m.events.Publish(domain.NewEvent(domain.EventBinarySwitched, "core binary switched to "+bin))
+	}
 
-			select {
-			case m.restartCh <- restartCrash:
-			default:
-			}
-			return
+	if m.rollbackEnabled() {
+		if err := m.keeper.MarkGood(cfg); err != nil {
+			log.Error("failed to mark config as known-good", "error", err)
 		}
+	}
+}
+
+// rollbackBinary - restores the previous binary after a failure of the switched one and restarts the core.
+// Returns false when no switch is pending. Must be called with m.mu held.
+func (m *CoreManager) rollbackBinary(log *slog.Logger, cause error) bool {
+	if m.pendingBinary == "" {
+		return false
+	}
+
+	failed, prev := m.pendingBinary, m.prevBinary
+	m.pendingBinary = ""
+	m.prevBinary = ""
+
+	path, err := m.binaries.Path(prev)
+	if err != nil {
+		log.Error("core binary rollback failed", "binary", prev, "error", err)
+		return false
+	}
+	m.dsp.SetBinary(path)
 
-		m.mu.Lock()
-		m.working = true
+	reason := fmt.Sprintf("core binary %s failed: %v", failed, cause)
+	log.Warn("core binary rolled back", "binary", prev, "reason", reason)
+
+	m.lastRollback = time.Now()
+	m.rollbackReason = reason
+	m.events.Publish(domain.NewEvent(domain.EventBinaryRollback, reason))
+
+	m.state = domain.CoreCrashed
+	m.request(restartManual)
+
+	return true
+}
+
+// rollback - restores the previous binary or last-known-good config after a guarded run failure and restarts the core.
+// Returns false when the run is not guarded or restore failed. Must be called with m.mu held.
+func (m *CoreManager) rollback(log *slog.Logger, cause error) bool {
+	if m.rollbackBinary(log, cause) {
+		return true
+	}
+
+	if !m.guarded {
+		return false
+	}
+	m.guarded = false
+
+	if err := m.keeper.RestoreGood(); err != nil {
+		log.Error("config rollback failed", "error", err)
+		return false
+	}
+
+	reason := fmt.Sprintf("core failed within %s after config apply: %v", m.grace, cause)
+	log.Warn("config rolled back to last-known-good", "reason", reason)
+
+	m.lastRollback = time.Now()
+	m.rollbackReason = reason
+	m.events.Publish(domain.NewEvent(domain.EventConfigRollback, reason))
+
+	m.state = domain.CoreCrashed
+	m.request(restartManual)
+
+	return true
+}
+
+// handleCrash - rolls back a guarded run or schedules crash restart. Must be called with m.mu held.
+func (m *CoreManager) handleCrash(log *slog.Logger, cause error) {
+	if m.rollback(log, cause) {
+		return
+	}
+
+	if m.policy.ResetAfter > 0 && time.Since(m.lastStartTime) >= m.policy.ResetAfter {
 		m.crashRestarts = 0
-		m.mu.Unlock()
-	}()
+	}
+	m.registerCrash(log)
 }
 
-func (m *CoreManager) Status() domain.CoreStatus {
+// registerCrash - counts the crash and schedules restart or trips the breaker. Must be called with m.mu held.
+func (m *CoreManager) registerCrash(log *slog.Logger) {
+	m.crashRestarts++
+
+	if m.policy.Tripped(m.crashRestarts) {
+		log.Error("core crash limit reached, restarts disabled until manual restart", "crashes", m.crashRestarts)
+		m.state = domain.CoreFailed
+
+		ev := domain.NewEvent(domain.EventCoreFailed, "core crash limit reached")
+		ev.Attempt = m.crashRestarts
+		m.events.Publish(ev)
+		return
+	}
+
+	m.state = domain.CoreCrashed
+	m.request(restartCrash)
+}
+
+// exitCode - extracts process exit code from the runner error.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var ee *domain.CoreExitError
+	if errors.As(err, &ee) {
+		return ee.ExitCode
+	}
+
+	var ec interface{ ExitCode() int }
+	if errors.As(err, &ec) {
+		return ec.ExitCode()
+	}
+
+	return -1
+}
+
+func (m *CoreManager) shutdown() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.closed = true
+	m.runID++
+
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+
+	m.state = domain.CoreStopped
+}
+
+// ProcessStats - returns resource usage of the running core process.
+func (m *CoreManager) ProcessStats() (domain.ProcessStats, error) {
+	if m.sampler == nil {
+		return domain.ProcessStats{}, errors.New("process metrics disabled")
+	}
+
+	m.mu.Lock()
+	running := m.state == domain.CoreRunning
+	m.mu.Unlock()
+
+	pid := m.dsp.PID()
+	if !running || pid == 0 {
+		return domain.ProcessStats{}, ErrCoreNotActive
+	}
+
+	return m.sampler.Sample(pid)
+}
+
+func (m *CoreManager) Status() domain.CoreStatus {
+	m.mu.Lock()
+
+	working := m.state == domain.CoreRunning
+
 	var wt time.Duration
-	if m.working {
+	if working {
 		wt = time.Since(m.lastStartTime)
 	}
 
-	return domain.CoreStatus{
-		Working:     m.working,
+	status := domain.CoreStatus{
+		State:       m.state,
+		Working:     working,
 		LastLog:     m.lastLine.LastLog(),
 		WorkingTime: wt,
+		Crashes:     m.crashRestarts,
+		Restarts:    m.restarts,
+		Binary:      m.binary,
+		Attached:    m.attached,
+
+		Healthy:      working && (m.prober == nil || m.healthy),
+		ProbeLatency: m.probeLatency,
+
+		Draining:  m.draining,
+		DrainRate: m.drainRate,
+
+		LastRollback:   m.lastRollback,
+		RollbackReason: m.rollbackReason,
 	}
+
+	m.mu.Unlock()
+
+	if working && m.sampler != nil {
+		if ps, err := m.ProcessStats(); err == nil {
+			status.Process = ps
+		}
+	}
+
+	return status
 }
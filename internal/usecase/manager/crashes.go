@@ -0,0 +1,86 @@
+package manager
+
+import (
+	"errors"
+	"time"
+
+	"github.com/eterline/xraymon/internal/domain"
+)
+
+const defaultCrashHistory = 32
+
+// WithCrashHistory - sets number of kept crash records.
+func WithCrashHistory(n int) Option {
+	return func(m *CoreManager) {
+		if n > 0 {
+			m.crashLimit = n
+		}
+	}
+}
+
+// WithConfigHistory - resolves revision numbers of crashed configs.
+func WithConfigHistory(history domain.ConfigHistory) Option {
+	return func(m *CoreManager) {
+		m.history = history
+	}
+}
+
+// recordCrash - appends crash of a run with cfg of revision rev to the history, a run refused
+// by the config test has zero uptime. Must be called with m.mu held.
+func (m *CoreManager) recordCrash(cause error, cfg domain.CoreConfiguration, rev uint64, uptime time.Duration) {
+	rec := domain.CrashRecord{
+		Time:       time.Now(),
+		ExitCode:   exitCode(cause),
+		Reason:     cause.Error(),
+		Uptime:     uptime,
+		ConfigHash: cfg.Hash(),
+		ConfigRev:  rev,
+		Binary:     m.binary,
+	}
+
+	var ee *domain.CoreExitError
+	if errors.As(cause, &ee) {
+		rec.Signal = ee.Signal
+		rec.Output = ee.Output
+	}
+
+	if len(m.crashLog) >= m.crashLimit {
+		m.crashLog = append(m.crashLog[:0], m.crashLog[len(m.crashLog)-m.crashLimit+1:]...)
+	}
+	m.crashLog = append(m.crashLog, rec)
+}
+
+// revisionOf - returns the newest kept revision with cfg content, 0 when there is none.
+// Reads the history from disk, so it must be called without m.mu held.
+func (m *CoreManager) revisionOf(cfg domain.CoreConfiguration) uint64 {
+	hash := cfg.Hash()
+	if m.history == nil || hash == "" {
+		return 0
+	}
+
+	revs, err := m.history.Revisions()
+	if err != nil {
+		return 0
+	}
+
+	for _, rev := range revs {
+		if rev.Hash == hash {
+			return rev.Number
+		}
+	}
+
+	return 0
+}
+
+// Crashes - returns crash history, newest first.
+func (m *CoreManager) Crashes() []domain.CrashRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	list := make([]domain.CrashRecord, 0, len(m.crashLog))
+	for i := len(m.crashLog) - 1; i >= 0; i-- {
+		list = append(list, m.crashLog[i])
+	}
+
+	return list
+}
@@ -0,0 +1,203 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/infra/log"
+)
+
+// DrainPolicy - connection draining before requested restarts.
+type DrainPolicy struct {
+	Timeout   time.Duration // longest drain, the core is restarted afterwards whatever the traffic
+	Threshold uint64        // inbound traffic in bytes per second considered drained
+	Interval  time.Duration // traffic check period
+}
+
+func (p DrainPolicy) normalize() DrainPolicy {
+	if p.Interval <= 0 {
+		p.Interval = time.Second
+	}
+	return p
+}
+
+// TrafficMeter - recent traffic statistics of the running core.
+type TrafficMeter interface {
+	StatsNow(ctx context.Context) ([]domain.StatsSnapshot, error)
+}
+
+// WithDrain - enables draining restarts. Inbounds of the running core are removed through the
+// WithHotApply applier, so no new connections are accepted, then the restart waits until the inbound
+// traffic falls to the threshold or the timeout passes.
+func WithDrain(meter TrafficMeter, p DrainPolicy) Option {
+	return func(m *CoreManager) {
+		if meter == nil || p.Timeout <= 0 {
+			return
+		}
+		m.meter = meter
+		m.drainPolicy = p.normalize()
+	}
+}
+
+// DrainRestart - restarts the core after draining its connections. Without WithDrain or when the core
+// is not running it is the same as Restart.
+func (m *CoreManager) DrainRestart() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return ErrManagerClosed
+	}
+
+	m.stopped = false
+	m.guarded = false
+	m.request(restartDrain)
+
+	return nil
+}
+
+// DrainApplyConfig - applies the saved config like ApplyConfig, a restart it needs is drained.
+func (m *CoreManager) DrainApplyConfig() error {
+	if m.attached {
+		return domain.ErrAttached
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return ErrManagerClosed
+	}
+
+	m.stopped = false
+	m.request(restartDrainApply)
+
+	return nil
+}
+
+// drain - closes inbounds of the running core and waits for its connections to calm down.
+// Runs in the manager loop, Stop, shutdown and a crash of the core cut it short.
+func (m *CoreManager) drain() {
+	log := log.MustLoggerFromContext(m.rootCtx)
+
+	m.mu.Lock()
+	if m.meter == nil || m.applier == nil || m.state != domain.CoreRunning || m.running == nil {
+		m.mu.Unlock()
+		return
+	}
+	ctx, cfg, id, p := m.ctx, m.running, m.runID, m.drainPolicy
+	m.draining = true
+	m.drainRate = 0
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		m.draining = false
+		m.drainRate = 0
+		m.mu.Unlock()
+	}()
+
+	tags := inboundTags(cfg)
+	for _, tag := range tags {
+		if err := m.applier.RemoveInbound(ctx, tag); err != nil {
+			log.Warn("drain: failed to close inbound", "inbound", tag, "error", err)
+		}
+	}
+
+	start := time.Now()
+	msg := fmt.Sprintf("draining connections, %d inbounds closed", len(tags))
+	log.Info(msg, "timeout", p.Timeout, "threshold", p.Threshold)
+	m.events.Publish(domain.NewEvent(domain.EventDrainStarted, msg))
+
+	timeout := time.NewTimer(p.Timeout)
+	defer timeout.Stop()
+
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	var rate uint64
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("drain interrupted")
+			return
+		case <-timeout.C:
+			m.drainFinished(log, "drain timed out", start, rate)
+			return
+		case <-ticker.C:
+		}
+
+		m.mu.Lock()
+		alive := id == m.runID && m.state == domain.CoreRunning
+		m.mu.Unlock()
+		if !alive {
+			log.Info("drain interrupted, core is not running")
+			return
+		}
+
+		stats, err := m.meter.StatsNow(ctx)
+		if err != nil {
+			log.Warn("drain: traffic stats unavailable", "error", err)
+			continue
+		}
+		rate = inboundRate(stats)
+
+		m.mu.Lock()
+		m.drainRate = rate
+		m.mu.Unlock()
+
+		ev := domain.NewEvent(domain.EventDrainProgress, "draining connections")
+		ev.Rate = rate
+		ev.Delay = time.Since(start)
+		m.events.Publish(ev)
+
+		if rate <= p.Threshold {
+			m.drainFinished(log, "connections drained", start, rate)
+			return
+		}
+	}
+}
+
+func (m *CoreManager) drainFinished(log *slog.Logger, msg string, start time.Time, rate uint64) {
+	took := time.Since(start)
+	log.Info(msg, "took", took.Round(time.Millisecond).String(), "rate", rate)
+
+	ev := domain.NewEvent(domain.EventDrainFinished, msg)
+	ev.Rate = rate
+	ev.Delay = took
+	m.events.Publish(ev)
+}
+
+// inboundTags - returns tags of tagged inbounds of the config.
+func inboundTags(cfg domain.CoreConfiguration) []string {
+	var inbounds []struct {
+		Tag string `json:"tag"`
+	}
+	if err := json.Unmarshal(cfg["inbounds"], &inbounds); err != nil {
+		return nil
+	}
+
+	tags := make([]string, 0, len(inbounds))
+	for _, in := range inbounds {
+		if in.Tag != "" {
+			tags = append(tags, in.Tag)
+		}
+	}
+
+	return tags
+}
+
+// inboundRate - total inbound traffic in bytes per second.
+func inboundRate(stats []domain.StatsSnapshot) uint64 {
+	var rate uint64
+	for _, s := range stats {
+		if s.Type == domain.TypeInbound {
+			rate += s.IO.PerSecRX + s.IO.PerSecTX
+		}
+	}
+	return rate
+}
@@ -0,0 +1,114 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/infra/log"
+)
+
+// HealthPolicy - liveness probing of the running core.
+type HealthPolicy struct {
+	Interval time.Duration // delay between probes, the first probe runs one interval after start
+	Timeout  time.Duration // probe call timeout
+	Failures int           // consecutive failed probes that mark the core unhealthy
+}
+
+func (p HealthPolicy) normalize() HealthPolicy {
+	if p.Timeout <= 0 || p.Timeout > p.Interval {
+		p.Timeout = p.Interval
+	}
+	if p.Failures < 1 {
+		p.Failures = 1
+	}
+	return p
+}
+
+// WithHealthProbe - enables liveness probing, an unhealthy core is restarted as crashed.
+func WithHealthProbe(prober domain.CoreProber, p HealthPolicy) Option {
+	return func(m *CoreManager) {
+		if prober == nil || p.Interval <= 0 {
+			return
+		}
+		m.prober = prober
+		m.health = p.normalize()
+	}
+}
+
+// probeLoop - probes the core of run id until ctx is done.
+func (m *CoreManager) probeLoop(ctx context.Context, id uint64) {
+	ticker := time.NewTicker(m.health.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !m.probe(ctx, id) {
+			return
+		}
+	}
+}
+
+// probe - runs one probe and records its result. Returns false when the run is over.
+func (m *CoreManager) probe(ctx context.Context, id uint64) bool {
+	pctx, cancel := context.WithTimeout(ctx, m.health.Timeout)
+	start := time.Now()
+	err := m.prober.Probe(pctx)
+	latency := time.Since(start)
+	cancel()
+
+	if ctx.Err() != nil {
+		return false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if id != m.runID || m.state != domain.CoreRunning {
+		return false
+	}
+
+	if err == nil {
+		m.healthy = true
+		m.probeFailures = 0
+		m.probeLatency = latency
+		return true
+	}
+
+	m.probeFailures++
+
+	log := log.MustLoggerFromContext(m.rootCtx)
+	log.Warn("core probe failed", "failures", m.probeFailures, "error", err)
+
+	if m.probeFailures < m.health.Failures {
+		return true
+	}
+
+	m.healthy = false
+
+	msg := fmt.Sprintf("core did not answer %d probes: %v", m.probeFailures, err)
+	log.Error("core unhealthy, restarting", "failures", m.probeFailures)
+
+	ev := domain.NewEvent(domain.EventCoreUnhealthy, msg)
+	ev.Attempt = m.probeFailures
+	m.events.Publish(ev)
+
+	// the wedged run is dropped and handled as a crash
+	m.runID++
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+	cause := errors.New(msg)
+	m.recordCrash(cause, m.running, m.runningRev, time.Since(m.lastStartTime))
+	m.handleCrash(log, cause)
+
+	return false
+}
@@ -0,0 +1,64 @@
+package manager
+
+import (
+	"github.com/eterline/xraymon/internal/domain"
+	"github.com/eterline/xraymon/internal/infra/log"
+)
+
+// WithLogStore - persists core log settings changes.
+func WithLogStore(store domain.LogSettingsStore) Option {
+	return func(m *CoreManager) {
+		m.logStore = store
+	}
+}
+
+// LogSettings - returns current core log settings.
+func (m *CoreManager) LogSettings() domain.LogSettings {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.logs
+}
+
+// SetLogSettings - stores new core log settings. Xray LoggerService can only reopen log handlers with
+// the level the core started with, so the settings are applied by a core restart when restart is set,
+// or with the next start otherwise. Returns true when the restart is scheduled.
+func (m *CoreManager) SetLogSettings(ls domain.LogSettings, restart bool) (bool, error) {
+	if m.attached {
+		return false, domain.ErrAttached
+	}
+
+	if err := ls.Validate(); err != nil {
+		return false, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return false, ErrManagerClosed
+	}
+
+	// stored and applied under the same lock, concurrent calls can't leave them diverged
+	if m.logStore != nil {
+		if err := m.logStore.SaveLogSettings(ls); err != nil {
+			return false, err
+		}
+	}
+
+	changed := m.logs != ls
+	m.logs = ls
+
+	log.MustLoggerFromContext(m.rootCtx).Info(
+		"core log settings changed",
+		"level", ls.Level, "dns_log", ls.DNSLog, "mask_address", ls.MaskAddress,
+	)
+
+	if !restart || !changed || m.state != domain.CoreRunning {
+		return false, nil
+	}
+
+	m.request(restartManual)
+
+	return true, nil
+}
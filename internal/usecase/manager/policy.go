@@ -0,0 +1,76 @@
+package manager
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// RestartPolicy - crash restart policy: exponential backoff with jitter and a circuit breaker.
+type RestartPolicy struct {
+	InitialDelay time.Duration // delay before the first crash restart
+	Multiplier   float64       // delay growth factor for every next consecutive crash
+	MaxDelay     time.Duration // upper bound of the delay, 0 - unbounded
+	Jitter       float64       // random delay spread, fraction in [0, 1]
+	MaxCrashes   int           // consecutive crashes that trip the breaker, 0 - unlimited
+	ResetAfter   time.Duration // run time after which the crash counter is reset, 0 - never
+}
+
+// DefaultRestartPolicy - policy used when no other is configured.
+func DefaultRestartPolicy() RestartPolicy {
+	return RestartPolicy{
+		InitialDelay: 10 * time.Second,
+		Multiplier:   2,
+		MaxDelay:     5 * time.Minute,
+		Jitter:       0.2,
+		MaxCrashes:   10,
+		ResetAfter:   5 * time.Minute,
+	}
+}
+
+func (p RestartPolicy) normalize() RestartPolicy {
+	if p.InitialDelay < 0 {
+		p.InitialDelay = 0
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = 1
+	}
+	if p.MaxDelay < 0 {
+		p.MaxDelay = 0
+	}
+	p.Jitter = math.Min(math.Max(p.Jitter, 0), 1)
+	if p.MaxCrashes < 0 {
+		p.MaxCrashes = 0
+	}
+	return p
+}
+
+// Delay - returns restart delay after n consecutive crashes (n >= 1).
+func (p RestartPolicy) Delay(n int) time.Duration {
+	return p.delay(n, rand.Float64())
+}
+
+// delay - computes the delay with r in [0, 1) as the jitter source.
+func (p RestartPolicy) delay(n int, r float64) time.Duration {
+	p = p.normalize()
+
+	if n < 1 {
+		n = 1
+	}
+
+	limit := float64(1 << 62) // keeps the conversion to time.Duration in range
+	if p.MaxDelay > 0 {
+		limit = float64(p.MaxDelay)
+	}
+
+	d := math.Min(float64(p.InitialDelay)*math.Pow(p.Multiplier, float64(n-1)), limit)
+
+	d += d * p.Jitter * (2*r - 1)
+
+	return time.Duration(math.Min(math.Max(d, 0), limit))
+}
+
+// Tripped - reports whether n consecutive crashes trip the breaker.
+func (p RestartPolicy) Tripped(n int) bool {
+	return p.MaxCrashes > 0 && n >= p.MaxCrashes
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package manager_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eterline/xraymon/internal/usecase/manager"
+)
+
+func Test_RestartPolicyDelay(t *testing.T) {
+	p := manager.RestartPolicy{
+		InitialDelay: time.Second,
+		Multiplier:   2,
+		MaxDelay:     10 * time.Second,
+	}
+
+	tests := []struct {
+		n        int
+		expected time.Duration
+	}{
+		{0, time.Second},
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second}, // capped
+		{100, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		got := p.Delay(tt.n)
+		if got != tt.expected {
+			t.Errorf("Delay(%d) = %v, want %v", tt.n, got, tt.expected)
+		}
+	}
+}
+
+func Test_RestartPolicyJitter(t *testing.T) {
+	p := manager.RestartPolicy{
+		InitialDelay: 10 * time.Second,
+		Multiplier:   1,
+		Jitter:       0.5,
+	}
+
+	for i := 0; i < 1000; i++ {
+		got := p.Delay(1)
+		if got < 5*time.Second || got > 15*time.Second {
+			t.Fatalf("Delay(1) = %v, want in [5s, 15s]", got)
+		}
+	}
+}
+
+func Test_RestartPolicyUnbounded(t *testing.T) {
+	p := manager.RestartPolicy{
+		InitialDelay: time.Second,
+		Multiplier:   10,
+	}
+
+	if got := p.Delay(1000); got <= 0 {
+		t.Errorf("Delay(1000) = %v, want positive duration", got)
+	}
+}
+
+func Test_RestartPolicyTripped(t *testing.T) {
+	tests := []struct {
+		max      int
+		n        int
+		expected bool
+	}{
+		{0, 1000, false}, // unlimited
+		{3, 2, false},
+		{3, 3, true},
+		{3, 4, true},
+	}
+
+	for _, tt := range tests {
+		p := manager.RestartPolicy{MaxCrashes: tt.max}
+		if got := p.Tripped(tt.n); got != tt.expected {
+			t.Errorf("MaxCrashes=%d Tripped(%d) = %v, want %v", tt.max, tt.n, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eterline/xraymon/internal/usecase/scheduler"
+)
+
+func Test_CronNext(t *testing.T) {
+	// 2025-03-14 is a friday
+	from := time.Date(2025, 3, 14, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2025, 3, 14, 10, 31, 0, 0, time.UTC)},
+		{"30 3 * * *", time.Date(2025, 3, 15, 3, 30, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 3, 14, 10, 45, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2025, 3, 14, 13, 0, 0, 0, time.UTC)},
+		{"0 0 * * mon", time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2025, 3, 21, 0, 0, 0, 0, time.UTC)}, // day-of-month or friday
+		{"0 0 31 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			c, err := scheduler.ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron: %v", err)
+			}
+
+			if got := c.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ParseCronInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * * fun",
+		"@sometimes",
+	} {
+		if _, err := scheduler.ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func Test_JobNextWindow(t *testing.T) {
+	from := time.Date(2025, 3, 14, 10, 30, 0, 0, time.UTC)
+
+	night, _ := scheduler.ParseCron("0 2 * * *")
+	every, _ := scheduler.ParseCron("0 * * * *")
+	window := &scheduler.Window{Name: "night", Start: night, Duration: 2 * time.Hour}
+
+	tests := []struct {
+		name string
+		job  scheduler.Job
+		from time.Time
+		want time.Time
+	}{
+		{"window only", scheduler.Job{Window: window}, from, time.Date(2025, 3, 15, 2, 0, 0, 0, time.UTC)},
+		{"cron only", scheduler.Job{Cron: every}, from, time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC)},
+		{"deferred to window", scheduler.Job{Cron: every, Window: window}, from, time.Date(2025, 3, 15, 2, 0, 0, 0, time.UTC)},
+		{"inside window", scheduler.Job{Cron: every, Window: window}, time.Date(2025, 3, 15, 2, 10, 0, 0, time.UTC), time.Date(2025, 3, 15, 3, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.job.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
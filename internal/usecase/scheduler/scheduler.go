@@ -0,0 +1,250 @@
+// Copyright (c) 2025 EterLine (Andrew)
+// This file is part of xraymon.
+// Licensed under the MIT License. See the LICENSE file for details.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/eterline/xraymon/internal/domain"
+)
+
+// Action - scheduled operation on a core instance.
+type Action string
+
+const (
+	ActionRestart Action = "restart"
+	ActionRotate  Action = "rotate"
+	ActionApply   Action = "apply"
+)
+
+func ParseAction(s string) (Action, error) {
+	switch a := Action(s); a {
+	case ActionRestart, ActionRotate, ActionApply:
+		return a, nil
+	default:
+		return "", fmt.Errorf("unknown action %q", s)
+	}
+}
+
+// Target - core instance operations available to the scheduler.
+type Target interface {
+	Restart() error
+	ApplyConfig() error
+	RotateJournals() error
+}
+
+// Window - maintenance window opened at every Start match for Duration.
+type Window struct {
+	Name     string
+	Start    *Cron
+	Duration time.Duration
+}
+
+// contains - reports whether t falls into one of the window occurrences.
+func (w *Window) contains(t time.Time) bool {
+	s := w.Start.Next(t.Add(-w.Duration))
+	return !s.IsZero() && !s.After(t)
+}
+
+// Job - action on an instance triggered by Cron. With Window only, the job runs when the window opens;
+// with both, cron matches outside the window are deferred to the next window.
+type Job struct {
+	Name     string
+	Instance string
+	Action   Action
+	Cron     *Cron
+	Window   *Window
+}
+
+// Next - returns the next run time after t, zero when there is none.
+func (j *Job) Next(t time.Time) time.Time {
+	if j.Cron == nil {
+		return j.Window.Start.Next(t)
+	}
+
+	next := j.Cron.Next(t)
+	if next.IsZero() || j.Window == nil || j.Window.contains(next) {
+		return next
+	}
+
+	return j.Window.Start.Next(next)
+}
+
+type jobState struct {
+	Job
+
+	next      time.Time
+	lastRun   time.Time
+	lastError string
+}
+
+// Scheduler - runs jobs on their core instance targets.
+type Scheduler struct {
+	targets map[string]Target
+	logger  *slog.Logger
+	events  domain.EventPublisher
+
+	mu   sync.Mutex
+	jobs []*jobState
+	done context.CancelFunc
+}
+
+func New(targets map[string]Target, logger *slog.Logger, pub domain.EventPublisher) *Scheduler {
+	return &Scheduler{
+		targets: targets,
+		logger:  logger,
+		events:  pub,
+	}
+}
+
+// Add - registers job, must be called before Start.
+func (s *Scheduler) Add(job Job) error {
+	if job.Cron == nil && job.Window == nil {
+		return fmt.Errorf("job %q: neither cron nor window set", job.Name)
+	}
+
+	if _, ok := s.targets[job.Instance]; !ok {
+		return fmt.Errorf("job %q: unknown instance %q", job.Name, job.Instance)
+	}
+
+	if _, err := ParseAction(string(job.Action)); err != nil {
+		return fmt.Errorf("job %q: %w", job.Name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, j := range s.jobs {
+		if j.Name == job.Name {
+			return fmt.Errorf("duplicate job name %q", job.Name)
+		}
+	}
+
+	s.jobs = append(s.jobs, &jobState{Job: job})
+
+	return nil
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.done = cancel
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, j := range s.jobs {
+		go s.loop(ctx, j)
+	}
+}
+
+func (s *Scheduler) Stop() {
+	if s.done != nil {
+		s.done()
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, j *jobState) {
+	log := s.logger.With("job", j.Name, "instance", j.Instance)
+
+	for {
+		next := j.Next(time.Now())
+
+		s.mu.Lock()
+		j.next = next
+		s.mu.Unlock()
+
+		if next.IsZero() {
+			log.Warn("scheduled job will never run again")
+			return
+		}
+
+		log.Debug("scheduled job armed", "next", next)
+
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.run(log, j)
+	}
+}
+
+func (s *Scheduler) run(log *slog.Logger, j *jobState) {
+	target := s.targets[j.Instance]
+
+	var err error
+	switch j.Action {
+	case ActionRestart:
+		err = target.Restart()
+	case ActionApply:
+		err = target.ApplyConfig()
+	case ActionRotate:
+		err = target.RotateJournals()
+	}
+
+	s.mu.Lock()
+	j.lastRun = time.Now()
+	j.lastError = ""
+	if err != nil {
+		j.lastError = err.Error()
+	}
+	s.mu.Unlock()
+
+	msg := fmt.Sprintf("scheduled %s by job %q", j.Action, j.Name)
+	if err != nil {
+		log.Error("scheduled job failed", "action", j.Action, "error", err)
+		msg += ": " + err.Error()
+	} else {
+		log.Info("scheduled job done", "action", j.Action)
+	}
+
+	ev := domain.NewEvent(domain.EventScheduled, msg)
+	ev.Instance = j.Instance
+	s.events.Publish(ev)
+}
+
+// List - returns jobs with their next and last run times.
+func (s *Scheduler) List() []domain.ScheduledJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]domain.ScheduledJob, 0, len(s.jobs))
+
+	for _, j := range s.jobs {
+		sj := domain.ScheduledJob{
+			Name:      j.Name,
+			Instance:  j.Instance,
+			Action:    string(j.Action),
+			NextRun:   j.next,
+			LastRun:   j.lastRun,
+			LastError: j.lastError,
+		}
+
+		if j.Cron != nil {
+			sj.Cron = j.Cron.String()
+		}
+
+		if j.Window != nil {
+			sj.Window = j.Window.Name
+			sj.WindowCron = j.Window.Start.String()
+			sj.WindowDuration = j.Window.Duration
+		}
+
+		if sj.NextRun.IsZero() {
+			sj.NextRun = j.Next(time.Now())
+		}
+
+		list = append(list, sj)
+	}
+
+	return list
+}
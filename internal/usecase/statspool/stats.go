@@ -2,6 +2,7 @@ package statspool
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"sync"
 	"time"
@@ -17,17 +18,19 @@ type StatsPool struct {
 	statsProvider StatsProvider
 	pollInterval  time.Duration
 	logger        *slog.Logger
+	events        domain.EventPublisher
 
 	mu    sync.RWMutex
 	cache []domain.StatsSnapshot
 	done  context.CancelFunc
 }
 
-func NewStatsPool(stats StatsProvider, interval time.Duration, logger *slog.Logger) *StatsPool {
+func NewStatsPool(stats StatsProvider, interval time.Duration, logger *slog.Logger, pub domain.EventPublisher) *StatsPool {
 	return &StatsPool{
 		statsProvider: stats,
 		pollInterval:  interval,
 		logger:        logger,
+		events:        pub,
 	}
 }
 
@@ -88,4 +91,8 @@ func (p *StatsPool) collect(ctx context.Context) {
 	p.mu.Unlock()
 
 	p.logger.Debug("collected stats", "count", len(snapshots))
+	p.events.Publish(domain.NewEvent(
+		domain.EventStatsCollected,
+		fmt.Sprintf("collected %d stats snapshots", len(snapshots)),
+	))
 }